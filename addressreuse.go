@@ -0,0 +1,116 @@
+package litrpcclient
+
+import (
+	"context"
+	"sync"
+)
+
+// AddressReuseKind describes how an address was reused
+type AddressReuseKind int
+
+const (
+	// AddressSentToAgain means Send was asked to pay an address it paid before
+	AddressSentToAgain AddressReuseKind = iota
+	// AddressSentToOwn means Send was asked to pay an address our own wallet issued
+	AddressSentToOwn
+	// AddressReceivedMultiple means one of our addresses holds more than one unspent output
+	AddressReceivedMultiple
+)
+
+// AddressReuseWarning describes a reused address
+type AddressReuseWarning struct {
+	Kind AddressReuseKind
+	// Address is the reused address. Empty for AddressReceivedMultiple, where
+	// the address is identified by KeyPath.
+	Address string
+	// KeyPath is the wallet's derivation path of the address, for AddressReceivedMultiple
+	KeyPath string
+	// Count is the number of times the address was used
+	Count int
+}
+
+// WithAddressReuseHandler makes the client call [handler] whenever it detects
+// reuse of an address. Sends are not blocked, the handler is only informed.
+func WithAddressReuseHandler(handler func(AddressReuseWarning)) Option {
+	return func(o *clientOptions) {
+		o.addressReuseHandler = handler
+	}
+}
+
+// addressTracker keeps the addresses we issued and the ones we sent to
+type addressTracker struct {
+	mtx    sync.Mutex
+	issued map[string]bool
+	sentTo map[string]int
+}
+
+// issue records addresses handed out by the wallet
+func (t *addressTracker) issue(addresses []string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.issued == nil {
+		t.issued = make(map[string]bool)
+	}
+	for _, adr := range addresses {
+		t.issued[adr] = true
+	}
+}
+
+// send records a payment to [address] and returns the warnings it triggers
+func (t *addressTracker) send(address string) []AddressReuseWarning {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.sentTo == nil {
+		t.sentTo = make(map[string]int)
+	}
+	t.sentTo[address]++
+
+	var warnings []AddressReuseWarning
+	if t.issued[address] {
+		warnings = append(warnings, AddressReuseWarning{Kind: AddressSentToOwn, Address: address, Count: t.sentTo[address]})
+	}
+	if t.sentTo[address] > 1 {
+		warnings = append(warnings, AddressReuseWarning{Kind: AddressSentToAgain, Address: address, Count: t.sentTo[address]})
+	}
+	return warnings
+}
+
+// warnAddressReuse passes warnings to the handler, if one is configured
+func (c *LitRpcClient) warnAddressReuse(warnings []AddressReuseWarning) {
+	if c.opts.addressReuseHandler == nil {
+		return
+	}
+	for _, w := range warnings {
+		c.opts.addressReuseHandler(w)
+	}
+}
+
+// CheckAddressReuse looks for addresses of our wallet that received more than
+// one payment that is still unspent, and returns (and reports to the handler set
+// with WithAddressReuseHandler) a warning for each.
+func (c *LitRpcClient) CheckAddressReuse() ([]AddressReuseWarning, error) {
+	return c.CheckAddressReuseCtx(context.Background())
+}
+
+// CheckAddressReuseCtx is like CheckAddressReuse, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) CheckAddressReuseCtx(ctx context.Context) ([]AddressReuseWarning, error) {
+	utxos, err := c.ListUtxosCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	count := make(map[string]int)
+	for _, utxo := range utxos {
+		count[utxo.KeyPath]++
+	}
+
+	var warnings []AddressReuseWarning
+	for _, utxo := range utxos {
+		if count[utxo.KeyPath] > 1 {
+			warnings = append(warnings, AddressReuseWarning{Kind: AddressReceivedMultiple, KeyPath: utxo.KeyPath, Count: count[utxo.KeyPath]})
+			// Only warn once per address
+			count[utxo.KeyPath] = 0
+		}
+	}
+	c.warnAddressReuse(warnings)
+	return warnings, nil
+}
@@ -0,0 +1,206 @@
+package litrpcclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// NodeBalance is the balance of a coin type on a node of an Aggregator
+type NodeBalance struct {
+	Node string
+	litrpc.CoinBalReply
+}
+
+// NodeChannel is a channel of a node of an Aggregator
+type NodeChannel struct {
+	Node string
+	litrpc.ChannelInfo
+}
+
+// NodeContract is a contract of a node of an Aggregator
+type NodeContract struct {
+	Node     string
+	Contract *lnutil.DlcContract
+}
+
+// NodeErrors holds the errors of the nodes of an Aggregator that could not be
+// queried, by node name
+type NodeErrors map[string]error
+
+func (e NodeErrors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("%s: %v", name, e[name])
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Aggregator queries a fleet of nodes concurrently and merges the results,
+// labelling every item with the name of the node it came from. When some nodes
+// fail, the results of the other nodes are returned together with a
+// NodeErrors describing the failures.
+type Aggregator struct {
+	mtx     sync.Mutex
+	clients map[string]*LitRpcClient
+}
+
+// NewAggregator creates an Aggregator for [clients], by node name
+func NewAggregator(clients map[string]*LitRpcClient) *Aggregator {
+	a := &Aggregator{clients: make(map[string]*LitRpcClient, len(clients))}
+	for name, client := range clients {
+		a.clients[name] = client
+	}
+	return a
+}
+
+// Add adds [client] to the fleet as node [name], replacing any node of that name
+func (a *Aggregator) Add(name string, client *LitRpcClient) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.clients[name] = client
+}
+
+// Remove removes node [name] from the fleet. It does not close its client.
+func (a *Aggregator) Remove(name string) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	delete(a.clients, name)
+}
+
+// Nodes returns the names of the nodes in the fleet, sorted
+func (a *Aggregator) Nodes() []string {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	names := make([]string, 0, len(a.clients))
+	for name := range a.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Balances returns the balances of all nodes, ordered by node
+func (a *Aggregator) Balances(ctx context.Context) ([]NodeBalance, error) {
+	var mtx sync.Mutex
+	var all []NodeBalance
+	err := a.each(func(name string, c *LitRpcClient) error {
+		balances, err := c.ListBalancesCtx(ctx)
+		if err != nil {
+			return err
+		}
+		mtx.Lock()
+		defer mtx.Unlock()
+		for _, b := range balances {
+			all = append(all, NodeBalance{name, b})
+		}
+		return nil
+	})
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Node < all[j].Node })
+	return all, err
+}
+
+// Channels returns the channels of all nodes, ordered by node
+func (a *Aggregator) Channels(ctx context.Context) ([]NodeChannel, error) {
+	var mtx sync.Mutex
+	var all []NodeChannel
+	err := a.each(func(name string, c *LitRpcClient) error {
+		channels, err := c.ListChannelsCtx(ctx)
+		if err != nil {
+			return err
+		}
+		mtx.Lock()
+		defer mtx.Unlock()
+		for _, ch := range channels {
+			all = append(all, NodeChannel{name, ch})
+		}
+		return nil
+	})
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Node < all[j].Node })
+	return all, err
+}
+
+// Contracts returns the contracts of all nodes, ordered by node
+func (a *Aggregator) Contracts(ctx context.Context) ([]NodeContract, error) {
+	var mtx sync.Mutex
+	var all []NodeContract
+	err := a.each(func(name string, c *LitRpcClient) error {
+		contracts, err := c.ListContractsCtx(ctx)
+		if err != nil {
+			return err
+		}
+		mtx.Lock()
+		defer mtx.Unlock()
+		for _, contract := range contracts {
+			all = append(all, NodeContract{name, contract})
+		}
+		return nil
+	})
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Node < all[j].Node })
+	return all, err
+}
+
+// TotalBalances sums [balances] per coin type over all nodes. The sync height
+// of a total is the lowest of the nodes, and its fee rate is not set.
+func TotalBalances(balances []NodeBalance) []litrpc.CoinBalReply {
+	var totals []litrpc.CoinBalReply
+	byCoin := make(map[uint32]int)
+	for _, b := range balances {
+		i, ok := byCoin[b.CoinType]
+		if !ok {
+			i = len(totals)
+			byCoin[b.CoinType] = i
+			totals = append(totals, litrpc.CoinBalReply{CoinType: b.CoinType, SyncHeight: b.SyncHeight})
+		}
+		t := &totals[i]
+		t.ChanTotal += b.ChanTotal
+		t.TxoTotal += b.TxoTotal
+		t.MatureWitty += b.MatureWitty
+		if b.SyncHeight < t.SyncHeight {
+			t.SyncHeight = b.SyncHeight
+		}
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].CoinType < totals[j].CoinType })
+	return totals
+}
+
+// each runs [fn] for every node concurrently and collects the errors
+func (a *Aggregator) each(fn func(name string, c *LitRpcClient) error) error {
+	a.mtx.Lock()
+	clients := make(map[string]*LitRpcClient, len(a.clients))
+	for name, client := range a.clients {
+		clients[name] = client
+	}
+	a.mtx.Unlock()
+
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	errs := make(NodeErrors)
+	for name, client := range clients {
+		wg.Add(1)
+		go func(name string, client *LitRpcClient) {
+			defer wg.Done()
+			err := fn(name, client)
+			if err != nil {
+				mtx.Lock()
+				errs[name] = err
+				mtx.Unlock()
+			}
+		}(name, client)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
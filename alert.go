@@ -0,0 +1,314 @@
+package litrpcclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// Alert is raised when an AlertRule starts firing, and again with Resolved
+// set when it stops
+type Alert struct {
+	Rule     string    `json:"rule"`
+	Message  string    `json:"message"`
+	Resolved bool      `json:"resolved"`
+	Time     time.Time `json:"time"`
+}
+
+// AlertRule is a condition on the state of a node that an AlertEngine checks
+// on every Mirror refresh. Rules are evaluated from a single goroutine, so
+// they can keep state between evaluations.
+type AlertRule interface {
+	// Name identifies the rule in its alerts
+	Name() string
+	// Evaluate returns whether the rule fires for [snapshot], and a message
+	// describing the problem when it does
+	Evaluate(snapshot *MirrorSnapshot) (message string, firing bool)
+}
+
+// ThresholdRule fires while [value] of the snapshot is below [limit] (or
+// above it, when [below] is false)
+func ThresholdRule(name string, value func(*MirrorSnapshot) float64, below bool, limit float64) AlertRule {
+	return &thresholdRule{name: name, value: value, below: below, limit: limit}
+}
+
+type thresholdRule struct {
+	name  string
+	value func(*MirrorSnapshot) float64
+	below bool
+	limit float64
+}
+
+func (r *thresholdRule) Name() string {
+	return r.name
+}
+
+func (r *thresholdRule) Evaluate(snapshot *MirrorSnapshot) (string, bool) {
+	v := r.value(snapshot)
+	if r.below && v < r.limit {
+		return fmt.Sprintf("%s: %v is below %v", r.name, v, r.limit), true
+	}
+	if !r.below && v > r.limit {
+		return fmt.Sprintf("%s: %v is above %v", r.name, v, r.limit), true
+	}
+	return "", false
+}
+
+// AbsenceRule fires when [present] has been false for all snapshots of the
+// last [period]
+func AbsenceRule(name string, present func(*MirrorSnapshot) bool, period time.Duration) AlertRule {
+	return &absenceRule{name: name, present: present, period: period}
+}
+
+type absenceRule struct {
+	name    string
+	present func(*MirrorSnapshot) bool
+	period  time.Duration
+	since   time.Time
+}
+
+func (r *absenceRule) Name() string {
+	return r.name
+}
+
+func (r *absenceRule) Evaluate(snapshot *MirrorSnapshot) (string, bool) {
+	if r.present(snapshot) {
+		r.since = time.Time{}
+		return "", false
+	}
+	if r.since.IsZero() {
+		r.since = snapshot.Updated
+	}
+	absent := snapshot.Updated.Sub(r.since)
+	if absent < r.period {
+		return "", false
+	}
+	return fmt.Sprintf("%s: absent for %s", r.name, absent.Round(time.Second)), true
+}
+
+// RateOfChangeRule fires when [value] of the snapshot changed by more than
+// [maxChange] (in either direction) within [window]
+func RateOfChangeRule(name string, value func(*MirrorSnapshot) float64, maxChange float64, window time.Duration) AlertRule {
+	return &rateRule{name: name, value: value, maxChange: maxChange, window: window}
+}
+
+type rateRule struct {
+	name      string
+	value     func(*MirrorSnapshot) float64
+	maxChange float64
+	window    time.Duration
+	history   []ratePoint
+}
+
+type ratePoint struct {
+	t time.Time
+	v float64
+}
+
+func (r *rateRule) Name() string {
+	return r.name
+}
+
+func (r *rateRule) Evaluate(snapshot *MirrorSnapshot) (string, bool) {
+	v := r.value(snapshot)
+	r.history = append(r.history, ratePoint{snapshot.Updated, v})
+	cutoff := snapshot.Updated.Add(-r.window)
+	for len(r.history) > 1 && r.history[0].t.Before(cutoff) {
+		r.history = r.history[1:]
+	}
+	change := v - r.history[0].v
+	if change > r.maxChange || -change > r.maxChange {
+		return fmt.Sprintf("%s: changed by %v within %s", r.name, change, r.window), true
+	}
+	return "", false
+}
+
+// ChannelBalanceRule fires while any open channel has a balance of less
+// than [amount] satoshi on our side
+func ChannelBalanceRule(name string, amount int64) AlertRule {
+	return &filterRule{name: name, check: func(snapshot *MirrorSnapshot) (string, bool) {
+		low := filterChannels(append(snapshot.Channels[:0:0], snapshot.Channels...), []ChannelFilter{OpenChannels, ChannelsBalanceBelow(amount)})
+		if len(low) == 0 {
+			return "", false
+		}
+		return fmt.Sprintf("%s: %d channel(s) below %d satoshi, first is channel %d with %d", name, len(low), amount, low[0].CIdx, low[0].MyBalance), true
+	}}
+}
+
+// UnsettledContractRule fires while an active contract is still not settled
+// [grace] after its settlement time, which usually means the oracle did not
+// publish a value
+func UnsettledContractRule(name string, grace time.Duration) AlertRule {
+	return &filterRule{name: name, check: func(snapshot *MirrorSnapshot) (string, bool) {
+		overdue := ContractsSettlingBetween(time.Unix(0, 0), snapshot.Updated.Add(-grace))
+		for _, contract := range snapshot.Contracts {
+			if contract.Status == lnutil.ContractStatusActive && overdue(contract) {
+				return fmt.Sprintf("%s: contract %d is not settled %s after its settlement time", name, contract.Idx, grace), true
+			}
+		}
+		return "", false
+	}}
+}
+
+type filterRule struct {
+	name  string
+	check func(*MirrorSnapshot) (string, bool)
+}
+
+func (r *filterRule) Name() string {
+	return r.name
+}
+
+func (r *filterRule) Evaluate(snapshot *MirrorSnapshot) (string, bool) {
+	return r.check(snapshot)
+}
+
+// Notifier delivers alerts to an operator
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// NotifierFunc is a function that implements Notifier
+type NotifierFunc func(ctx context.Context, alert Alert) error
+
+// Notify calls f
+func (f NotifierFunc) Notify(ctx context.Context, alert Alert) error {
+	return f(ctx, alert)
+}
+
+// WebhookNotifier posts alerts as JSON to URL
+type WebhookNotifier struct {
+	URL string
+	// HTTPClient is the client used for the requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+// Notify posts [alert] to the webhook
+func (n *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	b, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, n.URL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	httpClient := n.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s: %s", n.URL, resp.Status)
+	}
+	return nil
+}
+
+// Mailer sends an email to the operator, for instance over SMTP
+type Mailer interface {
+	SendMail(ctx context.Context, subject, body string) error
+}
+
+// EmailNotifier sends alerts as emails with Mailer
+type EmailNotifier struct {
+	Mailer Mailer
+}
+
+// Notify mails [alert]
+func (n *EmailNotifier) Notify(ctx context.Context, alert Alert) error {
+	subject := "[ALERT] " + alert.Rule
+	if alert.Resolved {
+		subject = "[RESOLVED] " + alert.Rule
+	}
+	return n.Mailer.SendMail(ctx, subject, fmt.Sprintf("%s\n\n%s", alert.Message, alert.Time.Format(time.RFC3339)))
+}
+
+// AlertEngine evaluates rules on every refresh of a Mirror, and notifies
+// when a rule starts or stops firing. Failed notifications are reported to
+// the client's async error handler.
+type AlertEngine struct {
+	mirror    *Mirror
+	notifiers []Notifier
+
+	mtx    sync.Mutex
+	rules  []AlertRule
+	firing map[string]bool
+}
+
+// NewAlertEngine creates an AlertEngine that evaluates the snapshots of
+// [mirror] and sends alerts to [notifiers]. Call Run to start it.
+func NewAlertEngine(mirror *Mirror, notifiers ...Notifier) *AlertEngine {
+	return &AlertEngine{
+		mirror:    mirror,
+		notifiers: notifiers,
+		firing:    make(map[string]bool),
+	}
+}
+
+// AddRule adds [rule] to the rules that are evaluated
+func (e *AlertEngine) AddRule(rule AlertRule) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.rules = append(e.rules, rule)
+}
+
+// Firing returns the names of the rules that are currently firing
+func (e *AlertEngine) Firing() []string {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	var names []string
+	for name, firing := range e.firing {
+		if firing {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Run evaluates the rules on every snapshot of the mirror until [ctx] is
+// cancelled. The mirror has to be running.
+func (e *AlertEngine) Run(ctx context.Context) {
+	for snapshot := range e.mirror.Subscribe(ctx) {
+		e.Evaluate(ctx, snapshot)
+	}
+}
+
+// Evaluate evaluates the rules on [snapshot] once, and sends the alerts of
+// the rules that started or stopped firing
+func (e *AlertEngine) Evaluate(ctx context.Context, snapshot *MirrorSnapshot) {
+	e.mtx.Lock()
+	var alerts []Alert
+	for _, rule := range e.rules {
+		message, firing := rule.Evaluate(snapshot)
+		name := rule.Name()
+		if firing == e.firing[name] {
+			continue
+		}
+		e.firing[name] = firing
+		if !firing {
+			message = name + ": resolved"
+		}
+		alerts = append(alerts, Alert{Rule: name, Message: message, Resolved: !firing, Time: snapshot.Updated})
+	}
+	e.mtx.Unlock()
+
+	for _, alert := range alerts {
+		for _, n := range e.notifiers {
+			err := n.Notify(ctx, alert)
+			if err != nil {
+				e.mirror.client.asyncError(fmt.Errorf("alert %s: %w", alert.Rule, err))
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package litrpcclient
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/mit-dci/lit/qln"
+)
+
+// ArchiveStates writes the states from StateDump that are more than [keep]
+// states behind the latest state of their channel to [w], as gzip-compressed
+// JSON (one state per line). It returns the number of states archived. LIT has
+// no RPC to prune states, so the archived states remain on the node; the archive
+// allows verification jobs to work from the archive plus the recent states.
+func (c *LitRpcClient) ArchiveStates(w io.Writer, keep uint64) (int, error) {
+	return c.ArchiveStatesCtx(context.Background(), w, keep)
+}
+
+// ArchiveStatesCtx is like ArchiveStates, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ArchiveStatesCtx(ctx context.Context, w io.Writer, keep uint64) (int, error) {
+	states, err := c.StateDumpCtx(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	latest := make(map[[20]byte]uint64)
+	for _, tx := range states {
+		if tx.Idx > latest[tx.Pkh] {
+			latest[tx.Pkh] = tx.Idx
+		}
+	}
+
+	zw := gzip.NewWriter(w)
+	enc := json.NewEncoder(zw)
+	n := 0
+	for _, tx := range states {
+		if tx.Idx+keep >= latest[tx.Pkh] {
+			continue
+		}
+		err = enc.Encode(tx)
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, zw.Close()
+}
+
+// ReadStateArchive reads the states from an archive written by ArchiveStates
+func ReadStateArchive(r io.Reader) ([]qln.JusticeTx, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var states []qln.JusticeTx
+	dec := json.NewDecoder(zr)
+	for {
+		var tx qln.JusticeTx
+		err = dec.Decode(&tx)
+		if err == io.EOF {
+			return states, nil
+		}
+		if err != nil {
+			return states, err
+		}
+		states = append(states, tx)
+	}
+}
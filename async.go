@@ -0,0 +1,163 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// PendingCall is an RPC call started with CallAsync
+type PendingCall struct {
+	Method string
+
+	client *LitRpcClient
+	done   chan struct{}
+	raw    json.RawMessage
+	err    error
+}
+
+// CallAsync starts a call of RPC [method] with [args], and returns without
+// waiting for the reply. The call is completed by the loop that receives
+// the replies on the client's connection, so fanning out many independent
+// calls (like GetContract for every contract) doesn't take a goroutine per
+// call. The call is abandoned when [ctx] is done or the timeout set with
+// WithTimeout passes; calls made with the same [ctx] share one goroutine
+// that watches it.
+//
+//	calls := make([]*PendingCall, len(indexes))
+//	for i, idx := range indexes {
+//		calls[i] = c.CallAsync(ctx, "LitRPC.GetContract", &litrpc.GetContractArgs{Idx: idx})
+//	}
+//	for _, call := range calls {
+//		reply := new(litrpc.GetContractReply)
+//		err := call.Result(reply)
+//		...
+//	}
+func (c *LitRpcClient) CallAsync(ctx context.Context, method string, args interface{}) *PendingCall {
+	p := &PendingCall{Method: method, client: c, done: make(chan struct{})}
+	call, err := c.startCall(ctx, method, args, &p.raw)
+	if err != nil {
+		p.err = err
+		close(p.done)
+		return p
+	}
+
+	// stops stops the timer and the watch of ctx once the call completed
+	var mtx sync.Mutex
+	var stops []func()
+	completed := false
+	cancel := c.conn.Go(call.ctx, method, args, call.target(), func(err error) {
+		mtx.Lock()
+		completed = true
+		pendingStops := stops
+		mtx.Unlock()
+		for _, stop := range pendingStops {
+			stop()
+		}
+		p.err = call.finish(err)
+		close(p.done)
+	})
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	if completed {
+		return p
+	}
+	if timeout := c.callTimeout(call.ctx, method); timeout > 0 {
+		timer := time.AfterFunc(timeout, func() { cancel(context.DeadlineExceeded) })
+		stops = append(stops, func() { timer.Stop() })
+	}
+	stops = append(stops, c.asyncWatch.add(call.ctx, func() { cancel(call.ctx.Err()) }))
+	return p
+}
+
+// Done returns a channel that's closed when the reply arrived or the call
+// failed
+func (p *PendingCall) Done() <-chan struct{} {
+	return p.done
+}
+
+// Result waits for the call to complete, and decodes its reply into [reply].
+// It returns the error of the call if it failed.
+func (p *PendingCall) Result(reply interface{}) error {
+	<-p.done
+	if p.err != nil {
+		return p.err
+	}
+	err := json.Unmarshal(p.raw, reply)
+	if err != nil {
+		return err
+	}
+	return p.client.runDecodeHooks(p.Method, reply)
+}
+
+// doneWatch calls functions when their context is done. Functions added
+// with contexts that share a Done channel share one goroutine, which ends
+// when the context is done or all its functions were removed.
+type doneWatch struct {
+	mtx    sync.Mutex
+	groups map[<-chan struct{}]*doneGroup
+}
+
+// doneGroup holds the functions waiting for one Done channel
+type doneGroup struct {
+	funcs map[uint64]func()
+	next  uint64
+	// empty is closed when the last function is removed
+	empty chan struct{}
+}
+
+// add makes the watch call [f] when [ctx] is done, and returns a function
+// that removes it again
+func (w *doneWatch) add(ctx context.Context, f func()) (remove func()) {
+	done := ctx.Done()
+	if done == nil {
+		return func() {}
+	}
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if w.groups == nil {
+		w.groups = make(map[<-chan struct{}]*doneGroup)
+	}
+	g := w.groups[done]
+	if g == nil {
+		g = &doneGroup{funcs: make(map[uint64]func()), empty: make(chan struct{})}
+		w.groups[done] = g
+		go w.watch(done, g)
+	}
+	id := g.next
+	g.next++
+	g.funcs[id] = f
+	return func() {
+		w.mtx.Lock()
+		defer w.mtx.Unlock()
+		if _, ok := g.funcs[id]; !ok {
+			return
+		}
+		delete(g.funcs, id)
+		if len(g.funcs) == 0 {
+			delete(w.groups, done)
+			close(g.empty)
+		}
+	}
+}
+
+// watch calls the functions of [g] when [done] is closed
+func (w *doneWatch) watch(done <-chan struct{}, g *doneGroup) {
+	select {
+	case <-done:
+	case <-g.empty:
+		return
+	}
+	w.mtx.Lock()
+	if w.groups[done] == g {
+		delete(w.groups, done)
+	}
+	funcs := g.funcs
+	g.funcs = nil
+	w.mtx.Unlock()
+	for _, f := range funcs {
+		f()
+	}
+}
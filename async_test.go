@@ -0,0 +1,135 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// heldNode is a JSON-RPC node that holds on to the requests it reads until
+// they are answered with reply
+type heldNode struct {
+	codec    rpc.ServerCodec
+	requests chan rpc.Request
+}
+
+// newHeldNodeClient returns a client on a websocket style transport, and the
+// node at the other end of its connection
+func newHeldNodeClient(t *testing.T, opts ...Option) (*LitRpcClient, *rpcTransport, *heldNode) {
+	clientConn, nodeConn := net.Pipe()
+	node := &heldNode{codec: jsonrpc.NewServerCodec(nodeConn), requests: make(chan rpc.Request, 1000)}
+	go func() {
+		defer close(node.requests)
+		for {
+			var req rpc.Request
+			if node.codec.ReadRequestHeader(&req) != nil || node.codec.ReadRequestBody(nil) != nil {
+				return
+			}
+			node.requests <- req
+		}
+	}()
+	transport := newRPCTransport(jsonrpc.NewClientCodec(clientConn))
+	c := new(LitRpcClient)
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+	c.conn = transport
+	t.Cleanup(func() { c.Close(); nodeConn.Close() })
+	return c, transport, node
+}
+
+// reply answers request [req] with [result]
+func (n *heldNode) reply(req rpc.Request, result interface{}) {
+	n.codec.WriteResponse(&rpc.Response{ServiceMethod: req.ServiceMethod, Seq: req.Seq}, result)
+}
+
+// pendingCalls returns the number of calls waiting for a reply
+func (t *rpcTransport) pendingCalls() int {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return len(t.pending)
+}
+
+func TestCallAsyncFanOutWithoutGoroutinePerCall(t *testing.T) {
+	c, transport, node := newHeldNodeClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const n = 500
+	baseline := runtime.NumGoroutine()
+	calls := make([]*PendingCall, n)
+	for i := range calls {
+		calls[i] = c.CallAsync(ctx, "LitRPC.GetContract", i)
+	}
+	requests := make([]rpc.Request, n)
+	for i := range requests {
+		requests[i] = <-node.requests
+	}
+	// The calls share the watch of ctx
+	if extra := runtime.NumGoroutine() - baseline; extra > 5 {
+		t.Fatalf("%d goroutines for %d pending calls", extra, n)
+	}
+
+	// Answer in reverse order
+	for i := n - 1; i >= 0; i-- {
+		node.reply(requests[i], i)
+	}
+	for i, call := range calls {
+		var reply int
+		err := call.Result(&reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reply != i {
+			t.Fatalf("call %d got the reply to call %d", i, reply)
+		}
+	}
+	if pending := transport.pendingCalls(); pending != 0 {
+		t.Fatalf("%d calls still pending", pending)
+	}
+}
+
+func TestCallAsyncCancelAndTimeout(t *testing.T) {
+	c, transport, node := newHeldNodeClient(t, WithMethodTimeout("LitRPC.Slow", 50*time.Millisecond))
+
+	call := c.CallAsync(context.Background(), "LitRPC.Slow", nil)
+	<-node.requests
+	select {
+	case <-call.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("call didn't time out")
+	}
+	if err := call.Result(new(int)); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("got %v, want ErrTimeout", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := []*PendingCall{
+		c.CallAsync(ctx, "LitRPC.GetContract", 1),
+		c.CallAsync(ctx, "LitRPC.GetContract", 2),
+	}
+	<-node.requests
+	<-node.requests
+	cancel()
+	for _, call := range calls {
+		if err := call.Result(new(int)); !errors.Is(err, context.Canceled) {
+			t.Fatalf("got %v, want context.Canceled", err)
+		}
+	}
+	if pending := transport.pendingCalls(); pending != 0 {
+		t.Fatalf("%d calls still pending after timeout and cancel", pending)
+	}
+
+	// Calls in flight fail when the client is closed
+	call = c.CallAsync(context.Background(), "LitRPC.GetContract", 3)
+	<-node.requests
+	c.Close()
+	if err := call.Result(new(int)); !errors.Is(err, ErrClosed) {
+		t.Fatalf("got %v, want ErrClosed", err)
+	}
+}
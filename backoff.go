@@ -0,0 +1,107 @@
+package litrpcclient
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff decides how long to wait before retrying an operation that failed.
+// Reconnection (WithReconnectBackoff), reconnecting peers (PeerKeeper) and
+// polling the node (WithWatchBackoff) each take their own Backoff, so
+// deployments on metered or battery-powered connections can retry less
+// aggressively.
+type Backoff interface {
+	// Delay returns the time to wait before retry [attempt], which is 1 for
+	// the first retry after a failure
+	Delay(attempt int) time.Duration
+}
+
+// ExponentialBackoff waits Min before the first retry and doubles the delay
+// after every failed retry, up to Max if it is set. With Jitter, a random delay between
+// 50% and 100% of that is used, so clients that failed at the same time
+// don't all retry at once.
+type ExponentialBackoff struct {
+	Min    time.Duration
+	Max    time.Duration
+	Jitter bool
+}
+
+// Delay implements Backoff
+func (b ExponentialBackoff) Delay(attempt int) time.Duration {
+	delay := b.Min
+	for i := 1; i < attempt && (b.Max == 0 || delay < b.Max); i++ {
+		if delay > math.MaxInt64/2 {
+			delay = math.MaxInt64
+			break
+		}
+		delay *= 2
+	}
+	if b.Max != 0 && delay > b.Max {
+		delay = b.Max
+	}
+	if b.Jitter {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+	return delay
+}
+
+// ConstantBackoff waits the same time before every retry
+type ConstantBackoff time.Duration
+
+// Delay implements Backoff
+func (b ConstantBackoff) Delay(attempt int) time.Duration {
+	return time.Duration(b)
+}
+
+// BackoffFunc adapts a function to a Backoff
+type BackoffFunc func(attempt int) time.Duration
+
+// Delay implements Backoff
+func (f BackoffFunc) Delay(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// WithReconnectBackoff is like WithReconnect, but waits between attempts to
+// redial the node as decided by [backoff]
+func WithReconnectBackoff(backoff Backoff) Option {
+	return func(o *clientOptions) {
+		o.reconnectBackoff = backoff
+	}
+}
+
+// WithWatchBackoff sets the time the client waits before polling the node
+// again after a poll failed, in WatchMessages, WatchContracts, Mirror,
+// PeerKeeper and OfferExpiry. By default WatchMessages waits 5 seconds, and
+// the others poll at their usual interval.
+func WithWatchBackoff(backoff Backoff) Option {
+	return func(o *clientOptions) {
+		o.watchBackoff = backoff
+	}
+}
+
+// pollEvery calls [poll] right away and then every [interval], until [ctx] is
+// done. After a failed poll, it waits as decided by [backoff] instead, if it
+// is set.
+func pollEvery(ctx context.Context, interval time.Duration, backoff Backoff, poll func() error) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+	failures := 0
+	for {
+		delay := interval
+		if err := poll(); err != nil && backoff != nil {
+			failures++
+			delay = backoff.Delay(failures)
+		} else if err == nil {
+			failures = 0
+		}
+		timer.Reset(delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+	}
+}
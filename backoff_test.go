@@ -0,0 +1,44 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoffWithoutMax(t *testing.T) {
+	b := ExponentialBackoff{Min: time.Second}
+	if d := b.Delay(4); d != 8*time.Second {
+		t.Fatalf("delay %v without Max, want 8s", d)
+	}
+	if d := b.Delay(100); d <= 0 {
+		t.Fatalf("delay %v overflowed", d)
+	}
+	b.Max = 5 * time.Second
+	if d := b.Delay(4); d != 5*time.Second {
+		t.Fatalf("delay %v with Max 5s", d)
+	}
+}
+
+func TestPollEveryBacksOffAfterFailures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var attempts []int
+	backoff := BackoffFunc(func(attempt int) time.Duration {
+		attempts = append(attempts, attempt)
+		return time.Millisecond
+	})
+	polls := 0
+	pollEvery(ctx, time.Hour, backoff, func() error {
+		polls++
+		if polls == 4 {
+			cancel()
+			return nil
+		}
+		return errors.New("node down")
+	})
+	if polls != 4 || len(attempts) != 3 || attempts[2] != 3 {
+		t.Fatalf("%d polls, backoff attempts %v", polls, attempts)
+	}
+}
@@ -0,0 +1,101 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/qln"
+)
+
+// BreakSnapshot records a channel as it was right before it was broken, for
+// later analysis of disputes
+type BreakSnapshot struct {
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"`
+	// NodeLNAddress is the LN address of our node
+	NodeLNAddress string             `json:"nodeLNAddress"`
+	Channel       litrpc.ChannelInfo `json:"channel"`
+	// Peer is the peer of the channel, nil if it wasn't connected
+	Peer *qln.PeerInfo `json:"peer,omitempty"`
+	// States are the previous states of the channel known to the node
+	States []qln.JusticeTx `json:"states"`
+}
+
+// WithBreakSnapshots makes BreakChannel and BreakChannelWithReason write a
+// BreakSnapshot of the channel to a JSON file in directory [dir] before
+// breaking it. If the snapshot can't be taken, the channel is still broken and
+// the error is passed to the handler set with WithAsyncErrorHandler.
+func WithBreakSnapshots(dir string) Option {
+	return func(o *clientOptions) {
+		o.breakSnapshotDir = dir
+	}
+}
+
+// BreakChannelWithReason is like BreakChannel, and records [reason] in the
+// snapshot written when the client was created with WithBreakSnapshots
+func (c *LitRpcClient) BreakChannelWithReason(channelIndex uint32, reason string) error {
+	return c.BreakChannelWithReasonCtx(context.Background(), channelIndex, reason)
+}
+
+// BreakChannelWithReasonCtx is like BreakChannelWithReason, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) BreakChannelWithReasonCtx(ctx context.Context, channelIndex uint32, reason string) error {
+	if c.opts.breakSnapshotDir != "" {
+		err := c.writeBreakSnapshot(ctx, channelIndex, reason)
+		if err != nil {
+			c.asyncError(fmt.Errorf("break snapshot of channel %d: %w", channelIndex, err))
+		}
+	}
+	return c.breakChannel(ctx, channelIndex)
+}
+
+// TakeBreakSnapshot captures the current state of channel [channelIndex]
+func (c *LitRpcClient) TakeBreakSnapshot(ctx context.Context, channelIndex uint32, reason string) (*BreakSnapshot, error) {
+	channel, err := c.GetChannelCtx(ctx, channelIndex)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &BreakSnapshot{Time: time.Now(), Reason: reason, Channel: *channel}
+
+	snapshot.NodeLNAddress, err = c.GetLNAddressCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	peers, err := c.ListConnectionsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range peers {
+		if peers[i].PeerNumber == snapshot.Channel.PeerIdx {
+			snapshot.Peer = &peers[i]
+		}
+	}
+	states, err := c.StateDumpCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range states {
+		if tx.Pkh == snapshot.Channel.Pkh {
+			snapshot.States = append(snapshot.States, tx)
+		}
+	}
+	return snapshot, nil
+}
+
+// writeBreakSnapshot takes a snapshot of [channelIndex] and writes it to the
+// snapshot directory
+func (c *LitRpcClient) writeBreakSnapshot(ctx context.Context, channelIndex uint32, reason string) error {
+	snapshot, err := c.TakeBreakSnapshot(ctx, channelIndex, reason)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("break-%d-%d.json", channelIndex, snapshot.Time.Unix())
+	return writeFileAtomic(filepath.Join(c.opts.breakSnapshotDir, name), b, 0600)
+}
@@ -0,0 +1,196 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is returned by Send, Sweep, FundChannel, OfferContract
+// and AcceptContract on a client created with WithBudget, when the
+// operation would take the on-chain spend of the budget's window over its
+// limit
+var ErrBudgetExceeded = errors.New("on-chain budget exceeded")
+
+// BudgetSpend is an on-chain operation charged to a Budget
+type BudgetSpend struct {
+	Method string    `json:"method"`
+	Amount int64     `json:"amount"`
+	Fee    int64     `json:"fee"`
+	Time   time.Time `json:"time"`
+}
+
+// Budget caps the satoshi a client spends on-chain (amounts plus estimated
+// fees) within a rolling window, protecting against automation that runs
+// away. The spends are persisted, so restarting the process doesn't reset
+// the budget. Amounts of different coin types are added up as they are, so
+// use a budget for clients that transact in a single coin.
+type Budget struct {
+	// Limit is the most satoshi that can be spent within Window
+	Limit int64
+	// Window is the period over which spends are added up
+	Window time.Duration
+
+	path string
+
+	mtx    sync.Mutex
+	spends []BudgetSpend
+}
+
+// NewBudget creates a Budget of [limit] satoshi per [window], that persists
+// to the file at [path]. Previous spends are loaded from it.
+func NewBudget(path string, limit int64, window time.Duration) (*Budget, error) {
+	b := &Budget{Limit: limit, Window: window, path: path}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &b.spends)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// WithBudget makes the client charge its on-chain operations to [budget],
+// and refuse them with ErrBudgetExceeded when the budget is used up. Fees
+// are estimated like WithFeeGuard does.
+func WithBudget(budget *Budget) Option {
+	return func(o *clientOptions) {
+		o.budget = budget
+	}
+}
+
+// Spent returns the satoshi spent within the current window
+func (b *Budget) Spent() int64 {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.spent(time.Now())
+}
+
+// Remaining returns the satoshi that can still be spent within the current
+// window
+func (b *Budget) Remaining() int64 {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.Limit - b.spent(time.Now())
+}
+
+// Spends returns the spends within the current window
+func (b *Budget) Spends() []BudgetSpend {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.expire(time.Now())
+	return append([]BudgetSpend(nil), b.spends...)
+}
+
+// spent returns the sum of the spends after expiring the old ones
+func (b *Budget) spent(now time.Time) int64 {
+	b.expire(now)
+	var total int64
+	for _, s := range b.spends {
+		total += s.Amount + s.Fee
+	}
+	return total
+}
+
+// expire removes the spends that are older than the window
+func (b *Budget) expire(now time.Time) {
+	cutoff := now.Add(-b.Window)
+	i := 0
+	for i < len(b.spends) && b.spends[i].Time.Before(cutoff) {
+		i++
+	}
+	b.spends = b.spends[i:]
+}
+
+// charge records [spend] if it fits in the budget, and returns
+// ErrBudgetExceeded if it doesn't
+func (b *Budget) charge(spend BudgetSpend) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	spent := b.spent(spend.Time)
+	if spent+spend.Amount+spend.Fee > b.Limit {
+		return fmt.Errorf("%w: %s of %d (fee %d) with %d of %d spent in the last %s",
+			ErrBudgetExceeded, spend.Method, spend.Amount, spend.Fee, spent, b.Limit, b.Window)
+	}
+	b.spends = append(b.spends, spend)
+	err := b.save()
+	if err != nil {
+		b.spends = b.spends[:len(b.spends)-1]
+	}
+	return err
+}
+
+// refund removes [spend], for operations that failed
+func (b *Budget) refund(spend BudgetSpend) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	for i := len(b.spends) - 1; i >= 0; i-- {
+		if b.spends[i] == spend {
+			b.spends = append(b.spends[:i], b.spends[i+1:]...)
+			break
+		}
+	}
+	b.save()
+}
+
+// save writes the spends to the budget's file. Must be called with mtx held.
+func (b *Budget) save() error {
+	data, err := json.Marshal(b.spends)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(b.path, data, 0600)
+}
+
+// chargeBudget charges an on-chain operation of [method] of [amount]
+// satoshi, with a transaction of [size] vbytes of coin type [coinType]
+// (unknown if [known] is false), to the client's budget. It returns a
+// function to call with the result of the operation, that refunds the
+// charge if the node certainly didn't spend anything. Operations that fail
+// in other ways, like timeouts, stay charged.
+func (c *LitRpcClient) chargeBudget(ctx context.Context, method string, coinType uint32, known bool, size, amount int64) (func(error), error) {
+	budget := c.opts.budget
+	if budget == nil {
+		return func(error) {}, nil
+	}
+	spend := BudgetSpend{Method: method, Amount: amount, Time: time.Now()}
+	if known {
+		feeRate, err := c.GetFeeCtx(ctx, coinType)
+		if err != nil {
+			return nil, err
+		}
+		spend.Fee = feeRate * size
+	}
+	err := budget.charge(spend)
+	if err != nil {
+		return nil, err
+	}
+	return func(err error) {
+		if errors.Is(err, ErrServerRejected) || errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrReadOnly) {
+			budget.refund(spend)
+		}
+	}, nil
+}
+
+// chargeContract charges the funding of contract [contractIndex] by
+// [method] to the client's budget, see chargeBudget
+func (c *LitRpcClient) chargeContract(ctx context.Context, method string, contractIndex uint64) (func(error), error) {
+	if c.opts.budget == nil {
+		return func(error) {}, nil
+	}
+	contract, err := c.GetContractCtx(ctx, contractIndex)
+	if err != nil {
+		return nil, err
+	}
+	return c.chargeBudget(ctx, method, contract.CoinType, true, fundingTxSize, contract.OurFundingAmount)
+}
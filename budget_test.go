@@ -0,0 +1,89 @@
+package litrpcclient
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+func newTestBudget(t *testing.T) *Budget {
+	budget, err := NewBudget(filepath.Join(t.TempDir(), "budget.json"), 1000000, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return budget
+}
+
+func TestBudgetRefundsRejectedSend(t *testing.T) {
+	node := newFakeNode()
+	node.handle("LitRPC.Send", func(interface{}) (interface{}, error) {
+		return nil, errors.New("insufficient funds")
+	})
+	budget := newTestBudget(t)
+	c := newTestClient(node, WithBudget(budget))
+
+	_, err := c.Send("addr", 1000)
+	if !errors.Is(err, ErrServerRejected) {
+		t.Fatalf("got %v, want ErrServerRejected", err)
+	}
+	if spent := budget.Spent(); spent != 0 {
+		t.Fatalf("%d spent after a rejected send, want 0", spent)
+	}
+}
+
+func TestBudgetRefundsReadOnly(t *testing.T) {
+	budget := newTestBudget(t)
+	c := newTestClient(newFakeNode(), WithBudget(budget), WithReadOnly())
+
+	_, err := c.Send("addr", 1000)
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("got %v, want ErrReadOnly", err)
+	}
+	if spent := budget.Spent(); spent != 0 {
+		t.Fatalf("%d spent after a read-only refusal, want 0", spent)
+	}
+}
+
+func TestBudgetKeepsTimeoutsAndRefundsOpenCircuit(t *testing.T) {
+	node := &flakyNode{fakeNode: newFakeNode()}
+	node.setDown(true)
+	budget := newTestBudget(t)
+	c := newTestClient(node.fakeNode, WithBudget(budget), WithCircuitBreaker(1, time.Minute))
+	c.conn = node
+
+	// The node may have sent a transaction it didn't answer for
+	_, err := c.Send("addr", 1000)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("got %v, want ErrTimeout", err)
+	}
+	if spent := budget.Spent(); spent != 1000 {
+		t.Fatalf("%d spent after a timeout, want 1000", spent)
+	}
+
+	_, err = c.Send("addr", 2000)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, want ErrCircuitOpen", err)
+	}
+	if spent := budget.Spent(); spent != 1000 {
+		t.Fatalf("%d spent after a call the open circuit stopped, want 1000", spent)
+	}
+}
+
+func TestBudgetNotChargedForInvalidFundChannel(t *testing.T) {
+	node := newFakeNode()
+	node.reply("LitRPC.GetFee", litrpc.FeeReply{CurrentFee: 10})
+	budget := newTestBudget(t)
+	c := newTestClient(node, WithBudget(budget))
+
+	err := c.FundChannel(1, 1, 100000, 0, []byte(strings.Repeat("x", MaxDataSize+1)))
+	if !errors.Is(err, ErrDataTooLong) {
+		t.Fatalf("got %v, want ErrDataTooLong", err)
+	}
+	if spent := budget.Spent(); spent != 0 {
+		t.Fatalf("%d spent after invalid data, want 0", spent)
+	}
+}
@@ -0,0 +1,145 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+// ErrChannelNotFound is returned by GetChannel for a channel index the node
+// doesn't know
+var ErrChannelNotFound = errors.New("channel not found")
+
+// ChannelState describes where a channel is in its lifecycle
+type ChannelState int
+
+const (
+	// ChannelPending means the funding transaction is not confirmed yet
+	ChannelPending ChannelState = iota
+	// ChannelOpen means the funding transaction is confirmed and the channel can be used
+	ChannelOpen
+	// ChannelClosed means the channel was closed or broken
+	ChannelClosed
+)
+
+func (s ChannelState) String() string {
+	switch s {
+	case ChannelPending:
+		return "pending"
+	case ChannelOpen:
+		return "open"
+	}
+	return "closed"
+}
+
+// ChannelStatus is a channel together with its lifecycle state and the number
+// of confirmations of its funding transaction
+type ChannelStatus struct {
+	litrpc.ChannelInfo
+	State         ChannelState
+	Confirmations int32
+}
+
+// ListChannelStatuses returns all channels like ListChannels, but distinguishes
+// channels whose funding transaction is not yet confirmed (ChannelPending) and
+// includes the number of confirmations, based on the sync height of the channel's
+// coin type.
+func (c *LitRpcClient) ListChannelStatuses() ([]ChannelStatus, error) {
+	return c.ListChannelStatusesCtx(context.Background())
+}
+
+// ListChannelStatusesCtx is like ListChannelStatuses, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListChannelStatusesCtx(ctx context.Context) ([]ChannelStatus, error) {
+	channels, err := c.ListChannelsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := c.ListBalancesCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	heights := make(map[uint32]int32, len(balances))
+	for _, bal := range balances {
+		heights[bal.CoinType] = bal.SyncHeight
+	}
+
+	statuses := make([]ChannelStatus, len(channels))
+	for i, ch := range channels {
+		statuses[i].ChannelInfo = ch
+		if ch.Height > 0 && heights[ch.CoinType] >= ch.Height {
+			statuses[i].Confirmations = heights[ch.CoinType] - ch.Height + 1
+		}
+		switch {
+		case ch.Closed:
+			statuses[i].State = ChannelClosed
+		case statuses[i].Confirmations == 0:
+			statuses[i].State = ChannelPending
+		default:
+			statuses[i].State = ChannelOpen
+		}
+	}
+	return statuses, nil
+}
+
+// GetChannel returns the channel with index [channelIndex]. LIT has no RPC to
+// look up a single channel, so this lists the channels and picks the one
+// asked for. Returns ErrChannelNotFound if there is no such channel.
+func (c *LitRpcClient) GetChannel(channelIndex uint32) (*litrpc.ChannelInfo, error) {
+	return c.GetChannelCtx(context.Background(), channelIndex)
+}
+
+// GetChannelCtx is like GetChannel, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) GetChannelCtx(ctx context.Context, channelIndex uint32) (*litrpc.ChannelInfo, error) {
+	channels, err := c.ListChannelsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range channels {
+		if channels[i].CIdx == channelIndex {
+			return &channels[i], nil
+		}
+	}
+	return nil, ErrChannelNotFound
+}
+
+// ChannelFilter selects channels in ListChannelsFiltered and Mirror.Channels
+type ChannelFilter func(ch litrpc.ChannelInfo) bool
+
+// ChannelsWithPeer selects the channels with peer [peerIndex]
+func ChannelsWithPeer(peerIndex uint32) ChannelFilter {
+	return func(ch litrpc.ChannelInfo) bool {
+		return ch.PeerIdx == peerIndex
+	}
+}
+
+// ChannelsOfCoin selects the channels of coin type [coinType]
+func ChannelsOfCoin(coinType uint32) ChannelFilter {
+	return func(ch litrpc.ChannelInfo) bool {
+		return ch.CoinType == coinType
+	}
+}
+
+// OpenChannels selects the channels that are not closed
+func OpenChannels(ch litrpc.ChannelInfo) bool {
+	return !ch.Closed
+}
+
+// ClosedChannels selects the channels that are closed or broken
+func ClosedChannels(ch litrpc.ChannelInfo) bool {
+	return ch.Closed
+}
+
+// ListChannelsFiltered returns the channels that match all of [filters]
+func (c *LitRpcClient) ListChannelsFiltered(filters ...ChannelFilter) ([]litrpc.ChannelInfo, error) {
+	return c.ListChannelsFilteredCtx(context.Background(), filters...)
+}
+
+// ListChannelsFilteredCtx is like ListChannelsFiltered, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListChannelsFilteredCtx(ctx context.Context, filters ...ChannelFilter) ([]litrpc.ChannelInfo, error) {
+	channels, err := c.ListChannelsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterChannels(channels, filters), nil
+}
@@ -0,0 +1,76 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrUnknownTemplate is returned by OpenFromTemplate for a template name that
+// was not registered
+var ErrUnknownTemplate = errors.New("unknown channel template")
+
+// ChannelTemplate is a named set of channel parameters, so channels to many
+// peers can be opened the same way
+type ChannelTemplate struct {
+	Name        string `json:"name"`
+	CoinType    uint32 `json:"coinType"`
+	Capacity    int64  `json:"capacity"`
+	InitialSend int64  `json:"initialSend"`
+	// Data is attached to the channel's initial state, like a tag that
+	// identifies channels opened from the template
+	Data []byte `json:"data,omitempty"`
+	// FeePerByte, if not 0, is set as the fee rate of the coin type with
+	// SetFee before funding. The fee rate applies to all transactions of the
+	// node, not just this channel.
+	FeePerByte int64 `json:"feePerByte,omitempty"`
+}
+
+// channelTemplates holds the registered channel templates by name
+type channelTemplates struct {
+	mtx       sync.Mutex
+	templates map[string]ChannelTemplate
+}
+
+// RegisterChannelTemplates adds [templates] to the client, replacing
+// templates with the same name
+func (c *LitRpcClient) RegisterChannelTemplates(templates ...ChannelTemplate) {
+	c.templates.mtx.Lock()
+	defer c.templates.mtx.Unlock()
+	if c.templates.templates == nil {
+		c.templates.templates = make(map[string]ChannelTemplate)
+	}
+	for _, t := range templates {
+		c.templates.templates[t.Name] = t
+	}
+}
+
+// ChannelTemplate returns the registered template named [name]
+func (c *LitRpcClient) ChannelTemplate(name string) (ChannelTemplate, bool) {
+	c.templates.mtx.Lock()
+	defer c.templates.mtx.Unlock()
+	t, ok := c.templates.templates[name]
+	return t, ok
+}
+
+// OpenFromTemplate funds a channel with peer [peerIndex] using the parameters
+// of the registered template named [templateName], like FundChannel. Returns
+// ErrUnknownTemplate if there is no such template.
+func (c *LitRpcClient) OpenFromTemplate(peerIndex uint32, templateName string) error {
+	return c.OpenFromTemplateCtx(context.Background(), peerIndex, templateName)
+}
+
+// OpenFromTemplateCtx is like OpenFromTemplate, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) OpenFromTemplateCtx(ctx context.Context, peerIndex uint32, templateName string) error {
+	t, ok := c.ChannelTemplate(templateName)
+	if !ok {
+		return ErrUnknownTemplate
+	}
+	if t.FeePerByte != 0 {
+		err := c.SetFeeCtx(ctx, t.CoinType, t.FeePerByte)
+		if err != nil {
+			return err
+		}
+	}
+	return c.FundChannelCtx(ctx, peerIndex, t.CoinType, t.Capacity, t.InitialSend, t.Data)
+}
@@ -0,0 +1,115 @@
+package litrpcclient
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+// defaultWatchBackoff is the time WatchMessages waits after a failed poll
+const defaultWatchBackoff = ConstantBackoff(5 * time.Second)
+
+// ChatMessage is a text message received from a peer
+type ChatMessage struct {
+	// PeerIndex is the peer that sent the message, if it could be parsed
+	PeerIndex uint32
+	Text      string
+	// Raw is the message as the node reported it
+	Raw string
+}
+
+// Say sends text message [message] to peer [peerIndex]
+func (c *LitRpcClient) Say(peerIndex uint32, message string) error {
+	return c.SayCtx(context.Background(), peerIndex, message)
+}
+
+// SayCtx is like Say, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SayCtx(ctx context.Context, peerIndex uint32, message string) error {
+	err := c.checkPeer(ctx, peerIndex)
+	if err != nil {
+		return err
+	}
+
+	args := new(litrpc.SayArgs)
+	args.Peer = peerIndex
+	args.Message = message
+	reply := new(litrpc.StatusReply)
+	return c.callCtx(ctx, "LitRPC.Say", args, reply)
+}
+
+// GetMessage waits for the next text message from any peer. The node hands
+// every message to only one caller, so don't combine this with WatchMessages.
+func (c *LitRpcClient) GetMessage() (*ChatMessage, error) {
+	return c.GetMessageCtx(context.Background())
+}
+
+// GetMessageCtx is like GetMessage, but uses [ctx] for cancellation and
+// deadlines. The timeout set with WithTimeout does not apply, since the call
+// lasts until a message arrives.
+func (c *LitRpcClient) GetMessageCtx(ctx context.Context) (*ChatMessage, error) {
+	args := new(litrpc.NoArgs)
+	reply := new(litrpc.StatusReply)
+	err := c.subscriptions().callCtx(WithoutTimeout(ctx), "LitRPC.GetMessages", args, reply)
+	if err != nil {
+		return nil, err
+	}
+	return parseChatMessage(reply.Status), nil
+}
+
+// WatchMessages returns a channel that receives the text messages peers send
+// us. The channel is closed when [ctx] is cancelled.
+func (c *LitRpcClient) WatchMessages(ctx context.Context) <-chan ChatMessage {
+	messages := make(chan ChatMessage)
+	go func() {
+		defer close(messages)
+		backoff := c.opts.watchBackoff
+		if backoff == nil {
+			backoff = defaultWatchBackoff
+		}
+		failures := 0
+		for {
+			msg, err := c.GetMessageCtx(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				c.asyncError(err)
+				failures++
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(backoff.Delay(failures)):
+				}
+				continue
+			}
+			failures = 0
+			select {
+			case <-ctx.Done():
+				return
+			case messages <- *msg:
+			}
+		}
+	}()
+	return messages
+}
+
+// parseChatMessage parses a message in the form LIT reports them:
+// "msg from <peer index>: <text>"
+func parseChatMessage(raw string) *ChatMessage {
+	msg := &ChatMessage{Text: raw, Raw: raw}
+	rest := strings.TrimPrefix(raw, "msg from ")
+	sep := strings.Index(rest, ": ")
+	if rest == raw || sep == -1 {
+		return msg
+	}
+	peerIdx, err := strconv.ParseUint(rest[:sep], 10, 32)
+	if err != nil {
+		return msg
+	}
+	msg.PeerIndex = uint32(peerIdx)
+	msg.Text = rest[sep+2:]
+	return msg
+}
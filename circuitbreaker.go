@@ -0,0 +1,109 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by a client created with WithCircuitBreaker for
+// calls to a method that failed repeatedly, without contacting the node
+var ErrCircuitOpen = errors.New("circuit open after repeated failures")
+
+// WithCircuitBreaker makes the client stop calling a method after it failed
+// [threshold] times in a row, so a broken subsystem of the node doesn't use
+// up the time of every caller. Calls to the method then fail with
+// ErrCircuitOpen, until [cooldown] passed; the next call is sent as a probe,
+// and closes the circuit if it succeeds or opens it for another [cooldown] if
+// it fails. Only calls the node didn't answer count as failures, like
+// timeouts and lost connections. Errors the node returns (ErrRemote) reset
+// the count like successes, so rejected calls don't block valid ones; calls
+// cancelled by their context and methods the node doesn't support are ignored.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(o *clientOptions) {
+		o.breakerThreshold = threshold
+		o.breakerCooldown = cooldown
+	}
+}
+
+// circuit is the breaker state of a single method
+type circuit struct {
+	failures int
+	// openUntil is when the circuit allows a probe, zero while closed
+	openUntil time.Time
+	probing   bool
+}
+
+// circuitBreakers holds the breaker state per method
+type circuitBreakers struct {
+	mtx      sync.Mutex
+	circuits map[string]*circuit
+}
+
+// allowCall returns ErrCircuitOpen if calls to [method] should not be sent
+func (c *LitRpcClient) allowCall(method string) error {
+	if c.opts.breakerThreshold <= 0 {
+		return nil
+	}
+	b := &c.breakers
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	cb, ok := b.circuits[method]
+	if !ok || cb.openUntil.IsZero() {
+		return nil
+	}
+	if cb.probing || time.Now().Before(cb.openUntil) {
+		return ErrCircuitOpen
+	}
+	cb.probing = true
+	return nil
+}
+
+// recordCall updates the breaker of [method] with the outcome [err] of a call
+func (c *LitRpcClient) recordCall(ctx context.Context, method string, err error) {
+	if c.opts.breakerThreshold <= 0 {
+		return
+	}
+	b := &c.breakers
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.circuits == nil {
+		b.circuits = make(map[string]*circuit)
+	}
+	cb, ok := b.circuits[method]
+	if !ok {
+		cb = new(circuit)
+		b.circuits[method] = cb
+	}
+	cb.probing = false
+	if err != nil && (errors.Is(ctx.Err(), context.Canceled) || errors.Is(err, ErrNotSupported)) {
+		return
+	}
+	// A node that rejects a call answered it; the rejection is about the
+	// call, not about the method being broken
+	if err == nil || errors.Is(err, ErrRemote) {
+		cb.failures = 0
+		cb.openUntil = time.Time{}
+		return
+	}
+	cb.failures++
+	if cb.failures >= c.opts.breakerThreshold {
+		cb.openUntil = time.Now().Add(c.opts.breakerCooldown)
+	}
+}
+
+// OpenCircuits returns the methods whose circuit is open
+func (c *LitRpcClient) OpenCircuits() []string {
+	c.breakers.mtx.Lock()
+	defer c.breakers.mtx.Unlock()
+	var methods []string
+	for method, cb := range c.breakers.circuits {
+		if !cb.openUntil.IsZero() {
+			methods = append(methods, method)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
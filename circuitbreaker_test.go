@@ -0,0 +1,107 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+// flakyNode is a fakeNode whose calls can be made to fail like a broken
+// connection, without reaching the node
+type flakyNode struct {
+	*fakeNode
+	mtx  sync.Mutex
+	down bool
+}
+
+func (n *flakyNode) setDown(down bool) {
+	n.mtx.Lock()
+	n.down = down
+	n.mtx.Unlock()
+}
+
+func (n *flakyNode) Go(ctx context.Context, method string, args interface{}, reply interface{}, done func(error)) func(error) {
+	n.mtx.Lock()
+	down := n.down
+	n.mtx.Unlock()
+	if down {
+		done(faultTimeout{})
+		return noCancel
+	}
+	return n.fakeNode.Go(ctx, method, args, reply, done)
+}
+
+func TestCircuitBreakerStates(t *testing.T) {
+	const cooldown = 100 * time.Millisecond
+	node := &flakyNode{fakeNode: newFakeNode()}
+	node.reply("LitRPC.Balance", litrpc.BalanceReply{})
+	c := newTestClient(node.fakeNode, WithCircuitBreaker(2, cooldown))
+	c.conn = node
+
+	node.setDown(true)
+	for i := 0; i < 2; i++ {
+		_, err := c.ListBalances()
+		if !errors.Is(err, ErrTimeout) {
+			t.Fatalf("call %d: got %v, want ErrTimeout", i, err)
+		}
+	}
+	_, err := c.ListBalances()
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("after 2 failures: got %v, want ErrCircuitOpen", err)
+	}
+
+	// A failing probe opens the circuit for another cooldown
+	time.Sleep(cooldown)
+	_, err = c.ListBalances()
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("probe: got %v, want ErrTimeout", err)
+	}
+	_, err = c.ListBalances()
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("after failed probe: got %v, want ErrCircuitOpen", err)
+	}
+
+	// A succeeding probe closes it
+	node.setDown(false)
+	time.Sleep(cooldown)
+	for i := 0; i < 3; i++ {
+		_, err = c.ListBalances()
+		if err != nil {
+			t.Fatalf("call %d after recovery: %v", i, err)
+		}
+	}
+	if open := c.OpenCircuits(); len(open) != 0 {
+		t.Fatalf("open circuits after recovery: %v", open)
+	}
+}
+
+func TestCircuitBreakerIgnoresRejections(t *testing.T) {
+	node := &flakyNode{fakeNode: newFakeNode()}
+	node.handle("LitRPC.Send", func(interface{}) (interface{}, error) {
+		return nil, errors.New("insufficient funds")
+	})
+	c := newTestClient(node.fakeNode, WithCircuitBreaker(2, time.Minute))
+	c.conn = node
+
+	for i := 0; i < 5; i++ {
+		_, err := c.Send("addr", 1000)
+		if !errors.Is(err, ErrServerRejected) {
+			t.Fatalf("call %d: got %v, want ErrServerRejected", i, err)
+		}
+	}
+
+	// A rejection in between resets the count of failures
+	node.setDown(true)
+	c.Send("addr", 1000)
+	node.setDown(false)
+	c.Send("addr", 1000)
+	node.setDown(true)
+	_, err := c.Send("addr", 1000)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("got %v, want ErrTimeout with the circuit closed", err)
+	}
+}
@@ -1,86 +1,400 @@
 package litrpcclient
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"net/rpc"
-	"net/rpc/jsonrpc"
+	"net"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/mit-dci/lit/crypto/koblitz"
 	"github.com/mit-dci/lit/dlc"
 	"github.com/mit-dci/lit/litrpc"
 	"github.com/mit-dci/lit/lnutil"
 	"github.com/mit-dci/lit/qln"
-	"golang.org/x/net/websocket"
 )
 
+// LitRpcClient is a client for a LIT node's RPC interface. It is safe for
+// concurrent use by multiple goroutines; replies are matched to their calls
+// regardless of the order in which the node sends them.
 type LitRpcClient struct {
-	wsConn          *websocket.Conn
-	rpcConn         *rpc.Client
-	listeningStatus uint8
+	conn transport
+	// listeningStatus caches whether LIT listens: 0 unknown, 1 yes, 2 no.
+	// Accessed atomically.
+	listeningStatus uint32
+	states          stateTracker
+	pushQueue       pushQueue
+	addresses       addressTracker
+	peers           peerRegistry
+	stats           connStats
+	capabilities    capabilities
+	listened        listenState
+	htlcs           htlcWatchers
+	templates       channelTemplates
+	breakers        circuitBreakers
+	multihops       multihopClaims
+	asyncWatch      doneWatch
+	aliases         aliasTable
+
+	opts clientOptions
+
+	// sub is a client on a second connection, used for subscriptions
+	sub *LitRpcClient
 }
 
+// clientOptions holds the settings made with Options
+type clientOptions struct {
+	rcKey            *koblitz.PrivateKey
+	subscriptionConn bool
+	recordDir        string
+	errorSink        ErrorSink
+	redaction        RedactionPolicy
+	faults           *FaultProfile
+
+	addressReuseHandler func(AddressReuseWarning)
+	appName             string
+	readOnly            bool
+	reconnectBackoff    Backoff
+	watchBackoff        Backoff
+	asyncErrorHandler   func(error)
+	confirmations       map[uint32]int32
+	socksAddress        string
+	socksUser           string
+	socksPassword       string
+	timeout             time.Duration
+	methodTimeouts      map[string]time.Duration
+	contractChecks      bool
+	breakSnapshotDir    string
+	chainTips           map[uint32]func(context.Context) (int32, error)
+	maxFeePercent       float64
+	keyring             *Keyring
+	archive             *Archive
+	breakerThreshold    int
+	breakerCooldown     time.Duration
+	budget              *Budget
+	tracer              Tracer
+	chainBackends       map[uint32]ChainBackend
+	multihopTimeout     *time.Duration
+	fieldAliases        []FieldAlias
+	nodeVersion         string
+	decodeHooks         map[string][]DecodeHook
+}
+
+// MaxDataSize is the largest data (in bytes) that can be attached to a
+// channel state with Push, FundChannel or AddHTLC
+const MaxDataSize = 32
+
+// stateData converts [data] to the fixed size data of a channel state, or
+// returns ErrDataTooLong if it doesn't fit
+func stateData(data []byte) ([MaxDataSize]byte, error) {
+	var d [MaxDataSize]byte
+	if len(data) > MaxDataSize {
+		return d, fmt.Errorf("%w: %d bytes, at most %d allowed", ErrDataTooLong, len(data), MaxDataSize)
+	}
+	copy(d[:], data)
+	return d, nil
+}
+
+// Option configures optional behaviour of a LitRpcClient created with NewClient
+type Option func(*clientOptions)
+
 // NewClient creates a new LitRpcClient and connects to the given
-// hostname and port
-func NewClient(host string, port int32) (*LitRpcClient, error) {
+// hostname and port. By default it connects to LIT's websocket RPC
+// port, use WithRemoteControl to connect to the remote control interface
+// on LIT's LN port instead.
+func NewClient(host string, port int32, opts ...Option) (*LitRpcClient, error) {
 	client := new(LitRpcClient)
+	for _, opt := range opts {
+		opt(&client.opts)
+	}
+
 	var err error
-	client.wsConn, err = websocket.Dial(fmt.Sprintf("ws://%s:%d/ws", host, port), "", "http://127.0.0.1/")
+	client.conn, err = client.dial(host, port)
 	if err != nil {
 		return nil, err
 	}
-	client.rpcConn = jsonrpc.NewClient(client.wsConn)
+	if client.opts.subscriptionConn {
+		client.sub = &LitRpcClient{opts: client.opts}
+		client.sub.conn, err = client.dial(host, port)
+		if err != nil {
+			client.conn.Close()
+			return nil, err
+		}
+	}
 	return client, nil
 }
 
+// dial opens a connection to the node using the configured transport
+func (c *LitRpcClient) dial(host string, port int32) (transport, error) {
+	conn, err := c.dialOnce(host, port)
+	if err != nil || c.opts.reconnectBackoff == nil {
+		return conn, err
+	}
+	return &reconnectTransport{
+		dial:        func() (transport, error) { return c.dialOnce(host, port) },
+		onReconnect: c.relisten,
+		onError:     c.asyncError,
+		backoff:     c.opts.reconnectBackoff,
+		conn:        conn,
+	}, nil
+}
+
+// dialOnce opens a single connection to the node
+func (c *LitRpcClient) dialOnce(host string, port int32) (transport, error) {
+	netDial, err := c.netDialer(host)
+	if err != nil {
+		return nil, err
+	}
+	var conn transport
+	if key := c.remoteControlKey(host, port); key != nil {
+		conn, err = dialRemoteControl(key, host, port, netDial, &c.stats, c.asyncError)
+	} else {
+		conn, err = dialWebsocket(host, port, netDial, &c.stats)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c.wrapTransport(conn), nil
+}
+
+// wrapTransport adds the configured recording and fault injection to the
+// new connection [conn]
+func (c *LitRpcClient) wrapTransport(conn transport) transport {
+	c.stats.connected()
+	if c.opts.recordDir != "" {
+		conn = &recordTransport{transport: conn, dir: c.opts.recordDir}
+	}
+	if c.opts.faults != nil {
+		conn = newFaultTransport(conn, *c.opts.faults)
+	}
+	return conn
+}
+
 // Close Disconnects from the LIT node
 func (c *LitRpcClient) Close() {
-	c.wsConn.Close()
+	c.conn.Close()
+	if c.sub != nil {
+		c.sub.Close()
+	}
+}
+
+// CallContext calls RPC [method] on the node with [args], and decodes the result
+// into [reply]. It can be used for RPCs that have no wrapper in this client. The
+// call is abandoned when [ctx] is cancelled or its deadline expires.
+func (c *LitRpcClient) CallContext(ctx context.Context, method string, args interface{}, reply interface{}) error {
+	return c.callCtx(ctx, method, args, reply)
+}
+
+// call calls [method] on the node without a deadline
+func (c *LitRpcClient) call(method string, args interface{}, reply interface{}) error {
+	return c.callCtx(context.Background(), method, args, reply)
 }
 
-//Listen instructs LIT to listen for incoming connections. By default, LIT will not
-//listen. If LIT was already listening for incoming connections, this method
-//will just resolve.
+// callCtx calls [method] on the node and converts any error into one of the
+// package's error values
+func (c *LitRpcClient) callCtx(ctx context.Context, method string, args interface{}, reply interface{}) error {
+	call, err := c.startCall(ctx, method, args, reply)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.callContext(call.ctx, method)
+	defer cancel()
+	return call.finish(callTransport(ctx, c.conn, method, args, call.target()))
+}
+
+// pendingRPC is a call between startCall and finish
+type pendingRPC struct {
+	c       *LitRpcClient
+	ctx     context.Context
+	span    Span
+	method  string
+	args    interface{}
+	reply   interface{}
+	aliases map[string][]string
+	raw     json.RawMessage
+}
+
+// startCall runs the checks that can refuse a call of [method] before it is
+// sent, and starts its span. If it returns an error, the call is done.
+func (c *LitRpcClient) startCall(ctx context.Context, method string, args interface{}, reply interface{}) (*pendingRPC, error) {
+	ctx, span := c.startSpan(ctx, method)
+	err := c.capabilities.check(method)
+	if err == nil {
+		err = c.checkReadOnly(method, args)
+	}
+	if err == nil {
+		err = c.allowCall(method)
+	}
+	if err != nil {
+		if span != nil {
+			span.End(err)
+		}
+		return nil, err
+	}
+	c.stats.sent()
+	return &pendingRPC{c: c, ctx: ctx, span: span, method: method, args: args, reply: reply, aliases: c.aliasesFor(method)}, nil
+}
+
+// target returns what the transport decodes the reply into
+func (p *pendingRPC) target() interface{} {
+	if p.aliases != nil {
+		return &p.raw
+	}
+	return p.reply
+}
+
+// finish converts the error [err] of the transport into one of the
+// package's error values, decodes the reply, and records the outcome
+func (p *pendingRPC) finish(err error) error {
+	c, ctx, method := p.c, p.ctx, p.method
+	err = wrapError(method, err)
+	if err == nil && p.aliases != nil {
+		err = decodeWithAliases(p.raw, p.aliases, p.reply)
+	}
+	if err == nil || errors.Is(err, ErrRemote) || errors.Is(err, ErrNotSupported) {
+		c.stats.received()
+	}
+	c.recordCall(ctx, method, err)
+	if _, raw := p.reply.(*json.RawMessage); err == nil && !raw {
+		// Raw replies (from CallAsync) get the hooks once they are decoded
+		err = c.runDecodeHooks(method, p.reply)
+	}
+	if err == nil {
+		captureResult(ctx, method, p.reply)
+	}
+	if err != nil {
+		c.capabilities.record(method, err)
+		c.reportError(ctx, method, p.args, err)
+	}
+	if p.span != nil {
+		p.span.End(err)
+	}
+	return err
+}
+
+// Listen instructs LIT to listen for incoming connections. By default, LIT will not
+// listen. If LIT was already listening for incoming connections, this method
+// will just resolve.
 func (c *LitRpcClient) Listen(port string) error {
+	return c.ListenCtx(context.Background(), port)
+}
+
+// ListenCtx is like Listen, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListenCtx(ctx context.Context, port string) error {
+	_, err := c.listen(ctx, port)
+	if err != nil && err != ErrPortInUse {
+		return err
+	}
+	atomic.StoreUint32(&c.listeningStatus, 1)
+	return nil
+}
+
+// ListenAny instructs LIT to listen on the first of [ports] that is not already
+// in use, trying them in order. It returns the port LIT reports as actually bound.
+// If all ports are in use, ErrPortInUse is returned.
+func (c *LitRpcClient) ListenAny(ports ...string) (string, error) {
+	return c.ListenAnyCtx(context.Background(), ports...)
+}
+
+// ListenAnyCtx is like ListenAny, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListenAnyCtx(ctx context.Context, ports ...string) (string, error) {
+	for _, port := range ports {
+		bound, err := c.listen(ctx, port)
+		if err == ErrPortInUse {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		atomic.StoreUint32(&c.listeningStatus, 1)
+		return bound, nil
+	}
+	return "", ErrPortInUse
+}
+
+// listen calls LitRPC.Listen for a single port and returns the port that was
+// bound, as parsed from the listening addresses in the reply.
+func (c *LitRpcClient) listen(ctx context.Context, port string) (string, error) {
 	args := new(litrpc.ListenArgs)
 	args.Port = port
 
 	reply := new(litrpc.ListeningPortsReply)
-	err := c.rpcConn.Call("LitRPC.Listen", args, reply)
+	err := c.callCtx(ctx, "LitRPC.Listen", args, reply)
 	if err != nil {
-		if strings.Index(err.Error(), "already in use") == -1 {
-			return err
+		if strings.Index(err.Error(), "already in use") != -1 {
+			return "", ErrPortInUse
 		}
+		return "", err
 	}
-	c.listeningStatus = 1
-	return nil
+	c.listened.add(port)
+	return boundPort(port, reply.LisIpPorts), nil
+}
+
+// boundPort picks the port matching [requested] out of the listening addresses
+// LIT returned. If none matches, the last (most recently added) one is used.
+func boundPort(requested string, lisIpPorts []string) string {
+	want := portOf(requested)
+	bound := want
+	for _, ipPort := range lisIpPorts {
+		bound = portOf(ipPort)
+		if bound == want {
+			break
+		}
+	}
+	return bound
+}
+
+// portOf returns the port part of a host:port string, or the string itself
+// if it has no host part.
+func portOf(hostPort string) string {
+	_, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return strings.TrimPrefix(hostPort, ":")
+	}
+	return port
 }
 
 // IsListening checks if LIT is currently listening on any port.
 func (c *LitRpcClient) IsListening() (bool, error) {
-	if c.listeningStatus > 0 {
-		return (c.listeningStatus == 1), nil
+	return c.IsListeningCtx(context.Background())
+}
+
+// IsListeningCtx is like IsListening, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) IsListeningCtx(ctx context.Context) (bool, error) {
+	if status := atomic.LoadUint32(&c.listeningStatus); status > 0 {
+		return status == 1, nil
 	}
 
 	args := new(litrpc.NoArgs)
 	reply := new(litrpc.ListeningPortsReply)
-	err := c.rpcConn.Call("LitRPC.GetListeningPorts", args, reply)
+	err := c.callCtx(ctx, "LitRPC.GetListeningPorts", args, reply)
 	if err != nil {
 		return false, err
 	}
-	c.listeningStatus = 1
-	if reply.LisIpPorts == nil {
-		c.listeningStatus = 2
+	listening := reply.LisIpPorts != nil
+	if listening {
+		atomic.StoreUint32(&c.listeningStatus, 1)
+	} else {
+		atomic.StoreUint32(&c.listeningStatus, 2)
 	}
-	return (c.listeningStatus == 1), nil
+	return listening, nil
 }
 
 // GetLNAddress returns the LN address for this node
 func (c *LitRpcClient) GetLNAddress() (string, error) {
+	return c.GetLNAddressCtx(context.Background())
+}
+
+// GetLNAddressCtx is like GetLNAddress, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) GetLNAddressCtx(ctx context.Context) (string, error) {
 	args := new(litrpc.NoArgs)
 
 	reply := new(litrpc.ListeningPortsReply)
-	err := c.rpcConn.Call("LitRPC.GetListeningPorts", args, reply)
+	err := c.callCtx(ctx, "LitRPC.GetListeningPorts", args, reply)
 	if err != nil {
 		return "", err
 	}
@@ -88,7 +402,31 @@ func (c *LitRpcClient) GetLNAddress() (string, error) {
 }
 
 // Connect connects to another LIT node. address is mandatory, host and port can be left empty / 0.
+// Returns ErrPeerNotAllowed if the peer policy rejects the address.
 func (c *LitRpcClient) Connect(address, host string, port uint32) error {
+	return c.ConnectCtx(context.Background(), address, host, port)
+}
+
+// ConnectCtx is like Connect, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ConnectCtx(ctx context.Context, address, host string, port uint32) error {
+	err := c.peers.checkAddress(address)
+	if err != nil {
+		return err
+	}
+	err = c.connect(ctx, address, host, port)
+	if err != nil {
+		return err
+	}
+	if c.peers.hasPolicy() {
+		// Learn the peer's index, so operations on it can be checked
+		_, _, err = c.connectionTo(ctx, address)
+		return err
+	}
+	return nil
+}
+
+// connect calls LitRPC.Connect
+func (c *LitRpcClient) connect(ctx context.Context, address, host string, port uint32) error {
 	args := new(litrpc.ConnectArgs)
 	args.LNAddr = address
 	reply := new(litrpc.StatusReply)
@@ -98,23 +436,29 @@ func (c *LitRpcClient) Connect(address, host string, port uint32) error {
 			args.LNAddr += ":" + strconv.Itoa(int(port))
 		}
 	}
-	err := c.rpcConn.Call("LitRPC.Connect", args, reply)
+	err := c.callCtx(ctx, "LitRPC.Connect", args, reply)
 	if err != nil {
 		return err
 	}
-	if strings.Index(reply.Status, "connected to peer") == -1 {
-		return fmt.Errorf("Unexpected response from server: %s", reply.Status)
+	_, err = ParseStatus("LitRPC.Connect", reply.Status)
+	if err != nil {
+		return err
 	}
 	return nil
 }
 
 // ListConnections Returns a list of currently connected nodes
 func (c *LitRpcClient) ListConnections() ([]qln.PeerInfo, error) {
+	return c.ListConnectionsCtx(context.Background())
+}
+
+// ListConnectionsCtx is like ListConnections, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListConnectionsCtx(ctx context.Context) ([]qln.PeerInfo, error) {
 	empty := make([]qln.PeerInfo, 0)
 	args := new(litrpc.NoArgs)
 
 	reply := new(litrpc.ListConnectionsReply)
-	err := c.rpcConn.Call("LitRPC.ListConnections", args, reply)
+	err := c.callCtx(ctx, "LitRPC.ListConnections", args, reply)
 	if err != nil {
 		return empty, err
 	}
@@ -127,16 +471,22 @@ func (c *LitRpcClient) ListConnections() ([]qln.PeerInfo, error) {
 
 // AssignNickname assigns the nickname [nickname] to the known peer with index [peerIndex]
 func (c *LitRpcClient) AssignNickname(peerIndex uint32, nickname string) error {
+	return c.AssignNicknameCtx(context.Background(), peerIndex, nickname)
+}
+
+// AssignNicknameCtx is like AssignNickname, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) AssignNicknameCtx(ctx context.Context, peerIndex uint32, nickname string) error {
 	args := new(litrpc.AssignNicknameArgs)
 	args.Peer = peerIndex
 	args.Nickname = nickname
 	reply := new(litrpc.StatusReply)
-	err := c.rpcConn.Call("LitRPC.AssignNickname", args, reply)
+	err := c.callCtx(ctx, "LitRPC.AssignNickname", args, reply)
 	if err != nil {
 		return err
 	}
-	if strings.Index(reply.Status, "changed nickname") == -1 {
-		return fmt.Errorf("Unexpected response from server: %s", reply.Status)
+	_, err = ParseStatus("LitRPC.AssignNickname", reply.Status)
+	if err != nil {
+		return err
 	}
 	return nil
 }
@@ -144,25 +494,36 @@ func (c *LitRpcClient) AssignNickname(peerIndex uint32, nickname string) error {
 // Stop stops the LIT node. This means you'll have to restart it manually.
 // After stopping the node you can no longer connect to it via RPC.
 func (c *LitRpcClient) Stop() error {
+	return c.StopCtx(context.Background())
+}
+
+// StopCtx is like Stop, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) StopCtx(ctx context.Context) error {
 	args := new(litrpc.NoArgs)
 	reply := new(litrpc.StatusReply)
-	err := c.rpcConn.Call("LitRPC.Stop", args, reply)
+	err := c.callCtx(ctx, "LitRPC.Stop", args, reply)
 	if err != nil {
 		return err
 	}
-	if strings.Index(reply.Status, "Stopping lit node") == -1 {
-		return fmt.Errorf("Unexpected response from server: %s", reply.Status)
+	_, err = ParseStatus("LitRPC.Stop", reply.Status)
+	if err != nil {
+		return err
 	}
 	return nil
 }
 
 // Returns a list of balances from the LIT node's wallet
 func (c *LitRpcClient) ListBalances() ([]litrpc.CoinBalReply, error) {
+	return c.ListBalancesCtx(context.Background())
+}
+
+// ListBalancesCtx is like ListBalances, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListBalancesCtx(ctx context.Context) ([]litrpc.CoinBalReply, error) {
 	empty := make([]litrpc.CoinBalReply, 0)
 	args := new(litrpc.NoArgs)
 
 	reply := new(litrpc.BalanceReply)
-	err := c.rpcConn.Call("LitRPC.Balance", args, reply)
+	err := c.callCtx(ctx, "LitRPC.Balance", args, reply)
 	if err != nil {
 		return empty, err
 	}
@@ -175,11 +536,16 @@ func (c *LitRpcClient) ListBalances() ([]litrpc.CoinBalReply, error) {
 
 // Returns a list of all unspent transaction outputs, that are not part of a channel
 func (c *LitRpcClient) ListUtxos() ([]litrpc.TxoInfo, error) {
+	return c.ListUtxosCtx(context.Background())
+}
+
+// ListUtxosCtx is like ListUtxos, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListUtxosCtx(ctx context.Context) ([]litrpc.TxoInfo, error) {
 	empty := make([]litrpc.TxoInfo, 0)
 	args := new(litrpc.NoArgs)
 
 	reply := new(litrpc.TxoListReply)
-	err := c.rpcConn.Call("LitRPC.TxoList", args, reply)
+	err := c.callCtx(ctx, "LitRPC.TxoList", args, reply)
 	if err != nil {
 		return empty, err
 	}
@@ -193,16 +559,35 @@ func (c *LitRpcClient) ListUtxos() ([]litrpc.TxoInfo, error) {
 // Send sends coins from LIT's wallet using a normal on-chain transaction. Send to [address]
 // [amount] coins. Will return the transaction ID of the on-chain transaction
 func (c *LitRpcClient) Send(address string, amount int64) (string, error) {
+	return c.SendCtx(context.Background(), address, amount)
+}
+
+// SendCtx is like Send, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SendCtx(ctx context.Context, address string, amount int64) (string, error) {
+	coinType, known := addressCoinType(address)
+	if known {
+		err := c.checkFee(ctx, coinType, sendTxSize, amount)
+		if err != nil {
+			return "", err
+		}
+	}
+	settle, err := c.chargeBudget(ctx, "LitRPC.Send", coinType, known, sendTxSize, amount)
+	if err != nil {
+		return "", err
+	}
+	c.warnAddressReuse(c.addresses.send(address))
+
 	args := new(litrpc.SendArgs)
 	args.Amts = []int64{amount}
 	args.DestAddrs = []string{address}
 	reply := new(litrpc.TxidsReply)
-	err := c.rpcConn.Call("LitRPC.Send", args, reply)
+	err = c.callCtx(ctx, "LitRPC.Send", args, reply)
+	settle(err)
 	if err != nil {
 		return "", err
 	}
 	if reply.Txids == nil {
-		return "", fmt.Errorf("Unexpected response from server")
+		return "", remoteError("LitRPC.Send", "Unexpected response from server")
 	}
 
 	return reply.Txids[0], nil
@@ -211,16 +596,21 @@ func (c *LitRpcClient) Send(address string, amount int64) (string, error) {
 // SetFee allows you to configure the fee rate for a particular coin type. It will set
 // the fee for [coinType] to [feePerByte] satoshi/byte
 func (c *LitRpcClient) SetFee(coinType uint32, feePerByte int64) error {
+	return c.SetFeeCtx(context.Background(), coinType, feePerByte)
+}
+
+// SetFeeCtx is like SetFee, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SetFeeCtx(ctx context.Context, coinType uint32, feePerByte int64) error {
 	args := new(litrpc.SetFeeArgs)
 	args.CoinType = coinType
 	args.Fee = feePerByte
 	reply := new(litrpc.FeeReply)
-	err := c.rpcConn.Call("LitRPC.SetFee", args, reply)
+	err := c.callCtx(ctx, "LitRPC.SetFee", args, reply)
 	if err != nil {
 		return err
 	}
 	if reply.CurrentFee != feePerByte {
-		return fmt.Errorf("Fee was not set")
+		return remoteError("LitRPC.SetFee", "Fee was not set")
 	}
 
 	return nil
@@ -228,10 +618,15 @@ func (c *LitRpcClient) SetFee(coinType uint32, feePerByte int64) error {
 
 // GetFee returns the currently configured fee in satoshi per byte for [coinType]
 func (c *LitRpcClient) GetFee(coinType uint32) (int64, error) {
+	return c.GetFeeCtx(context.Background(), coinType)
+}
+
+// GetFeeCtx is like GetFee, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) GetFeeCtx(ctx context.Context, coinType uint32) (int64, error) {
 	args := new(litrpc.FeeArgs)
 	args.CoinType = coinType
 	reply := new(litrpc.FeeReply)
-	err := c.rpcConn.Call("LitRPC.GetFee", args, reply)
+	err := c.callCtx(ctx, "LitRPC.GetFee", args, reply)
 	if err != nil {
 		return 0, err
 	}
@@ -242,18 +637,35 @@ func (c *LitRpcClient) GetFee(coinType uint32) (int64, error) {
 // GetAddresses returns a list of (newly generated or existing) addresses. Generates [numberToMake] addresses for
 // coin type [coinType]. if [numberToMake] is 0, will return the existing addresses. Returns bech32 by default, or
 // legacy addresses when you set [legacy] to true
+//
+// Deprecated: use NewAddress to generate addresses and ListExistingAddresses to
+// list them. GetAddresses will be removed in v2.
 func (c *LitRpcClient) GetAddresses(coinType, numberToMake uint32, legacy bool) ([]string, error) {
+	return c.GetAddressesCtx(context.Background(), coinType, numberToMake, legacy)
+}
+
+// GetAddressesCtx is like GetAddresses, but uses [ctx] for cancellation and deadlines
+//
+// Deprecated: use NewAddressCtx and ListExistingAddressesCtx.
+func (c *LitRpcClient) GetAddressesCtx(ctx context.Context, coinType, numberToMake uint32, legacy bool) ([]string, error) {
+	return c.getAddresses(ctx, coinType, numberToMake, legacy)
+}
+
+// getAddresses calls LitRPC.Address and returns the bech32 or legacy addresses
+func (c *LitRpcClient) getAddresses(ctx context.Context, coinType, numberToMake uint32, legacy bool) ([]string, error) {
 	args := new(litrpc.AddressArgs)
 	args.CoinType = coinType
 	args.NumToMake = numberToMake
 	reply := new(litrpc.AddressReply)
-	err := c.rpcConn.Call("LitRPC.Address", args, reply)
+	err := c.callCtx(ctx, "LitRPC.Address", args, reply)
 	if err != nil {
 		return nil, err
 	}
 	if reply.LegacyAddresses == nil || reply.WitAddresses == nil {
-		return nil, fmt.Errorf("Unexpected reply from server")
+		return nil, remoteError("LitRPC.Address", "Unexpected reply from server")
 	}
+	c.addresses.issue(reply.WitAddresses)
+	c.addresses.issue(reply.LegacyAddresses)
 
 	if legacy {
 		return reply.LegacyAddresses, nil
@@ -262,13 +674,75 @@ func (c *LitRpcClient) GetAddresses(coinType, numberToMake uint32, legacy bool)
 	}
 }
 
+// Address is a wallet address in both its bech32 and legacy form
+type Address struct {
+	CoinType uint32
+	Witness  string
+	Legacy   string
+}
+
+// ListExistingAddresses returns the addresses the wallet already generated for coin
+// type [coinType], without generating new ones
+func (c *LitRpcClient) ListExistingAddresses(coinType uint32) ([]Address, error) {
+	return c.ListExistingAddressesCtx(context.Background(), coinType)
+}
+
+// ListExistingAddressesCtx is like ListExistingAddresses, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListExistingAddressesCtx(ctx context.Context, coinType uint32) ([]Address, error) {
+	args := new(litrpc.AddressArgs)
+	args.CoinType = coinType
+	args.NumToMake = 0
+	reply := new(litrpc.AddressReply)
+	err := c.callCtx(ctx, "LitRPC.Address", args, reply)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply.WitAddresses) != len(reply.LegacyAddresses) || len(reply.CoinTypes) != len(reply.WitAddresses) {
+		return nil, remoteError("LitRPC.Address", "Unexpected reply from server")
+	}
+
+	addresses := make([]Address, 0, len(reply.WitAddresses))
+	for i := range reply.WitAddresses {
+		if reply.CoinTypes[i] != coinType {
+			continue
+		}
+		addresses = append(addresses, Address{coinType, reply.WitAddresses[i], reply.LegacyAddresses[i]})
+	}
+	c.addresses.issue(reply.WitAddresses)
+	c.addresses.issue(reply.LegacyAddresses)
+	return addresses, nil
+}
+
+// NewAddress generates a single new address for coin type [coinType]. Returns bech32 by default, or a
+// legacy address when you set [legacy] to true
+func (c *LitRpcClient) NewAddress(coinType uint32, legacy bool) (string, error) {
+	return c.NewAddressCtx(context.Background(), coinType, legacy)
+}
+
+// NewAddressCtx is like NewAddress, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) NewAddressCtx(ctx context.Context, coinType uint32, legacy bool) (string, error) {
+	addresses, err := c.getAddresses(ctx, coinType, 1, legacy)
+	if err != nil {
+		return "", err
+	}
+	if len(addresses) == 0 {
+		return "", remoteError("LitRPC.Address", "No address returned from server")
+	}
+	return addresses[len(addresses)-1], nil
+}
+
 // ListChannels returns a list of channels (both active and closed)
 func (c *LitRpcClient) ListChannels() ([]litrpc.ChannelInfo, error) {
+	return c.ListChannelsCtx(context.Background())
+}
+
+// ListChannelsCtx is like ListChannels, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListChannelsCtx(ctx context.Context) ([]litrpc.ChannelInfo, error) {
 	empty := make([]litrpc.ChannelInfo, 0)
 	args := new(litrpc.NoArgs)
 
 	reply := new(litrpc.ChannelListReply)
-	err := c.rpcConn.Call("LitRPC.ChannelList", args, reply)
+	err := c.callCtx(ctx, "LitRPC.ChannelList", args, reply)
 	if err != nil {
 		return empty, err
 	}
@@ -283,21 +757,46 @@ func (c *LitRpcClient) ListChannels() ([]litrpc.ChannelInfo, error) {
 // between peers. After the channel exists, funds can freely be exchanged between peers without
 // using the blockchain. Will create a channel of coin type [coinType] with peer [peerIndex]. It will fund it
 // with [amount] from our wallet, and send over [initialSend] to our peer upon opening. If needed, [data] can
-// be used to associate arbitrary data with the payment (like an invoice reference)
+// be used to associate arbitrary data with the payment (like an invoice reference). Data longer than MaxDataSize
+// is rejected with ErrDataTooLong.
 func (c *LitRpcClient) FundChannel(peerIndex, coinType uint32, amount, initialSend int64, data []byte) error {
+	return c.FundChannelCtx(context.Background(), peerIndex, coinType, amount, initialSend, data)
+}
+
+// FundChannelCtx is like FundChannel, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) FundChannelCtx(ctx context.Context, peerIndex, coinType uint32, amount, initialSend int64, data []byte) error {
+	ref, err := stateData(data)
+	if err != nil {
+		return err
+	}
+	err = c.checkPeer(ctx, peerIndex)
+	if err != nil {
+		return err
+	}
+	err = c.checkFee(ctx, coinType, fundingTxSize, amount)
+	if err != nil {
+		return err
+	}
+	settle, err := c.chargeBudget(ctx, "LitRPC.FundChannel", coinType, true, fundingTxSize, amount)
+	if err != nil {
+		return err
+	}
+
 	args := new(litrpc.FundArgs)
 	args.Peer = peerIndex
 	args.CoinType = coinType
 	args.Capacity = amount
 	args.InitialSend = initialSend
-	copy(args.Data[:], data)
+	args.Data = correlationData(ctx, ref)
 	reply := new(litrpc.StatusReply)
-	err := c.rpcConn.Call("LitRPC.FundChannel", args, reply)
+	err = c.callCtx(ctx, "LitRPC.FundChannel", args, reply)
+	settle(err)
 	if err != nil {
 		return err
 	}
-	if strings.Index(reply.Status, "funded channel") == -1 {
-		return fmt.Errorf("Unexpected response from server: %s", reply.Status)
+	_, err = ParseStatus("LitRPC.FundChannel", reply.Status)
+	if err != nil {
+		return err
 	}
 
 	return nil
@@ -305,13 +804,20 @@ func (c *LitRpcClient) FundChannel(peerIndex, coinType uint32, amount, initialSe
 
 // StateDump dumps all the known (previous) states to channels. This can be useful when
 // analyzing payment references periodically. The data of each individual state
-// is returned in the array of JusticeTx objects.
+// is returned in the array of JusticeTx objects. If the highest state of a channel
+// is lower than in a previous dump, the states are returned together with a
+// *StateRegressionError.
 func (c *LitRpcClient) StateDump() ([]qln.JusticeTx, error) {
+	return c.StateDumpCtx(context.Background())
+}
+
+// StateDumpCtx is like StateDump, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) StateDumpCtx(ctx context.Context) ([]qln.JusticeTx, error) {
 	empty := []qln.JusticeTx{}
 	args := new(litrpc.NoArgs)
 
 	reply := new(litrpc.StateDumpReply)
-	err := c.rpcConn.Call("LitRPC.StateDump", args, reply)
+	err := c.callCtx(ctx, "LitRPC.StateDump", args, reply)
 	if err != nil {
 		return empty, err
 	}
@@ -319,35 +825,72 @@ func (c *LitRpcClient) StateDump() ([]qln.JusticeTx, error) {
 		return empty, nil
 	}
 
-	return reply.Txs, nil
+	return reply.Txs, c.states.checkDump(reply.Txs)
 }
 
 // Push pushes [amount] satoshi through channel [channelIndex] to the other peer. If needed, you can use [data] to
-// associate arbitrary data with the payment (like an invoice reference)
+// associate arbitrary data with the payment (like an invoice reference), at most MaxDataSize bytes or the push
+// fails with ErrDataTooLong. If the node returns a state index that
+// is not higher than the one from a previous push, the (completed) push's state index is returned together with a
+// *StateRegressionError. Concurrent pushes to the same channel are executed one at a time, in order.
 func (c *LitRpcClient) Push(channelIndex uint32, amount int64, data []byte) (uint64, error) {
+	return c.PushCtx(context.Background(), channelIndex, amount, data)
+}
+
+// PushCtx is like Push, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) PushCtx(ctx context.Context, channelIndex uint32, amount int64, data []byte) (uint64, error) {
+	ref, err := stateData(data)
+	if err != nil {
+		return 0, err
+	}
+	ref = correlationData(ctx, ref)
+	if c.pushQueue.coalescing() {
+		return c.pushCoalesced(ctx, channelIndex, amount, ref)
+	}
+	return c.push(ctx, channelIndex, amount, ref)
+}
+
+// push waits for its turn on the channel and then pushes [amount] with [data]
+func (c *LitRpcClient) push(ctx context.Context, channelIndex uint32, amount int64, data [32]byte) (uint64, error) {
+	release, err := c.pushQueue.acquire(ctx, channelIndex)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+	return c.doPush(ctx, channelIndex, amount, data)
+}
+
+// doPush calls LitRPC.Push. The caller must have acquired the channel's turn.
+func (c *LitRpcClient) doPush(ctx context.Context, channelIndex uint32, amount int64, data [32]byte) (uint64, error) {
 	args := new(litrpc.PushArgs)
 	args.ChanIdx = channelIndex
 	args.Amt = amount
-	copy(args.Data[:], data)
+	args.Data = data
 	reply := new(litrpc.PushReply)
-	err := c.rpcConn.Call("LitRPC.Push", args, reply)
+	err := c.callCtx(ctx, "LitRPC.Push", args, reply)
 	if err != nil {
 		return 0, err
 	}
-	return reply.StateIndex, nil
+	return reply.StateIndex, c.states.checkPush(channelIndex, reply.StateIndex)
 }
 
 // Close collaboratively closes channel [channelIndex] and returns the funds to the wallet
 func (c *LitRpcClient) CloseChannel(channelIndex uint32) error {
+	return c.CloseChannelCtx(context.Background(), channelIndex)
+}
+
+// CloseChannelCtx is like CloseChannel, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) CloseChannelCtx(ctx context.Context, channelIndex uint32) error {
 	args := new(litrpc.ChanArgs)
 	args.ChanIdx = channelIndex
 	reply := new(litrpc.StatusReply)
-	err := c.rpcConn.Call("LitRPC.CloseChannel", args, reply)
+	err := c.callCtx(ctx, "LitRPC.CloseChannel", args, reply)
 	if err != nil {
 		return err
 	}
-	if strings.Index(reply.Status, "OK closed") == -1 {
-		return fmt.Errorf("Unexpected response from server: %s", reply.Status)
+	_, err = ParseStatus("LitRPC.CloseChannel", reply.Status)
+	if err != nil {
+		return err
 	}
 
 	return nil
@@ -357,27 +900,39 @@ func (c *LitRpcClient) CloseChannel(channelIndex uint32) error {
 // is an uncooperative closing, and might require some time for the funds to be
 // returned to the wallet
 func (c *LitRpcClient) BreakChannel(channelIndex uint32) error {
+	return c.BreakChannelCtx(context.Background(), channelIndex)
+}
+
+// BreakChannelCtx is like BreakChannel, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) BreakChannelCtx(ctx context.Context, channelIndex uint32) error {
+	return c.BreakChannelWithReasonCtx(ctx, channelIndex, "")
+}
+
+// breakChannel calls LitRPC.BreakChannel
+func (c *LitRpcClient) breakChannel(ctx context.Context, channelIndex uint32) error {
 	args := new(litrpc.ChanArgs)
 	args.ChanIdx = channelIndex
 	reply := new(litrpc.StatusReply)
-	err := c.rpcConn.Call("LitRPC.BreakChannel", args, reply)
+	err := c.callCtx(ctx, "LitRPC.BreakChannel", args, reply)
 	if err != nil {
 		return err
 	}
-	if reply.Status == "" {
-		return fmt.Errorf("Unexpected response from server")
-	}
-
-	return nil
+	_, err = ParseStatus("LitRPC.BreakChannel", reply.Status)
+	return err
 }
 
 // ImportOracle imports an oracle that exposes a REST API at [url], and saves it under display name [name]
 func (c *LitRpcClient) ImportOracle(url, name string) (*dlc.DlcOracle, error) {
+	return c.ImportOracleCtx(context.Background(), url, name)
+}
+
+// ImportOracleCtx is like ImportOracle, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ImportOracleCtx(ctx context.Context, url, name string) (*dlc.DlcOracle, error) {
 	args := new(litrpc.ImportOracleArgs)
 	args.Url = url
 	args.Name = name
 	reply := new(litrpc.ImportOracleReply)
-	err := c.rpcConn.Call("LitRPC.ImportOracle", args, reply)
+	err := c.callCtx(ctx, "LitRPC.ImportOracle", args, reply)
 	if err != nil {
 		return nil, err
 	}
@@ -386,11 +941,16 @@ func (c *LitRpcClient) ImportOracle(url, name string) (*dlc.DlcOracle, error) {
 
 // AddOracle adds an oracle using its public key [pubkeyHex] (33 bytes hex), and saves it under display name [name]
 func (c *LitRpcClient) AddOracle(pubKeyHex, name string) (*dlc.DlcOracle, error) {
+	return c.AddOracleCtx(context.Background(), pubKeyHex, name)
+}
+
+// AddOracleCtx is like AddOracle, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) AddOracleCtx(ctx context.Context, pubKeyHex, name string) (*dlc.DlcOracle, error) {
 	args := new(litrpc.AddOracleArgs)
 	args.Key = pubKeyHex
 	args.Name = name
 	reply := new(litrpc.AddOracleReply)
-	err := c.rpcConn.Call("LitRPC.AddOracle", args, reply)
+	err := c.callCtx(ctx, "LitRPC.AddOracle", args, reply)
 	if err != nil {
 		return nil, err
 	}
@@ -399,11 +959,16 @@ func (c *LitRpcClient) AddOracle(pubKeyHex, name string) (*dlc.DlcOracle, error)
 
 // ListOracles returns a list of all known oracles
 func (c *LitRpcClient) ListOracles() ([]*dlc.DlcOracle, error) {
+	return c.ListOraclesCtx(context.Background())
+}
+
+// ListOraclesCtx is like ListOracles, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListOraclesCtx(ctx context.Context) ([]*dlc.DlcOracle, error) {
 	empty := []*dlc.DlcOracle{}
 	args := new(litrpc.NoArgs)
 
 	reply := new(litrpc.ListOraclesReply)
-	err := c.rpcConn.Call("LitRPC.ListOracles", args, reply)
+	err := c.callCtx(ctx, "LitRPC.ListOracles", args, reply)
 	if err != nil {
 		return empty, err
 	}
@@ -416,15 +981,20 @@ func (c *LitRpcClient) ListOracles() ([]*dlc.DlcOracle, error) {
 
 // NewContract creates a new, empty draft contract and returns it
 func (c *LitRpcClient) NewContract() (*lnutil.DlcContract, error) {
+	return c.NewContractCtx(context.Background())
+}
+
+// NewContractCtx is like NewContract, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) NewContractCtx(ctx context.Context) (*lnutil.DlcContract, error) {
 	args := new(litrpc.NoArgs)
 
 	reply := new(litrpc.NewContractReply)
-	err := c.rpcConn.Call("LitRPC.NewContract", args, reply)
+	err := c.callCtx(ctx, "LitRPC.NewContract", args, reply)
 	if err != nil {
 		return nil, err
 	}
 	if reply.Contract == nil {
-		return nil, fmt.Errorf("No contract returned from server")
+		return nil, remoteError("LitRPC.NewContract", "No contract returned from server")
 	}
 
 	return reply.Contract, nil
@@ -432,15 +1002,20 @@ func (c *LitRpcClient) NewContract() (*lnutil.DlcContract, error) {
 
 // GetContract returns the contract with id [contractIndex]
 func (c *LitRpcClient) GetContract(contractIndex uint64) (*lnutil.DlcContract, error) {
+	return c.GetContractCtx(context.Background(), contractIndex)
+}
+
+// GetContractCtx is like GetContract, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) GetContractCtx(ctx context.Context, contractIndex uint64) (*lnutil.DlcContract, error) {
 	args := new(litrpc.GetContractArgs)
 	args.Idx = contractIndex
 	reply := new(litrpc.GetContractReply)
-	err := c.rpcConn.Call("LitRPC.GetContract", args, reply)
+	err := c.callCtx(ctx, "LitRPC.GetContract", args, reply)
 	if err != nil {
 		return nil, err
 	}
 	if reply.Contract == nil {
-		return nil, fmt.Errorf("No contract returned from server")
+		return nil, remoteError("LitRPC.GetContract", "No contract returned from server")
 	}
 
 	return reply.Contract, nil
@@ -448,15 +1023,20 @@ func (c *LitRpcClient) GetContract(contractIndex uint64) (*lnutil.DlcContract, e
 
 // ListContracts returns all known contracts
 func (c *LitRpcClient) ListContracts() ([]*lnutil.DlcContract, error) {
+	return c.ListContractsCtx(context.Background())
+}
+
+// ListContractsCtx is like ListContracts, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListContractsCtx(ctx context.Context) ([]*lnutil.DlcContract, error) {
 	args := new(litrpc.NoArgs)
 
 	reply := new(litrpc.ListContractsReply)
-	err := c.rpcConn.Call("LitRPC.ListContracts", args, reply)
+	err := c.callCtx(ctx, "LitRPC.ListContracts", args, reply)
 	if err != nil {
 		return []*lnutil.DlcContract{}, err
 	}
 	if reply.Contracts == nil {
-		return []*lnutil.DlcContract{}, fmt.Errorf("No contract returned from server")
+		return []*lnutil.DlcContract{}, nil
 	}
 
 	return reply.Contracts, nil
@@ -464,16 +1044,38 @@ func (c *LitRpcClient) ListContracts() ([]*lnutil.DlcContract, error) {
 
 // OfferContract offers contract [contractIndex] to peer [peerIndex]
 func (c *LitRpcClient) OfferContract(contractIndex uint64, peerIndex uint32) error {
+	return c.OfferContractCtx(context.Background(), contractIndex, peerIndex)
+}
+
+// OfferContractCtx is like OfferContract, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) OfferContractCtx(ctx context.Context, contractIndex uint64, peerIndex uint32) error {
+	err := c.checkPeer(ctx, peerIndex)
+	if err != nil {
+		return err
+	}
+	if c.opts.contractChecks {
+		err = c.CheckContractCtx(ctx, contractIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	settle, err := c.chargeContract(ctx, "LitRPC.OfferContract", contractIndex)
+	if err != nil {
+		return err
+	}
+
 	args := new(litrpc.OfferContractArgs)
 	args.CIdx = contractIndex
 	args.PeerIdx = peerIndex
 	reply := new(litrpc.OfferContractReply)
-	err := c.rpcConn.Call("LitRPC.OfferContract", args, reply)
+	err = c.callCtx(ctx, "LitRPC.OfferContract", args, reply)
+	settle(err)
 	if err != nil {
 		return err
 	}
 	if !reply.Success {
-		return fmt.Errorf("Server returned success = false")
+		return remoteError("LitRPC.OfferContract", "Server returned success = false")
 	}
 
 	return nil
@@ -481,15 +1083,37 @@ func (c *LitRpcClient) OfferContract(contractIndex uint64, peerIndex uint32) err
 
 // AcceptContract accepts the contract with id [contractIndex]
 func (c *LitRpcClient) AcceptContract(contractIndex uint64) error {
+	return c.AcceptContractCtx(context.Background(), contractIndex)
+}
+
+// AcceptContractCtx is like AcceptContract, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) AcceptContractCtx(ctx context.Context, contractIndex uint64) error {
+	if c.peers.hasPolicy() {
+		contract, err := c.GetContractCtx(ctx, contractIndex)
+		if err != nil {
+			return err
+		}
+		err = c.checkPeer(ctx, contract.PeerIdx)
+		if err != nil {
+			return err
+		}
+	}
+
+	settle, err := c.chargeContract(ctx, "LitRPC.AcceptContract", contractIndex)
+	if err != nil {
+		return err
+	}
+
 	args := new(litrpc.AcceptContractArgs)
 	args.CIdx = contractIndex
 	reply := new(litrpc.AcceptContractReply)
-	err := c.rpcConn.Call("LitRPC.AcceptContract", args, reply)
+	err = c.callCtx(ctx, "LitRPC.AcceptContract", args, reply)
+	settle(err)
 	if err != nil {
 		return err
 	}
 	if !reply.Success {
-		return fmt.Errorf("Server returned success = false")
+		return remoteError("LitRPC.AcceptContract", "Server returned success = false")
 	}
 
 	return nil
@@ -497,15 +1121,20 @@ func (c *LitRpcClient) AcceptContract(contractIndex uint64) error {
 
 // DeclineContract declines the contract with id [contractIndex]
 func (c *LitRpcClient) DeclineContract(contractIndex uint64) error {
+	return c.DeclineContractCtx(context.Background(), contractIndex)
+}
+
+// DeclineContractCtx is like DeclineContract, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) DeclineContractCtx(ctx context.Context, contractIndex uint64) error {
 	args := new(litrpc.DeclineContractArgs)
 	args.CIdx = contractIndex
 	reply := new(litrpc.DeclineContractReply)
-	err := c.rpcConn.Call("LitRPC.DeclineContract", args, reply)
+	err := c.callCtx(ctx, "LitRPC.DeclineContract", args, reply)
 	if err != nil {
 		return err
 	}
 	if !reply.Success {
-		return fmt.Errorf("Server returned success = false")
+		return remoteError("LitRPC.DeclineContract", "Server returned success = false")
 	}
 
 	return nil
@@ -514,17 +1143,22 @@ func (c *LitRpcClient) DeclineContract(contractIndex uint64) error {
 // SettleContract settles the contract with id [contractIndex] using
 // oracle value [oracleValue] and signature [oracleSignature]
 func (c *LitRpcClient) SettleContract(contractIndex uint64, oracleValue int64, oracleSignature []byte) error {
+	return c.SettleContractCtx(context.Background(), contractIndex, oracleValue, oracleSignature)
+}
+
+// SettleContractCtx is like SettleContract, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SettleContractCtx(ctx context.Context, contractIndex uint64, oracleValue int64, oracleSignature []byte) error {
 	args := new(litrpc.SettleContractArgs)
 	args.CIdx = contractIndex
 	copy(args.OracleSig[:], oracleSignature)
 	args.OracleValue = oracleValue
 	reply := new(litrpc.SettleContractReply)
-	err := c.rpcConn.Call("LitRPC.SettleContract", args, reply)
+	err := c.callCtx(ctx, "LitRPC.SettleContract", args, reply)
 	if err != nil {
 		return err
 	}
 	if !reply.Success {
-		return fmt.Errorf("Server returned success = false")
+		return remoteError("LitRPC.SettleContract", "Server returned success = false")
 	}
 
 	return nil
@@ -534,17 +1168,22 @@ func (c *LitRpcClient) SettleContract(contractIndex uint64, oracleValue int64, o
 // When the oracle value is [valueFullyOurs], we get all the funds in the contract. When the value is [valueFullyTheirs]
 // our counter party gets all the funds. Between those two, a linear division is followed
 func (c *LitRpcClient) SetContractDivision(contractIndex uint64, valueFullyOurs, valueFullyTheirs int64) error {
+	return c.SetContractDivisionCtx(context.Background(), contractIndex, valueFullyOurs, valueFullyTheirs)
+}
+
+// SetContractDivisionCtx is like SetContractDivision, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SetContractDivisionCtx(ctx context.Context, contractIndex uint64, valueFullyOurs, valueFullyTheirs int64) error {
 	args := new(litrpc.SetContractDivisionArgs)
 	args.CIdx = contractIndex
 	args.ValueFullyOurs = valueFullyOurs
 	args.ValueFullyOurs = valueFullyTheirs
 	reply := new(litrpc.SetContractDivisionReply)
-	err := c.rpcConn.Call("LitRPC.SetContractDivision", args, reply)
+	err := c.callCtx(ctx, "LitRPC.SetContractDivision", args, reply)
 	if err != nil {
 		return err
 	}
 	if !reply.Success {
-		return fmt.Errorf("Server returned success = false")
+		return remoteError("LitRPC.SetContractDivision", "Server returned success = false")
 	}
 
 	return nil
@@ -552,16 +1191,21 @@ func (c *LitRpcClient) SetContractDivision(contractIndex uint64, valueFullyOurs,
 
 // SetContractCoinType specifies to use coin type [coinTyope] for the contract [contractIndex]. This cointype must be available or the server will return an error.
 func (c *LitRpcClient) SetContractCoinType(contractIndex uint64, coinType uint32) error {
+	return c.SetContractCoinTypeCtx(context.Background(), contractIndex, coinType)
+}
+
+// SetContractCoinTypeCtx is like SetContractCoinType, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SetContractCoinTypeCtx(ctx context.Context, contractIndex uint64, coinType uint32) error {
 	args := new(litrpc.SetContractCoinTypeArgs)
 	args.CIdx = contractIndex
 	args.CoinType = coinType
 	reply := new(litrpc.SetContractCoinTypeReply)
-	err := c.rpcConn.Call("LitRPC.SetContractCoinType", args, reply)
+	err := c.callCtx(ctx, "LitRPC.SetContractCoinType", args, reply)
 	if err != nil {
 		return err
 	}
 	if !reply.Success {
-		return fmt.Errorf("Server returned success = false")
+		return remoteError("LitRPC.SetContractCoinType", "Server returned success = false")
 	}
 
 	return nil
@@ -570,17 +1214,22 @@ func (c *LitRpcClient) SetContractCoinType(contractIndex uint64, coinType uint32
 // SetContractFunding describes how the funding of the contract [contractIndex] is supposed to happen. It will make us
 // fund [ourAmount] satoshi and request our counter party to fund [theirAmount] satoshi
 func (c *LitRpcClient) SetContractFunding(contractIndex uint64, ourAmount, theirAmount int64) error {
+	return c.SetContractFundingCtx(context.Background(), contractIndex, ourAmount, theirAmount)
+}
+
+// SetContractFundingCtx is like SetContractFunding, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SetContractFundingCtx(ctx context.Context, contractIndex uint64, ourAmount, theirAmount int64) error {
 	args := new(litrpc.SetContractFundingArgs)
 	args.CIdx = contractIndex
 	args.OurAmount = ourAmount
 	args.TheirAmount = theirAmount
 	reply := new(litrpc.SetContractFundingReply)
-	err := c.rpcConn.Call("LitRPC.SetContractFunding", args, reply)
+	err := c.callCtx(ctx, "LitRPC.SetContractFunding", args, reply)
 	if err != nil {
 		return err
 	}
 	if !reply.Success {
-		return fmt.Errorf("Server returned success = false")
+		return remoteError("LitRPC.SetContractFunding", "Server returned success = false")
 	}
 
 	return nil
@@ -588,16 +1237,21 @@ func (c *LitRpcClient) SetContractFunding(contractIndex uint64, ourAmount, their
 
 // SetContractSettlementTime sets the time (unix timestamp) the contract [contractIndex] is supposed to settle to [settlementTime]
 func (c *LitRpcClient) SetContractSettlementTime(contractIndex uint64, settlementTime uint64) error {
+	return c.SetContractSettlementTimeCtx(context.Background(), contractIndex, settlementTime)
+}
+
+// SetContractSettlementTimeCtx is like SetContractSettlementTime, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SetContractSettlementTimeCtx(ctx context.Context, contractIndex uint64, settlementTime uint64) error {
 	args := new(litrpc.SetContractSettlementTimeArgs)
 	args.CIdx = contractIndex
 	args.Time = settlementTime
 	reply := new(litrpc.SetContractSettlementTimeReply)
-	err := c.rpcConn.Call("LitRPC.SetContractSettlementTime", args, reply)
+	err := c.callCtx(ctx, "LitRPC.SetContractSettlementTime", args, reply)
 	if err != nil {
 		return err
 	}
 	if !reply.Success {
-		return fmt.Errorf("Server returned success = false")
+		return remoteError("LitRPC.SetContractSettlementTime", "Server returned success = false")
 	}
 
 	return nil
@@ -606,16 +1260,21 @@ func (c *LitRpcClient) SetContractSettlementTime(contractIndex uint64, settlemen
 // SetContractRPoint sets the public key of the R-point [rPoint] the oracle will use to sign the message with that is used
 // to settle contract [contractIndex]
 func (c *LitRpcClient) SetContractRPoint(contractIndex uint64, rPoint []byte) error {
+	return c.SetContractRPointCtx(context.Background(), contractIndex, rPoint)
+}
+
+// SetContractRPointCtx is like SetContractRPoint, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SetContractRPointCtx(ctx context.Context, contractIndex uint64, rPoint []byte) error {
 	args := new(litrpc.SetContractRPointArgs)
 	args.CIdx = contractIndex
 	copy(args.RPoint[:], rPoint)
 	reply := new(litrpc.SetContractRPointReply)
-	err := c.rpcConn.Call("LitRPC.SetContractRPoint", args, reply)
+	err := c.callCtx(ctx, "LitRPC.SetContractRPoint", args, reply)
 	if err != nil {
 		return err
 	}
 	if !reply.Success {
-		return fmt.Errorf("Server returned success = false")
+		return remoteError("LitRPC.SetContractRPoint", "Server returned success = false")
 	}
 
 	return nil
@@ -623,16 +1282,21 @@ func (c *LitRpcClient) SetContractRPoint(contractIndex uint64, rPoint []byte) er
 
 // SetContractOracle configures contract [contractIndex] to use oracle with index [oracleIndex]. You need to import the oracle first.
 func (c *LitRpcClient) SetContractOracle(contractIndex, oracleIndex uint64) error {
+	return c.SetContractOracleCtx(context.Background(), contractIndex, oracleIndex)
+}
+
+// SetContractOracleCtx is like SetContractOracle, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SetContractOracleCtx(ctx context.Context, contractIndex, oracleIndex uint64) error {
 	args := new(litrpc.SetContractOracleArgs)
 	args.CIdx = contractIndex
 	args.OIdx = oracleIndex
 	reply := new(litrpc.SetContractOracleReply)
-	err := c.rpcConn.Call("LitRPC.SetContractOracle", args, reply)
+	err := c.callCtx(ctx, "LitRPC.SetContractOracle", args, reply)
 	if err != nil {
 		return err
 	}
 	if !reply.Success {
-		return fmt.Errorf("Server returned success = false")
+		return remoteError("LitRPC.SetContractOracle", "Server returned success = false")
 	}
 
 	return nil
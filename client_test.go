@@ -0,0 +1,84 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/rpc"
+	"sync"
+)
+
+// fakeNode is a transport that answers calls with handlers, instead of a node
+type fakeNode struct {
+	mtx      sync.Mutex
+	handlers map[string]func(args interface{}) (interface{}, error)
+	calls    []string
+}
+
+func newFakeNode() *fakeNode {
+	return &fakeNode{handlers: make(map[string]func(interface{}) (interface{}, error))}
+}
+
+// handle makes the node answer calls to [method] with [handler]. An error
+// from the handler is returned as an error from the node.
+func (n *fakeNode) handle(method string, handler func(args interface{}) (interface{}, error)) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.handlers[method] = handler
+}
+
+// reply makes the node answer every call to [method] with [reply]
+func (n *fakeNode) reply(method string, reply interface{}) {
+	n.handle(method, func(interface{}) (interface{}, error) { return reply, nil })
+}
+
+// count returns the number of calls made to [method]
+func (n *fakeNode) count(method string) int {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	count := 0
+	for _, m := range n.calls {
+		if m == method {
+			count++
+		}
+	}
+	return count
+}
+
+func (n *fakeNode) Go(ctx context.Context, method string, args interface{}, reply interface{}, done func(error)) func(error) {
+	done(n.call(method, args, reply))
+	return noCancel
+}
+
+// call answers a call with the handler of [method]
+func (n *fakeNode) call(method string, args interface{}, reply interface{}) error {
+	n.mtx.Lock()
+	n.calls = append(n.calls, method)
+	handler := n.handlers[method]
+	n.mtx.Unlock()
+	if handler == nil {
+		return rpc.ServerError("rpc: can't find method " + method)
+	}
+	result, err := handler(args)
+	if err != nil {
+		return rpc.ServerError(err.Error())
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, reply)
+}
+
+func (n *fakeNode) Close() error {
+	return nil
+}
+
+// newTestClient returns a client that makes its calls to [node]
+func newTestClient(node *fakeNode, opts ...Option) *LitRpcClient {
+	c := new(LitRpcClient)
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+	c.conn = node
+	return c
+}
@@ -0,0 +1,451 @@
+// Command litrpccli calls a LIT node's RPCs from the command line, for
+// scripting operations on a node.
+//
+// Usage:
+//
+//	litrpccli [flags] <command> [arguments]
+//
+// Run litrpccli without arguments for the list of commands, or "litrpccli
+// shell" for an interactive prompt that keeps the connection open. With -json, the
+// result of a command is printed as JSON. With -key, the client connects to
+// the node's remote control interface using the key in the given file, which
+// holds the 32 byte private key, either raw or hex encoded.
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	litrpcclient "github.com/mit-dci/lit-rpc-client-go"
+	"github.com/mit-dci/lit/crypto/koblitz"
+)
+
+// errUsage is returned by commands that got the wrong arguments
+var errUsage = errors.New("wrong arguments")
+
+// command is a subcommand of litrpccli
+type command struct {
+	usage string
+	run   func(ctx context.Context, client *litrpcclient.LitRpcClient, args []string) (interface{}, error)
+}
+
+var commands = map[string]command{
+	"balance": {"", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		return c.ListBalancesCtx(ctx)
+	}},
+	"address": {"<cointype>", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, errUsage
+		}
+		return c.NewAddressCtx(ctx, parseUint32(args[0]), false)
+	}},
+	"send": {"<address> <amount>", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, errUsage
+		}
+		return c.SendCtx(ctx, args[0], parseInt64(args[1]))
+	}},
+	"peers": {"", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		return c.ListConnectionsCtx(ctx)
+	}},
+	"connect": {"<lnaddress> [host] [port]", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) < 1 || len(args) > 3 {
+			return nil, errUsage
+		}
+		host, port := "", uint32(0)
+		if len(args) > 1 {
+			host = args[1]
+		}
+		if len(args) > 2 {
+			port = parseUint32(args[2])
+		}
+		return nil, c.ConnectCtx(ctx, args[0], host, port)
+	}},
+	"channels": {"", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		return c.ListChannelsCtx(ctx)
+	}},
+	"fund": {"<peer> <cointype> <amount> [initialsend]", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) < 3 || len(args) > 4 {
+			return nil, errUsage
+		}
+		var initialSend int64
+		if len(args) > 3 {
+			initialSend = parseInt64(args[3])
+		}
+		return nil, c.FundChannelCtx(ctx, parseUint32(args[0]), parseUint32(args[1]), parseInt64(args[2]), initialSend, nil)
+	}},
+	"push": {"<channel> <amount> [data]", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) < 2 || len(args) > 3 {
+			return nil, errUsage
+		}
+		var data []byte
+		if len(args) > 2 {
+			data = []byte(args[2])
+		}
+		return c.PushCtx(ctx, parseUint32(args[0]), parseInt64(args[1]), data)
+	}},
+	"close": {"<channel>", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, errUsage
+		}
+		return nil, c.CloseChannelCtx(ctx, parseUint32(args[0]))
+	}},
+	"break": {"<channel>", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, errUsage
+		}
+		return nil, c.BreakChannelCtx(ctx, parseUint32(args[0]))
+	}},
+	"dlc list": {"", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		return c.ListContractsCtx(ctx)
+	}},
+	"dlc show": {"<contract>", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, errUsage
+		}
+		return c.GetContractCtx(ctx, parseUint64(args[0]))
+	}},
+	"dlc new": {"", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		return c.NewContractCtx(ctx)
+	}},
+	"dlc set": {"<contract> oracle <oracle> | cointype <cointype> | funding <ours> <theirs> | division <fullyours> <fullytheirs> | settletime <unixtime> | rpoint <hex>", setContract},
+	"dlc offer": {"<contract> <peer>", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, errUsage
+		}
+		return nil, c.OfferContractCtx(ctx, parseUint64(args[0]), parseUint32(args[1]))
+	}},
+	"dlc accept": {"<contract>", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, errUsage
+		}
+		return nil, c.AcceptContractCtx(ctx, parseUint64(args[0]))
+	}},
+	"dlc decline": {"<contract>", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, errUsage
+		}
+		return nil, c.DeclineContractCtx(ctx, parseUint64(args[0]))
+	}},
+	"dlc settle": {"<contract> <value> <signaturehex>", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) != 3 {
+			return nil, errUsage
+		}
+		return nil, c.SettleContractCtx(ctx, parseUint64(args[0]), parseInt64(args[1]), parseHex(args[2]))
+	}},
+	"oracle list": {"", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		return c.ListOraclesCtx(ctx)
+	}},
+	"oracle add": {"<pubkeyhex> <name>", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, errUsage
+		}
+		return c.AddOracleCtx(ctx, args[0], args[1])
+	}},
+	"oracle import": {"<url> <name>", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, errUsage
+		}
+		return c.ImportOracleCtx(ctx, args[0], args[1])
+	}},
+	"doctor": {"", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		return doctorReport{c.SelfTest(ctx)}, nil
+	}},
+	"stop": {"", func(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+		return nil, c.StopCtx(ctx)
+	}},
+}
+
+// doctorReport prints a self test report with a line per check
+type doctorReport struct {
+	*litrpcclient.SelfTestReport
+}
+
+func (r doctorReport) String() string {
+	var b strings.Builder
+	for _, check := range r.Checks {
+		status := "ok"
+		if check.Err != nil {
+			status = "FAIL"
+		} else if check.Warning {
+			status = "warn"
+		}
+		fmt.Fprintf(&b, "%-4s  %-12s  %s (%s)\n", status, check.Name, check.Detail, check.Duration.Round(time.Millisecond))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// setContract sets one of the parameters of a draft contract
+func setContract(ctx context.Context, c *litrpcclient.LitRpcClient, args []string) (interface{}, error) {
+	if len(args) < 3 {
+		return nil, errUsage
+	}
+	idx := parseUint64(args[0])
+	switch {
+	case args[1] == "oracle" && len(args) == 3:
+		return nil, c.SetContractOracleCtx(ctx, idx, parseUint64(args[2]))
+	case args[1] == "cointype" && len(args) == 3:
+		return nil, c.SetContractCoinTypeCtx(ctx, idx, parseUint32(args[2]))
+	case args[1] == "funding" && len(args) == 4:
+		return nil, c.SetContractFundingCtx(ctx, idx, parseInt64(args[2]), parseInt64(args[3]))
+	case args[1] == "division" && len(args) == 4:
+		return nil, c.SetContractDivisionCtx(ctx, idx, parseInt64(args[2]), parseInt64(args[3]))
+	case args[1] == "settletime" && len(args) == 3:
+		return nil, c.SetContractSettlementTimeCtx(ctx, idx, parseUint64(args[2]))
+	case args[1] == "rpoint" && len(args) == 3:
+		return nil, c.SetContractRPointCtx(ctx, idx, parseHex(args[2]))
+	}
+	return nil, errUsage
+}
+
+func main() {
+	host := flag.String("host", "127.0.0.1", "host of the LIT node")
+	port := flag.Int("port", 8001, "RPC port of the LIT node (the LN port with -key)")
+	keyFile := flag.String("key", "", "file with the remote control key, to connect to the remote control interface")
+	jsonOutput := flag.Bool("json", false, "print the result as JSON")
+	timeout := flag.Duration("timeout", 30*time.Second, "time to wait for the node")
+	flag.Usage = usage
+	flag.Parse()
+
+	shell := flag.NArg() == 1 && flag.Arg(0) == "shell"
+	name, cmd, args := lookup(flag.Args())
+	if name == "" && !shell {
+		usage()
+		os.Exit(2)
+	}
+
+	var opts []litrpcclient.Option
+	if *keyFile != "" {
+		key, err := readKey(*keyFile)
+		if err != nil {
+			fatal(err)
+		}
+		opts = append(opts, litrpcclient.WithRemoteControl(key))
+	}
+	client, err := litrpcclient.NewClient(*host, int32(*port), opts...)
+	if err != nil {
+		fatal(err)
+	}
+	defer client.Close()
+
+	if shell {
+		runShell(client, *timeout, *jsonOutput)
+		return
+	}
+	err = execute(client, *timeout, *jsonOutput, name, cmd, args)
+	if err == errUsage {
+		fmt.Fprintf(os.Stderr, "usage: litrpccli %s %s\n", name, cmd.usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+// argError is raised (as a panic) by the parse functions when an argument
+// is invalid, and recovered by execute
+type argError struct {
+	err error
+}
+
+// execute runs [cmd] with [args] and prints its result
+func execute(client *litrpcclient.LitRpcClient, timeout time.Duration, jsonOutput bool, name string, cmd command, args []string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(argError)
+			if !ok {
+				panic(r)
+			}
+			err = e.err
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	result, err := cmd.run(ctx, client, args)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(result)
+	} else if result != nil {
+		err = printResult(result)
+	}
+	if r, ok := result.(doctorReport); ok && err == nil && !r.OK() {
+		return errors.New("self test failed")
+	}
+	return err
+}
+
+// lookup finds the command named by the first one or two arguments, and
+// returns its name and the remaining arguments
+func lookup(args []string) (string, command, []string) {
+	if len(args) > 1 {
+		name := args[0] + " " + args[1]
+		if cmd, ok := commands[name]; ok {
+			return name, cmd, args[2:]
+		}
+	}
+	if len(args) > 0 {
+		if cmd, ok := commands[args[0]]; ok {
+			return args[0], cmd, args[1:]
+		}
+	}
+	return "", command{}, nil
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: litrpccli [flags] <command> [arguments]\n\nflags:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\ncommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	w := tabwriter.NewWriter(os.Stderr, 0, 4, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\t%s\n", name, commands[name].usage)
+	}
+	w.Flush()
+}
+
+// printResult prints [result] for humans: lists of structs as a table with
+// a column per field, and other values as they format with %+v
+func printResult(result interface{}) error {
+	rows := reflect.Indirect(reflect.ValueOf(result))
+	if rows.Kind() != reflect.Slice || rows.Len() == 0 || elemType(rows.Type()).Kind() != reflect.Struct {
+		fmt.Printf("%+v\n", result)
+		return nil
+	}
+	var columns []string
+	var fields []int
+	t := elemType(rows.Type())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		columns = append(columns, name)
+		fields = append(fields, i)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(columns, "\t"))
+	for i := 0; i < rows.Len(); i++ {
+		row := reflect.Indirect(rows.Index(i))
+		cells := make([]string, len(fields))
+		for j, field := range fields {
+			if row.IsValid() {
+				cells[j] = formatCell(row.Field(field))
+			}
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+// elemType returns the type of the elements of slice type [t], without
+// pointers
+func elemType(t reflect.Type) reflect.Type {
+	t = t.Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// formatCell formats [v] for a table cell: byte arrays like hashes and
+// pubkeys as hex, and floats without an exponent
+func formatCell(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return hex.EncodeToString(b)
+		}
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	}
+	if v.CanInterface() {
+		return fmt.Sprint(v.Interface())
+	}
+	return fmt.Sprint(v)
+}
+
+// readKey reads a private key from [path], stored raw or hex encoded
+func readKey(path string) (*koblitz.PrivateKey, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if decoded, err := hex.DecodeString(strings.TrimSpace(string(b))); err == nil {
+		b = decoded
+	}
+	if len(b) != 32 {
+		return nil, fmt.Errorf("%s: expected a 32 byte key, got %d bytes", path, len(b))
+	}
+	key, _ := koblitz.PrivKeyFromBytes(koblitz.S256(), b)
+	return key, nil
+}
+
+func parseUint32(s string) uint32 {
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		panic(argError{fmt.Errorf("invalid number %q", s)})
+	}
+	return uint32(n)
+}
+
+func parseUint64(s string) uint64 {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		panic(argError{fmt.Errorf("invalid number %q", s)})
+	}
+	return n
+}
+
+func parseInt64(s string) int64 {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		panic(argError{fmt.Errorf("invalid amount %q", s)})
+	}
+	return n
+}
+
+func parseHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(argError{fmt.Errorf("invalid hex %q", s)})
+	}
+	return b
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "litrpccli:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	litrpcclient "github.com/mit-dci/lit-rpc-client-go"
+)
+
+// runShell reads commands from stdin and runs them over the open connection
+// of [client], until stdin ends or the user types exit.
+//
+// Commands can be abbreviated to any unambiguous prefix of their words
+// ("ch" for channels, "d s" for dlc show); an ambiguous prefix lists the
+// commands it could mean. On a terminal, tab completes the command being
+// typed, or lists the commands it could be. There is no history.
+func runShell(client *litrpcclient.LitRpcClient, timeout time.Duration, jsonOutput bool) {
+	in := newLineReader()
+	for {
+		line, err := in.readLine("lit> ")
+		if err != nil {
+			fmt.Println()
+			return
+		}
+		words := strings.Fields(line)
+		if len(words) == 0 {
+			continue
+		}
+		switch words[0] {
+		case "exit", "quit":
+			return
+		case "help", "?":
+			usage()
+			continue
+		case "json":
+			jsonOutput = !jsonOutput
+			fmt.Println("json output:", jsonOutput)
+			continue
+		}
+
+		names, args := complete(words)
+		if len(names) != 1 {
+			if len(names) == 0 {
+				fmt.Println("unknown command, type help for the list of commands")
+			}
+			for _, name := range names {
+				fmt.Printf("  %s %s\n", name, commands[name].usage)
+			}
+			continue
+		}
+		cmd := commands[names[0]]
+		err = execute(client, timeout, jsonOutput, names[0], cmd, args)
+		if err == errUsage {
+			fmt.Printf("usage: %s %s\n", names[0], cmd.usage)
+		} else if err != nil {
+			fmt.Println("error:", err)
+		}
+	}
+}
+
+// complete returns the names of the commands whose words start with the
+// first one or two of [words], preferring exact matches and two word
+// commands, and the arguments that follow the command
+func complete(words []string) ([]string, []string) {
+	if name, _, args := lookup(words); name != "" {
+		return []string{name}, args
+	}
+	var one, two []string
+	for name := range commands {
+		parts := strings.Fields(name)
+		if !strings.HasPrefix(parts[0], words[0]) {
+			continue
+		}
+		if len(parts) == 1 {
+			one = append(one, name)
+		} else if len(words) > 1 && strings.HasPrefix(parts[1], words[1]) {
+			two = append(two, name)
+		} else if len(words) == 1 {
+			two = append(two, name)
+		}
+	}
+	sort.Strings(one)
+	sort.Strings(two)
+	if len(two) == 1 && len(words) > 1 {
+		return two, words[2:]
+	}
+	if len(one) == 1 && len(two) == 0 {
+		return one, words[1:]
+	}
+	return append(one, two...), nil
+}
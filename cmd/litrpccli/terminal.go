@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// lineReader reads the lines typed in the shell
+type lineReader interface {
+	// readLine prints [prompt] and returns the next line, or io.EOF when
+	// the input ends
+	readLine(prompt string) (string, error)
+}
+
+// newLineReader returns a line editor with tab completion if stdin is a
+// terminal that stty can switch to character mode, and otherwise a reader
+// of plain lines.
+func newLineReader() lineReader {
+	if stty("-g") != nil {
+		return scanReader{bufio.NewScanner(os.Stdin)}
+	}
+	return &lineEditor{in: bufio.NewReader(os.Stdin)}
+}
+
+// scanReader reads lines from piped input
+type scanReader struct {
+	in *bufio.Scanner
+}
+
+func (r scanReader) readLine(prompt string) (string, error) {
+	fmt.Print(prompt)
+	if !r.in.Scan() {
+		if r.in.Err() != nil {
+			return "", r.in.Err()
+		}
+		return "", io.EOF
+	}
+	return r.in.Text(), nil
+}
+
+// lineEditor reads lines from a terminal, one key at a time. It supports
+// backspace, Ctrl-U to clear the line, Ctrl-C to discard it, Ctrl-D to end
+// the input and tab to complete command names. Other control keys and
+// escape sequences, like the arrow keys, are ignored.
+type lineEditor struct {
+	in *bufio.Reader
+}
+
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	state, err := sttyOutput("-g")
+	if err != nil {
+		return "", err
+	}
+	// Only the line editing is done here: the terminal still translates
+	// output newlines, so commands print as usual
+	err = stty("-icanon", "-echo", "-isig", "min", "1")
+	if err != nil {
+		return "", err
+	}
+	defer stty(state)
+
+	fmt.Print(prompt)
+	var line []byte
+	for {
+		b, err := e.in.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case b == '\r' || b == '\n':
+			fmt.Println()
+			return string(line), nil
+		case b == 4: // Ctrl-D
+			if len(line) == 0 {
+				return "", io.EOF
+			}
+		case b == 3: // Ctrl-C
+			fmt.Println("^C")
+			return "", nil
+		case b == 21: // Ctrl-U
+			erase(line)
+			line = line[:0]
+		case b == 127 || b == 8:
+			if len(line) > 0 {
+				_, size := utf8.DecodeLastRune(line)
+				line = line[:len(line)-size]
+				fmt.Print("\b \b")
+			}
+		case b == '\t':
+			completed, matches := completeLine(string(line))
+			if len(completed) > len(line) {
+				erase(line)
+				line = append(line[:0], completed...)
+				fmt.Print(completed)
+			} else if len(matches) > 1 {
+				fmt.Printf("\n%s\n%s%s", strings.Join(matches, "  "), prompt, line)
+			}
+		case b == 27: // Escape sequence: ESC [ and a final byte
+			if next, _ := e.in.ReadByte(); next == '[' {
+				for {
+					c, err := e.in.ReadByte()
+					if err != nil || c >= 0x40 {
+						break
+					}
+				}
+			}
+		case b >= ' ':
+			line = append(line, b)
+			fmt.Print(string(b))
+		}
+	}
+}
+
+// erase removes [line] from the screen
+func erase(line []byte) {
+	n := utf8.RuneCount(line)
+	fmt.Print(strings.Repeat("\b", n) + strings.Repeat(" ", n) + strings.Repeat("\b", n))
+}
+
+// completeLine completes [line] to the longest prefix shared by the shell
+// commands that start with it, followed by a space if there's only one.
+// It also returns the matching commands.
+func completeLine(line string) (string, []string) {
+	prefix := strings.Join(strings.Fields(line), " ")
+	if prefix != "" && strings.HasSuffix(line, " ") {
+		prefix += " "
+	}
+	var matches []string
+	for _, name := range shellCommands() {
+		if strings.HasPrefix(name, prefix) {
+			matches = append(matches, name)
+		}
+	}
+	if len(matches) == 0 {
+		return line, nil
+	}
+	completed := matches[0]
+	for _, match := range matches[1:] {
+		for !strings.HasPrefix(match, completed) {
+			completed = completed[:len(completed)-1]
+		}
+	}
+	if len(matches) == 1 {
+		completed += " "
+	}
+	if len(completed) <= len(line) {
+		return line, matches
+	}
+	return completed, matches
+}
+
+// shellCommands returns the sorted names of the commands and the shell's
+// own commands
+func shellCommands() []string {
+	names := []string{"exit", "help", "json", "quit"}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// stty runs stty with [args] on the terminal on stdin
+func stty(args ...string) error {
+	_, err := sttyOutput(args...)
+	return err
+}
+
+// sttyOutput runs stty with [args] on the terminal on stdin, and returns
+// its output
+func sttyOutput(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
@@ -0,0 +1,144 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// capabilities remembers which methods the node does not support
+type capabilities struct {
+	mtx         sync.Mutex
+	unsupported map[string]bool
+}
+
+// check returns an *UnsupportedError if [method] is known to be unsupported
+func (r *capabilities) check(method string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.unsupported[method] {
+		return &UnsupportedError{Method: method}
+	}
+	return nil
+}
+
+// record remembers that [method] is not supported if [err] says so
+func (r *capabilities) record(method string, err error) {
+	if !errors.Is(err, ErrNotSupported) {
+		return
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.unsupported == nil {
+		r.unsupported = make(map[string]bool)
+	}
+	r.unsupported[method] = true
+}
+
+// UnsupportedMethods returns the methods the node reported not to know. Calls
+// to these methods fail with ErrNotSupported without contacting the node.
+func (c *LitRpcClient) UnsupportedMethods() []string {
+	c.capabilities.mtx.Lock()
+	defer c.capabilities.mtx.Unlock()
+	methods := make([]string, 0, len(c.capabilities.unsupported))
+	for method := range c.capabilities.unsupported {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// Feature is a group of client methods that depend on a set of node RPCs
+type Feature struct {
+	Name    string
+	Methods []string
+}
+
+// Features lists the client features that depend on RPCs not every LIT
+// version provides
+var Features = []Feature{
+	{"Listening", []string{"LitRPC.Listen", "LitRPC.GetListeningPorts"}},
+	{"Nicknames", []string{"LitRPC.AssignNickname"}},
+	{"State dumps", []string{"LitRPC.StateDump"}},
+	{"Multihop payments", []string{"LitRPC.PayMultihop", "LitRPC.ListMultihopPayments"}},
+	{"Oracles", []string{"LitRPC.ImportOracle", "LitRPC.AddOracle", "LitRPC.ListOracles"}},
+	{"Discreet log contracts", []string{
+		"LitRPC.NewContract", "LitRPC.GetContract", "LitRPC.ListContracts",
+		"LitRPC.OfferContract", "LitRPC.AcceptContract", "LitRPC.DeclineContract",
+		"LitRPC.SettleContract", "LitRPC.SetContractDivision", "LitRPC.SetContractCoinType",
+		"LitRPC.SetContractFunding", "LitRPC.SetContractSettlementTime",
+		"LitRPC.SetContractRPoint", "LitRPC.SetContractOracle",
+	}},
+}
+
+// Incompatibility describes a feature that will not work with the connected
+// node. It names the missing RPCs rather than a LIT version to upgrade to:
+// the client doesn't map methods to the LIT release that introduced them,
+// since nodes commonly run untagged builds, so a suggested minimum version
+// would often be wrong.
+type Incompatibility struct {
+	Feature        string
+	MissingMethods []string
+}
+
+// Supports checks if the node knows RPC method [method]. The method is called
+// with an argument that can't be decoded, so the node rejects the call before
+// executing it. The probe is sent straight over the connection: read-only
+// mode and circuit breakers don't apply to it, and its failure doesn't count
+// towards the breakers or get reported to the error sink.
+func (c *LitRpcClient) Supports(method string) (bool, error) {
+	return c.SupportsCtx(context.Background(), method)
+}
+
+// SupportsCtx is like Supports, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SupportsCtx(ctx context.Context, method string) (bool, error) {
+	if c.capabilities.check(method) != nil {
+		return false, nil
+	}
+	ctx, cancel := c.callContext(ctx, method)
+	defer cancel()
+	var reply interface{}
+	err := wrapError(method, callTransport(ctx, c.conn, method, 0, &reply))
+	c.capabilities.record(method, err)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, ErrNotSupported) {
+		return false, nil
+	}
+	if errors.Is(err, ErrRemote) {
+		return true, nil
+	}
+	return false, err
+}
+
+// CheckCompatibility checks which Features will not work with the connected
+// node because it lacks the RPCs they need. LIT does not report its version over
+// RPC, so this is determined by asking the node for each method. The features
+// returned need a LIT build that has all of the MissingMethods; finding the
+// version to upgrade to is left to the caller.
+func (c *LitRpcClient) CheckCompatibility() ([]Incompatibility, error) {
+	return c.CheckCompatibilityCtx(context.Background())
+}
+
+// CheckCompatibilityCtx is like CheckCompatibility, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) CheckCompatibilityCtx(ctx context.Context) ([]Incompatibility, error) {
+	var result []Incompatibility
+	for _, feature := range Features {
+		var missing []string
+		for _, method := range feature.Methods {
+			ok, err := c.SupportsCtx(ctx, method)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				missing = append(missing, method)
+			}
+		}
+		if len(missing) > 0 {
+			result = append(result, Incompatibility{feature.Name, missing})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package litrpcclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingSink counts the errors reported to it
+type countingSink struct {
+	reports []ErrorReport
+}
+
+func (s *countingSink) CaptureError(report ErrorReport) {
+	s.reports = append(s.reports, report)
+}
+
+func TestSupportsBypassesCallPipeline(t *testing.T) {
+	node := newFakeNode()
+	node.handle("LitRPC.Send", func(interface{}) (interface{}, error) {
+		return nil, errors.New("json: cannot unmarshal number into Go value of type litrpc.SendArgs")
+	})
+	sink := new(countingSink)
+	client := newTestClient(node, WithReadOnly(), WithCircuitBreaker(1, time.Hour), WithErrorSink(sink, RedactionPolicy{}))
+
+	for i := 0; i < 3; i++ {
+		ok, err := client.Supports("LitRPC.Send")
+		if err != nil || !ok {
+			t.Fatalf("Supports(Send) = %v, %v; want true", ok, err)
+		}
+		ok, err = client.Supports("LitRPC.Missing")
+		if err != nil || ok {
+			t.Fatalf("Supports(Missing) = %v, %v; want false", ok, err)
+		}
+	}
+	if open := client.OpenCircuits(); len(open) > 0 {
+		t.Errorf("probes opened circuits %v", open)
+	}
+	if len(sink.reports) > 0 {
+		t.Errorf("probes reported %d errors", len(sink.reports))
+	}
+	if n := node.count("LitRPC.Missing"); n != 1 {
+		t.Errorf("probed unsupported method %d times, want 1", n)
+	}
+}
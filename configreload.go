@@ -0,0 +1,160 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ClientConfig holds the client settings that can be changed while the client
+// is running. The zero value of each field is the client's default.
+type ClientConfig struct {
+	PeerPolicy     PeerPolicy `json:"peerPolicy"`
+	PushQueueDepth int        `json:"pushQueueDepth"`
+	PushCoalescing bool       `json:"pushCoalescing"`
+}
+
+// ConfigChange describes a reload of the configuration
+type ConfigChange struct {
+	Old ClientConfig
+	New ClientConfig
+	// Changed are the names of the ClientConfig fields that differ between
+	// Old and New
+	Changed []string
+}
+
+// ConfigReloader applies a ClientConfig from a JSON file to a client, and
+// applies it again whenever the file changes or the process receives SIGHUP,
+// so long-running bots can be reconfigured without a restart.
+type ConfigReloader struct {
+	// Interval is the time between checks of the file for changes
+	Interval time.Duration
+	// OnReload, if set, is called after every reload that changed a setting
+	OnReload func(ConfigChange)
+
+	client *LitRpcClient
+	path   string
+
+	mtx     sync.Mutex
+	config  ClientConfig
+	modTime time.Time
+}
+
+// NewConfigReloader creates a ConfigReloader for [client] that reads its
+// configuration from the file at [path], and applies it to [client]
+func NewConfigReloader(client *LitRpcClient, path string) (*ConfigReloader, error) {
+	r := &ConfigReloader{
+		Interval: 5 * time.Second,
+		client:   client,
+		path:     path,
+	}
+	_, err := r.Reload()
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Config returns the configuration that was last applied
+func (r *ConfigReloader) Config() ClientConfig {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.config
+}
+
+// Reload reads the file and applies its configuration to the client. If the
+// file can't be read or parsed, the current configuration stays in effect
+// and the error is returned.
+func (r *ConfigReloader) Reload() (ConfigChange, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return ConfigChange{}, err
+	}
+	b, err := ioutil.ReadFile(r.path)
+	if err != nil {
+		return ConfigChange{}, err
+	}
+	var config ClientConfig
+	err = json.Unmarshal(b, &config)
+	if err != nil {
+		return ConfigChange{}, err
+	}
+
+	r.client.SetPeerPolicy(config.PeerPolicy)
+	r.client.SetPushQueueDepth(config.PushQueueDepth)
+	r.client.SetPushCoalescing(config.PushCoalescing)
+
+	change := ConfigChange{Old: r.config, New: config, Changed: changedFields(r.config, config)}
+	r.config = config
+	r.modTime = info.ModTime()
+	return change, nil
+}
+
+// Run reloads the configuration when the file's modification time changes,
+// checked every Interval, or when the process receives SIGHUP, until [ctx]
+// is cancelled. Reload errors are passed to the handler set with
+// WithAsyncErrorHandler.
+func (r *ConfigReloader) Run(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.reload()
+		case <-ticker.C:
+			if r.modified() {
+				r.reload()
+			}
+		}
+	}
+}
+
+// modified returns true if the file changed since the last reload
+func (r *ConfigReloader) modified() bool {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return false
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return !info.ModTime().Equal(r.modTime)
+}
+
+// reload reloads the configuration and reports the outcome
+func (r *ConfigReloader) reload() {
+	change, err := r.Reload()
+	if err != nil {
+		r.client.asyncError(err)
+		return
+	}
+	if len(change.Changed) > 0 && r.OnReload != nil {
+		r.OnReload(change)
+	}
+}
+
+// changedFields returns the names of the fields that differ between [a] and [b]
+func changedFields(a, b ClientConfig) []string {
+	var changed []string
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	for i := 0; i < va.NumField(); i++ {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			changed = append(changed, va.Type().Field(i).Name)
+		}
+	}
+	return changed
+}
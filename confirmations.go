@@ -0,0 +1,44 @@
+package litrpcclient
+
+// defaultConfirmations are the confirmations required by default, per coin
+// type: 1 for regtest coins, 3 for testnet coins and 6 for mainnet coins
+var defaultConfirmations = map[uint32]int32{
+	0:     6, // Bitcoin
+	1:     3, // Bitcoin testnet3
+	257:   1, // Bitcoin regtest
+	2:     6, // Litecoin
+	65537: 3, // Litecoin testnet4
+	258:   1, // Litecoin regtest
+	28:    6, // Vertcoin
+	65536: 3, // Vertcoin testnet
+	261:   1, // Vertcoin regtest
+}
+
+// unknownCoinConfirmations is required for coin types without a default
+const unknownCoinConfirmations = 6
+
+// WithConfirmations sets the number of confirmations helpers that wait for a
+// transaction of coin type [coinType] to confirm (like FundChannelProgress)
+// require before they consider it confirmed.
+func WithConfirmations(coinType uint32, confirmations int32) Option {
+	return func(o *clientOptions) {
+		if o.confirmations == nil {
+			o.confirmations = make(map[uint32]int32)
+		}
+		o.confirmations[coinType] = confirmations
+	}
+}
+
+// RequiredConfirmations returns the number of confirmations required for
+// transactions of coin type [coinType]: the number set with WithConfirmations,
+// or the default (1 for regtest, 3 for testnet and 6 for mainnet coins, and 6
+// for coin types the client doesn't know).
+func (c *LitRpcClient) RequiredConfirmations(coinType uint32) int32 {
+	if n, ok := c.opts.confirmations[coinType]; ok {
+		return n
+	}
+	if n, ok := defaultConfirmations[coinType]; ok {
+		return n
+	}
+	return unknownCoinConfirmations
+}
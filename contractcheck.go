@@ -0,0 +1,178 @@
+package litrpcclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mit-dci/lit-rpc-client-go/oracle"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// dustLimit is the smallest output (in satoshi) the coins LIT supports relay
+const dustLimit = 546
+
+// coinAssets are the names oracles use for the asset of each coin type
+var coinAssets = map[uint32][]string{
+	0:     {"BTC", "Bitcoin"},
+	1:     {"BTC", "Bitcoin"},
+	257:   {"BTC", "Bitcoin"},
+	2:     {"LTC", "Litecoin"},
+	65537: {"LTC", "Litecoin"},
+	258:   {"LTC", "Litecoin"},
+	28:    {"VTC", "Vertcoin"},
+	65536: {"VTC", "Vertcoin"},
+	261:   {"VTC", "Vertcoin"},
+}
+
+// ContractCheckError is returned by CheckContract for a contract that can't
+// be funded or settled as configured
+type ContractCheckError struct {
+	// Field is the name of the contract field that fails the check
+	Field  string
+	Reason string
+}
+
+func (e *ContractCheckError) Error() string {
+	return fmt.Sprintf("Contract check failed: %s %s", e.Field, e.Reason)
+}
+
+// WithContractChecks makes OfferContract run CheckContract first, and refuse
+// to offer contracts that fail it
+func WithContractChecks() Option {
+	return func(o *clientOptions) {
+		o.contractChecks = true
+	}
+}
+
+// CheckContract verifies that contract [contractIndex] can be funded and
+// settled: the funding amounts and every payout of the division are either
+// zero or above the dust limit, our funding amount is covered by the mature
+// segwit balance of the node's wallet for the coin type, and the datasource
+// the contract's R-point belongs to quotes the asset of the contract's coin
+// type.
+//
+// There is no channel reserve to check: LIT funds contracts with an on-chain
+// transaction from the wallets of both parties, not from channels, so what
+// our side has to hold back is its wallet balance. The fee of the funding
+// transaction comes on top of the funding amount and is not known up front,
+// so the balance check only rules out contracts we can't fund at all. The
+// peer's funds can't be checked, as LIT doesn't report them.
+//
+// The datasource is
+// looked up through the REST API of the oracle, so this check is skipped for
+// oracles added with AddOracle instead of ImportOracle, and for coin types the
+// client doesn't know. Returns a *ContractCheckError if a check fails.
+func (c *LitRpcClient) CheckContract(contractIndex uint64) error {
+	return c.CheckContractCtx(context.Background(), contractIndex)
+}
+
+// CheckContractCtx is like CheckContract, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) CheckContractCtx(ctx context.Context, contractIndex uint64) error {
+	contract, err := c.GetContractCtx(ctx, contractIndex)
+	if err != nil {
+		return err
+	}
+	err = checkContractAmounts(contract)
+	if err != nil {
+		return err
+	}
+	err = c.checkContractFunds(ctx, contract)
+	if err != nil {
+		return err
+	}
+
+	assets, ok := coinAssets[contract.CoinType]
+	if !ok {
+		return nil
+	}
+	oracles, err := c.ListOraclesCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, o := range oracles {
+		if o.A != contract.OracleA {
+			continue
+		}
+		if o.Url == "" {
+			return nil
+		}
+		name, err := oracleDatasource(ctx, o.Url, contract.OracleR, contract.OracleTimestamp)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			return &ContractCheckError{Field: "OracleR", Reason: "is not an R-point of the oracle"}
+		}
+		for _, asset := range assets {
+			if strings.Contains(strings.ToLower(name), strings.ToLower(asset)) {
+				return nil
+			}
+		}
+		return &ContractCheckError{Field: "CoinType", Reason: fmt.Sprintf("does not match datasource %q", name)}
+	}
+	return &ContractCheckError{Field: "OracleA", Reason: "is not a known oracle"}
+}
+
+// checkContractAmounts checks the funding and payouts of [contract] against
+// the dust limit
+func checkContractAmounts(contract *lnutil.DlcContract) error {
+	total := contract.OurFundingAmount + contract.TheirFundingAmount
+	switch {
+	case contract.OurFundingAmount < 0 || (contract.OurFundingAmount > 0 && contract.OurFundingAmount < dustLimit):
+		return &ContractCheckError{Field: "OurFundingAmount", Reason: "is below the dust limit"}
+	case contract.TheirFundingAmount < 0 || (contract.TheirFundingAmount > 0 && contract.TheirFundingAmount < dustLimit):
+		return &ContractCheckError{Field: "TheirFundingAmount", Reason: "is below the dust limit"}
+	case total == 0:
+		return &ContractCheckError{Field: "OurFundingAmount", Reason: "and TheirFundingAmount are zero"}
+	}
+	for i, d := range contract.Division {
+		ours, theirs := d.ValueOurs, total-d.ValueOurs
+		if ours < 0 || theirs < 0 || (ours > 0 && ours < dustLimit) || (theirs > 0 && theirs < dustLimit) {
+			return &ContractCheckError{Field: fmt.Sprintf("Division[%d]", i), Reason: "has a payout below the dust limit"}
+		}
+	}
+	return nil
+}
+
+// checkContractFunds checks that the node's wallet holds enough mature
+// segwit outputs of the coin type of [contract] to fund our side of it
+func (c *LitRpcClient) checkContractFunds(ctx context.Context, contract *lnutil.DlcContract) error {
+	if contract.OurFundingAmount == 0 {
+		return nil
+	}
+	balances, err := c.ListBalancesCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, b := range balances {
+		if b.CoinType != contract.CoinType {
+			continue
+		}
+		if contract.OurFundingAmount > b.MatureWitty {
+			return &ContractCheckError{Field: "OurFundingAmount", Reason: fmt.Sprintf("exceeds the mature wallet balance of %d", b.MatureWitty)}
+		}
+		return nil
+	}
+	return &ContractCheckError{Field: "CoinType", Reason: "is not a coin type of the node's wallet"}
+}
+
+// oracleDatasource returns the name of the datasource of the oracle at [url]
+// that uses R-point [rPoint] at [timestamp], or an empty string if none does
+func oracleDatasource(ctx context.Context, url string, rPoint [33]byte, timestamp uint64) (string, error) {
+	client := oracle.NewClient(url)
+	datasources, err := client.Datasources(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, ds := range datasources {
+		r, err := client.RPoint(ctx, ds.Id, timestamp)
+		if err != nil {
+			return "", err
+		}
+		if r == rPoint {
+			return ds.Name, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,37 @@
+package litrpcclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+func TestCheckContractFunds(t *testing.T) {
+	node := newFakeNode()
+	node.reply("LitRPC.Balance", litrpc.BalanceReply{Balances: []litrpc.CoinBalReply{{CoinType: 257, MatureWitty: 100000}}})
+	node.reply("LitRPC.ListOracles", litrpc.ListOraclesReply{})
+	var contract lnutil.DlcContract
+	node.handle("LitRPC.GetContract", func(interface{}) (interface{}, error) {
+		return litrpc.GetContractReply{Contract: &contract}, nil
+	})
+	c := newTestClient(node)
+
+	for _, test := range []struct {
+		coinType uint32
+		funding  int64
+		field    string
+	}{
+		{257, 50000, "OracleA"},
+		{257, 200000, "OurFundingAmount"},
+		{258, 50000, "CoinType"},
+	} {
+		contract = lnutil.DlcContract{CoinType: test.coinType, OurFundingAmount: test.funding, TheirFundingAmount: 50000}
+		var checkErr *ContractCheckError
+		err := c.CheckContract(0)
+		if !errors.As(err, &checkErr) || checkErr.Field != test.field {
+			t.Errorf("coin type %d, funding %d: got %v, want a check of %s", test.coinType, test.funding, err, test.field)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package litrpcclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// ContractEventKind describes the kind of state transition a contract made
+type ContractEventKind int
+
+const (
+	// ContractOffered is emitted when a peer offers us a new contract
+	ContractOffered ContractEventKind = iota
+	// ContractAccepted is emitted when a contract is accepted (by us or by our peer)
+	ContractAccepted
+	// ContractDeclined is emitted when a contract is declined
+	ContractDeclined
+	// ContractFunded is emitted when the funding of a contract is confirmed and it becomes active
+	ContractFunded
+	// ContractSettled is emitted when a contract is being settled or is closed
+	ContractSettled
+	// ContractFailed is emitted when the node puts a contract in the error state
+	ContractFailed
+	// ContractStatusChanged is emitted for any other status change
+	ContractStatusChanged
+)
+
+func (k ContractEventKind) String() string {
+	switch k {
+	case ContractOffered:
+		return "offered"
+	case ContractAccepted:
+		return "accepted"
+	case ContractDeclined:
+		return "declined"
+	case ContractFunded:
+		return "funded"
+	case ContractSettled:
+		return "settled"
+	case ContractFailed:
+		return "failed"
+	}
+	return "status changed"
+}
+
+// ContractEvent is emitted by WatchContracts when a contract changes state
+type ContractEvent struct {
+	Kind           ContractEventKind
+	Contract       *lnutil.DlcContract
+	PreviousStatus lnutil.DlcContractStatus
+}
+
+// WatchContracts polls the node's contracts every [interval] and emits an
+// event for each contract that changed status since the previous poll. Contracts
+// that exist when the watch starts don't generate events, unless they change
+// status afterwards. After a failed poll, the watch waits as set with
+// WithWatchBackoff. The returned channel is closed when [ctx] is cancelled.
+func (c *LitRpcClient) WatchContracts(ctx context.Context, interval time.Duration) <-chan ContractEvent {
+	events := make(chan ContractEvent)
+	go func() {
+		defer close(events)
+		var known map[uint64]lnutil.DlcContractStatus
+		pollEvery(ctx, interval, c.opts.watchBackoff, func() error {
+			contracts, err := c.subscriptions().ListContractsCtx(ctx)
+			if err != nil {
+				return err
+			}
+			current := make(map[uint64]lnutil.DlcContractStatus, len(contracts))
+			for _, contract := range contracts {
+				current[contract.Idx] = contract.Status
+				if known == nil {
+					continue
+				}
+				prev, ok := known[contract.Idx]
+				if ok && prev == contract.Status {
+					continue
+				}
+				kind, emit := contractEventKind(ok, contract.Status)
+				if !emit {
+					continue
+				}
+				select {
+				case events <- ContractEvent{Kind: kind, Contract: contract, PreviousStatus: prev}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			known = current
+			return nil
+		})
+	}()
+	return events
+}
+
+// contractEventKind maps a (new) contract status to the kind of event to
+// emit. Newly seen drafts don't generate an event.
+func contractEventKind(existed bool, status lnutil.DlcContractStatus) (ContractEventKind, bool) {
+	switch status {
+	case lnutil.ContractStatusDraft:
+		return ContractStatusChanged, existed
+	case lnutil.ContractStatusOfferedToMe:
+		return ContractOffered, true
+	case lnutil.ContractStatusAccepted, lnutil.ContractStatusAcknowledged:
+		return ContractAccepted, true
+	case lnutil.ContractStatusDeclined:
+		return ContractDeclined, true
+	case lnutil.ContractStatusActive:
+		return ContractFunded, true
+	case lnutil.ContractStatusSettling, lnutil.ContractStatusClosed:
+		return ContractSettled, true
+	case lnutil.ContractStatusError:
+		return ContractFailed, true
+	}
+	return ContractStatusChanged, true
+}
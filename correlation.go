@@ -0,0 +1,47 @@
+package litrpcclient
+
+import (
+	"bytes"
+	"context"
+)
+
+// correlationPrefix marks payment data that holds a correlation id
+const correlationPrefix = "cid:"
+
+type correlationKey struct{}
+
+// WithCorrelationID returns a context that carries correlation id [id]. Calls
+// made with it include the id in their ErrorReport, and Push and FundChannel
+// embed it in the payment data when the caller passed no data of its own and
+// the id fits (it can be up to 28 bytes), so the receiving side can read it
+// from its state dump with CorrelationIDFromData.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationID returns the correlation id carried by [ctx], if any
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationKey{}).(string)
+	return id
+}
+
+// CorrelationIDFromData returns the correlation id embedded in payment data
+// (like the Data of a state in StateDump), if it holds one
+func CorrelationIDFromData(data [32]byte) (string, bool) {
+	if !bytes.HasPrefix(data[:], []byte(correlationPrefix)) {
+		return "", false
+	}
+	id := bytes.TrimRight(data[len(correlationPrefix):], "\x00")
+	return string(id), true
+}
+
+// correlationData embeds the correlation id of [ctx] in [data], if [data] is
+// empty and the id fits
+func correlationData(ctx context.Context, data [32]byte) [32]byte {
+	id := CorrelationID(ctx)
+	if id == "" || data != [32]byte{} || len(correlationPrefix)+len(id) > len(data) {
+		return data
+	}
+	copy(data[:], correlationPrefix+id)
+	return data
+}
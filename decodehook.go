@@ -0,0 +1,32 @@
+package litrpcclient
+
+// DecodeHook is called with the decoded reply to an RPC call. The reply is
+// the reply struct from package litrpc (like *litrpc.BalanceReply for
+// LitRPC.Balance) and can be modified in place. An error returned by the hook
+// is returned by the call.
+type DecodeHook func(method string, reply interface{}) error
+
+// WithDecodeHook makes the client call [hook] after a reply to [method]
+// (like "LitRPC.ChannelList") was decoded, before it is returned or converted
+// by the wrapper method. This allows applications to normalize or enrich
+// replies in one place. Hooks run in the order of their options, and only
+// apply to the client created with them.
+func WithDecodeHook(method string, hook DecodeHook) Option {
+	return func(o *clientOptions) {
+		if o.decodeHooks == nil {
+			o.decodeHooks = make(map[string][]DecodeHook)
+		}
+		o.decodeHooks[method] = append(o.decodeHooks[method], hook)
+	}
+}
+
+// runDecodeHooks runs the hooks set for [method] on [reply]
+func (c *LitRpcClient) runDecodeHooks(method string, reply interface{}) error {
+	for _, hook := range c.opts.decodeHooks[method] {
+		err := hook(method, reply)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package litrpcclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+func TestDecodeHookIsPerClient(t *testing.T) {
+	node := newFakeNode()
+	node.reply("LitRPC.Balance", litrpc.BalanceReply{Balances: []litrpc.CoinBalReply{{CoinType: 1}}})
+	setCoin := func(method string, reply interface{}) error {
+		reply.(*litrpc.BalanceReply).Balances[0].CoinType = 257
+		return nil
+	}
+	hooked := newTestClient(node, WithDecodeHook("LitRPC.Balance", setCoin))
+	plain := newTestClient(node)
+
+	balances, err := hooked.ListBalances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balances[0].CoinType != 257 {
+		t.Fatalf("hooked client got coin type %d, want 257", balances[0].CoinType)
+	}
+	balances, err = plain.ListBalances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balances[0].CoinType != 1 {
+		t.Fatalf("client without hook got coin type %d, want 1", balances[0].CoinType)
+	}
+
+	var reply litrpc.BalanceReply
+	err = hooked.CallAsync(context.Background(), "LitRPC.Balance", nil).Result(&reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Balances[0].CoinType != 257 {
+		t.Fatalf("async call got coin type %d, want 257", reply.Balances[0].CoinType)
+	}
+}
@@ -0,0 +1,180 @@
+package litrpcclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mit-dci/lit-rpc-client-go/oracle"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// SettlementObserver reports how a contract was settled on chain. LIT does
+// not expose the settlement transaction of a contract, so this has to come
+// from outside the node, like a block explorer or a full node watching the
+// contract's payout addresses.
+type SettlementObserver interface {
+	// SettlementPayout returns the amount the settlement of [contract] paid
+	// to us, and false if the settlement is not on chain (yet)
+	SettlementPayout(ctx context.Context, contract *lnutil.DlcContract) (int64, bool, error)
+}
+
+// SettlementDispute describes a contract whose settlement on chain doesn't
+// match the payout agreed for the value the oracle published
+type SettlementDispute struct {
+	Contract    *lnutil.DlcContract
+	OracleValue int64
+	// Expected is our payout according to the contract's division, or -1 if
+	// the division has no entry for the oracle value
+	Expected int64
+	// Observed is our payout according to the SettlementObserver
+	Observed int64
+}
+
+func (d *SettlementDispute) Error() string {
+	return fmt.Sprintf("Contract %d settled for %d, expected %d for oracle value %d",
+		d.Contract.Idx, d.Observed, d.Expected, d.OracleValue)
+}
+
+// SettlementChecker compares the settlement of closed contracts with the
+// payout computed from their division and the value their oracle published,
+// to detect misbehaving counterparties or node bugs. The oracle value is
+// fetched from the oracle's REST API, so only contracts of oracles added with
+// ImportOracle can be checked.
+type SettlementChecker struct {
+	// Interval is the time between checks in Run
+	Interval time.Duration
+	// Tolerance is the largest difference between the expected and observed
+	// payout that is not a dispute, to allow for transaction fees
+	Tolerance int64
+	// OnDispute is called for every contract whose settlement doesn't match.
+	// Run reports every contract once.
+	OnDispute func(*SettlementDispute)
+
+	client   *LitRpcClient
+	observer SettlementObserver
+
+	mtx     sync.Mutex
+	checked map[uint64]bool
+}
+
+// NewSettlementChecker creates a SettlementChecker for the contracts of
+// [client], that learns about settlements from [observer]
+func NewSettlementChecker(client *LitRpcClient, observer SettlementObserver) *SettlementChecker {
+	return &SettlementChecker{
+		Interval:  time.Minute,
+		Tolerance: 10000,
+		client:    client,
+		observer:  observer,
+		checked:   make(map[uint64]bool),
+	}
+}
+
+// Check checks the settlement of contract [contractIndex]. It returns a
+// *SettlementDispute if the settlement doesn't match, and nil if it does or
+// if the contract can't be checked yet.
+func (s *SettlementChecker) Check(ctx context.Context, contractIndex uint64) (*SettlementDispute, error) {
+	contract, err := s.client.GetContractCtx(ctx, contractIndex)
+	if err != nil {
+		return nil, err
+	}
+	dispute, _, err := s.check(ctx, contract)
+	return dispute, err
+}
+
+// Run checks the closed contracts every Interval until [ctx] is cancelled.
+// Errors are passed to the handler set with WithAsyncErrorHandler.
+func (s *SettlementChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		s.checkAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkAll checks the closed contracts that were not checked before
+func (s *SettlementChecker) checkAll(ctx context.Context) {
+	contracts, err := s.client.ListContractsCtx(ctx)
+	if err != nil {
+		s.client.asyncError(err)
+		return
+	}
+	for _, contract := range contracts {
+		s.mtx.Lock()
+		checked := s.checked[contract.Idx]
+		s.mtx.Unlock()
+		if checked || contract.Status != lnutil.ContractStatusClosed {
+			continue
+		}
+
+		dispute, done, err := s.check(ctx, contract)
+		if err != nil {
+			s.client.asyncError(fmt.Errorf("checking settlement of contract %d: %w", contract.Idx, err))
+			continue
+		}
+		if !done {
+			continue
+		}
+		s.mtx.Lock()
+		s.checked[contract.Idx] = true
+		s.mtx.Unlock()
+		if dispute != nil && s.OnDispute != nil {
+			s.OnDispute(dispute)
+		}
+	}
+}
+
+// check compares the settlement of [contract] with its division. It returns
+// whether the contract could be checked.
+func (s *SettlementChecker) check(ctx context.Context, contract *lnutil.DlcContract) (*SettlementDispute, bool, error) {
+	observed, settled, err := s.observer.SettlementPayout(ctx, contract)
+	if err != nil || !settled {
+		return nil, false, err
+	}
+	value, ok, err := s.oracleValue(ctx, contract)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	dispute := &SettlementDispute{Contract: contract, OracleValue: value, Expected: -1, Observed: observed}
+	for _, d := range contract.Division {
+		if d.OracleValue == value {
+			dispute.Expected = d.ValueOurs
+		}
+	}
+	diff := dispute.Expected - observed
+	if dispute.Expected >= 0 && diff >= -s.Tolerance && diff <= s.Tolerance {
+		return nil, true, nil
+	}
+	return dispute, true, nil
+}
+
+// oracleValue returns the value the oracle of [contract] published for the
+// contract's R-point, and false if the oracle has no REST API or didn't
+// publish yet
+func (s *SettlementChecker) oracleValue(ctx context.Context, contract *lnutil.DlcContract) (int64, bool, error) {
+	oracles, err := s.client.ListOraclesCtx(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, o := range oracles {
+		if o.A != contract.OracleA || o.Url == "" {
+			continue
+		}
+		publication, err := oracle.NewClient(o.Url).Publication(ctx, contract.OracleR)
+		if err == oracle.ErrNotPublished {
+			return 0, false, nil
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		return publication.Value, true, nil
+	}
+	return 0, false, nil
+}
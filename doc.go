@@ -0,0 +1,25 @@
+// Package litrpcclient is a client for the RPC interface of LIT
+// (https://github.com/mit-dci/lit) nodes.
+//
+// A client is created with NewClient and configured with Option values. It
+// talks to LIT's websocket RPC port by default, or to the remote control
+// interface on the LN port with WithRemoteControl. Every RPC method X has a
+// variant XCtx that takes a context for cancellation and deadlines.
+//
+// Errors returned by the client can be matched with errors.Is against
+// ErrTimeout, ErrNotConnected, ErrNotSupported, ErrServerRejected,
+// ErrUnexpectedReply and the other Err values of this package, and with
+// errors.As against *RemoteError and the other error types.
+//
+// # Stability
+//
+// This package follows semantic versioning; Version holds the current version.
+// Within major version 1, the exported functions, methods, types, options and
+// errors keep their signature and meaning. New methods, options, errors and
+// struct fields may be added in minor versions. Functions that are superseded
+// are marked Deprecated, keep working and delegate to their replacement, and
+// are only removed in the next major version. Types from the LIT packages
+// (litrpc, lnutil, qln, dlc) that appear in the API follow LIT's versioning.
+package litrpcclient
+
+//go:generate go run ./internal/mockgen
@@ -0,0 +1,99 @@
+package litrpcclient
+
+import (
+	"context"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+// DualFundRequest is a request from a peer to open a channel that both sides
+// fund
+type DualFundRequest struct {
+	PeerIndex uint32
+	CoinType  uint32
+	// TheirAmount is the amount the peer contributes
+	TheirAmount int64
+	// RequestedAmount is the amount the peer asks us to contribute
+	RequestedAmount int64
+}
+
+// DualFundChannel asks peer [peerIndex] to open a channel of coin type
+// [coinType] that we fund with [ourAmount] and the peer funds with
+// [theirAmount]. The channel is opened once the peer accepts the request
+// with DualFundAccept.
+func (c *LitRpcClient) DualFundChannel(peerIndex, coinType uint32, ourAmount, theirAmount int64) error {
+	return c.DualFundChannelCtx(context.Background(), peerIndex, coinType, ourAmount, theirAmount)
+}
+
+// DualFundChannelCtx is like DualFundChannel, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) DualFundChannelCtx(ctx context.Context, peerIndex, coinType uint32, ourAmount, theirAmount int64) error {
+	err := c.checkPeer(ctx, peerIndex)
+	if err != nil {
+		return err
+	}
+
+	args := new(litrpc.DualFundArgs)
+	args.Peer = peerIndex
+	args.CoinType = coinType
+	args.OurAmount = ourAmount
+	args.TheirAmount = theirAmount
+	reply := new(litrpc.StatusReply)
+	return c.callCtx(ctx, "LitRPC.DualFundChannel", args, reply)
+}
+
+// PendingDualFund returns the dual funding request a peer sent us that we
+// did not accept or decline yet. LIT holds at most one such request at a
+// time; nil is returned if there is none.
+func (c *LitRpcClient) PendingDualFund() (*DualFundRequest, error) {
+	return c.PendingDualFundCtx(context.Background())
+}
+
+// PendingDualFundCtx is like PendingDualFund, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) PendingDualFundCtx(ctx context.Context) (*DualFundRequest, error) {
+	args := new(litrpc.NoArgs)
+	reply := new(litrpc.PendingDualFundReply)
+	err := c.callCtx(ctx, "LitRPC.PendingDualFund", args, reply)
+	if err != nil {
+		return nil, err
+	}
+	if !reply.Pending {
+		return nil, nil
+	}
+	return &DualFundRequest{
+		PeerIndex:       reply.PeerIdx,
+		CoinType:        reply.CoinType,
+		TheirAmount:     reply.TheirAmount,
+		RequestedAmount: reply.RequestedAmount,
+	}, nil
+}
+
+// DualFundAccept accepts the pending dual funding request, contributing the
+// requested amount to the channel
+func (c *LitRpcClient) DualFundAccept() error {
+	return c.DualFundAcceptCtx(context.Background())
+}
+
+// DualFundAcceptCtx is like DualFundAccept, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) DualFundAcceptCtx(ctx context.Context) error {
+	return c.dualFundRespond(ctx, true)
+}
+
+// DualFundDecline declines the pending dual funding request
+func (c *LitRpcClient) DualFundDecline() error {
+	return c.DualFundDeclineCtx(context.Background())
+}
+
+// DualFundDeclineCtx is like DualFundDecline, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) DualFundDeclineCtx(ctx context.Context) error {
+	return c.dualFundRespond(ctx, false)
+}
+
+// dualFundRespond answers the pending dual funding request
+func (c *LitRpcClient) dualFundRespond(ctx context.Context, accept bool) error {
+	args := new(litrpc.DualFundRespondArgs)
+	if accept {
+		args.AcceptOrDecline = 1
+	}
+	reply := new(litrpc.StatusReply)
+	return c.callCtx(ctx, "LitRPC.DualFundRespond", args, reply)
+}
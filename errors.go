@@ -0,0 +1,177 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"strings"
+)
+
+var (
+	// ErrTimeout is returned when a call to the node timed out
+	ErrTimeout = errors.New("timeout")
+	// ErrNotConnected matches every error caused by the client not being
+	// connected to the node, like ErrClosed and ErrDisconnected
+	ErrNotConnected = errors.New("not connected")
+	// ErrClosed is returned when the connection to the node is closed
+	ErrClosed error = &connError{"connection closed"}
+	// ErrNotSupported is returned when the node does not support the requested operation
+	ErrNotSupported = errors.New("not supported by node")
+	// ErrRemote is returned when the node returned an error, or a reply indicating
+	// the operation failed. Use errors.As with *RemoteError to get the details.
+	ErrRemote = errors.New("remote error")
+	// ErrServerRejected is returned when the node returned an error for a call.
+	// It also matches ErrRemote.
+	ErrServerRejected = errors.New("rejected by node")
+	// ErrUnexpectedReply is returned when the node accepted a call, but its
+	// reply indicates the operation failed or can't be interpreted. It also
+	// matches ErrRemote.
+	ErrUnexpectedReply = errors.New("unexpected reply")
+	// ErrPortInUse is returned when LIT could not listen on the requested port(s)
+	// because they are already in use.
+	ErrPortInUse = errors.New("port already in use")
+	// ErrReadOnly is returned when a client created with WithReadOnly is asked
+	// to make a call that changes state on the node
+	ErrReadOnly = errors.New("client is read-only")
+	// ErrDisconnected is returned by a client created with WithReconnect for
+	// calls made while the connection to the node is down, or in flight when
+	// it dropped
+	ErrDisconnected error = &connError{"disconnected from node"}
+	// ErrDataTooLong is returned when the data passed to Push, FundChannel or
+	// AddHTLC is longer than MaxDataSize
+	ErrDataTooLong = errors.New("data too long")
+)
+
+// connError is the type of the errors that match ErrNotConnected
+type connError struct {
+	msg string
+}
+
+func (e *connError) Error() string {
+	return e.msg
+}
+
+// Is makes errors.Is match a connError against ErrNotConnected
+func (e *connError) Is(target error) bool {
+	return target == ErrNotConnected
+}
+
+// WithAsyncErrorHandler makes the client call [handler] with errors that
+// happen outside of a call, and thus can't be returned by one: the connection
+// to the node failing, replies that can't be parsed, and failed attempts to
+// reconnect (see WithReconnect). [handler] must not block.
+func WithAsyncErrorHandler(handler func(error)) Option {
+	return func(o *clientOptions) {
+		o.asyncErrorHandler = handler
+	}
+}
+
+// asyncError passes [err] to the handler set with WithAsyncErrorHandler, if any
+func (c *LitRpcClient) asyncError(err error) {
+	if c.opts.asyncErrorHandler != nil {
+		c.opts.asyncErrorHandler(err)
+	}
+}
+
+// RemoteError is an error returned by the node, or a reply from the node that
+// indicates the operation failed.
+type RemoteError struct {
+	// Method is the RPC method that was called
+	Method string
+	// Message is the error as returned by the node
+	Message string
+	// Nonce is the index of the call on the connection, for transports that
+	// number their calls (remote control), or 0
+	Nonce uint64
+	// UnexpectedReply is false if the node returned an error for the call, and
+	// true if it replied, but with a reply that indicates failure
+	UnexpectedReply bool
+}
+
+func (e *RemoteError) Error() string {
+	return e.Message
+}
+
+// Is makes errors.Is match a RemoteError against ErrRemote, and against
+// ErrServerRejected or ErrUnexpectedReply depending on UnexpectedReply
+func (e *RemoteError) Is(target error) bool {
+	switch target {
+	case ErrRemote:
+		return true
+	case ErrServerRejected:
+		return !e.UnexpectedReply
+	case ErrUnexpectedReply:
+		return e.UnexpectedReply
+	}
+	return false
+}
+
+// numberedServerError is an error returned by the node, together with the
+// index of the call on the connection
+type numberedServerError struct {
+	rpc.ServerError
+	nonce uint64
+}
+
+func (e *numberedServerError) Unwrap() error {
+	return e.ServerError
+}
+
+// UnsupportedError is returned when the node does not know the called method
+type UnsupportedError struct {
+	Method string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Method, ErrNotSupported)
+}
+
+// Is makes errors.Is match an UnsupportedError against ErrNotSupported
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrNotSupported
+}
+
+// remoteError builds the error for a reply to [method] that indicates failure
+func remoteError(method, format string, a ...interface{}) error {
+	return &RemoteError{Method: method, Message: fmt.Sprintf(format, a...), UnexpectedReply: true}
+}
+
+// wrapError converts an error returned by the RPC connection into one of the
+// package's error values
+func wrapError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var nonce uint64
+	if numbered, ok := err.(*numberedServerError); ok {
+		nonce = numbered.nonce
+		err = numbered.ServerError
+	}
+	if serverErr, ok := err.(rpc.ServerError); ok {
+		// net/rpc's error for unknown services and methods
+		if strings.HasPrefix(string(serverErr), "rpc: can't find") {
+			return &UnsupportedError{Method: method}
+		}
+		return &RemoteError{Method: method, Message: string(serverErr), Nonce: nonce}
+	}
+	if err == context.DeadlineExceeded {
+		return fmt.Errorf("%s: %w", method, ErrTimeout)
+	}
+	if err == context.Canceled {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	if err == ErrDisconnected {
+		return fmt.Errorf("%s: %w", method, ErrDisconnected)
+	}
+	if err == rpc.ErrShutdown || err == io.EOF || err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%s: %w", method, ErrClosed)
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return fmt.Errorf("%s: %w", method, ErrTimeout)
+	}
+	return err
+}
@@ -0,0 +1,71 @@
+// Command dashboard serves a read-only overview of a LIT node's balances,
+// channels and connection statistics as a web page. It reads from a Mirror,
+// so page views don't call the node.
+package main
+
+import (
+	"context"
+	"flag"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	litrpcclient "github.com/mit-dci/lit-rpc-client-go"
+)
+
+var page = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head><title>LIT node</title><meta http-equiv="refresh" content="10"></head>
+<body>
+{{with .Snapshot}}
+<h2>Balances</h2>
+<table>
+<tr><th>Coin type</th><th>Sync height</th><th>Wallet</th><th>Channels</th></tr>
+{{range .Balances}}<tr><td>{{.CoinType}}</td><td>{{.SyncHeight}}</td><td>{{.TxoTotal}}</td><td>{{.ChanTotal}}</td></tr>
+{{end}}
+</table>
+<h2>Channels</h2>
+<table>
+<tr><th>Index</th><th>Peer</th><th>Capacity</th><th>Our balance</th><th>State</th></tr>
+{{range .Channels}}{{if not .Closed}}<tr><td>{{.CIdx}}</td><td>{{.PeerIdx}}</td><td>{{.Capacity}}</td><td>{{.MyBalance}}</td><td>{{.StateNum}}</td></tr>
+{{end}}{{end}}
+</table>
+<p>Updated {{.Updated.Format "15:04:05"}}</p>
+{{else}}
+<p>Waiting for the first refresh</p>
+{{end}}
+<h2>Connection</h2>
+<p>{{.Stats.MessagesSent}} requests, {{.Stats.MessagesReceived}} replies, up {{.Stats.Uptime}}</p>
+</body>
+</html>
+`))
+
+func main() {
+	host := flag.String("host", "127.0.0.1", "host of the LIT node")
+	port := flag.Int("port", 8001, "RPC port of the LIT node")
+	listen := flag.String("listen", ":8080", "address to serve HTTP on")
+	flag.Parse()
+
+	client, err := litrpcclient.NewClient(*host, int32(*port),
+		litrpcclient.WithReadOnly(),
+		litrpcclient.WithReconnect(time.Second, time.Minute))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	mirror := litrpcclient.NewMirror(client)
+	go mirror.Run(context.Background())
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		err := page.Execute(w, map[string]interface{}{
+			"Snapshot": mirror.Snapshot(),
+			"Stats":    client.ConnStats(),
+		})
+		if err != nil {
+			log.Print(err)
+		}
+	})
+	log.Fatal(http.ListenAndServe(*listen, nil))
+}
@@ -0,0 +1,99 @@
+// Command marketmaker is a bot that answers discreet log contract offers. It
+// accepts offers that pass CheckContract and whose funding stays within the
+// configured limits, and declines all others.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	litrpcclient "github.com/mit-dci/lit-rpc-client-go"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+func main() {
+	host := flag.String("host", "127.0.0.1", "host of the LIT node")
+	port := flag.Int("port", 8001, "RPC port of the LIT node")
+	maxFunding := flag.Int64("max-funding", 1000000, "largest amount in satoshi to fund a single contract with")
+	maxExposure := flag.Int64("max-exposure", 10000000, "largest total amount in satoshi to have in open contracts")
+	flag.Parse()
+
+	client, err := litrpcclient.NewClient(*host, int32(*port),
+		litrpcclient.WithAppName("marketmaker"),
+		litrpcclient.WithReconnect(time.Second, time.Minute),
+		litrpcclient.WithAsyncErrorHandler(func(err error) { log.Print(err) }))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		cancel()
+	}()
+
+	for ev := range client.WatchContracts(ctx, 5*time.Second) {
+		switch ev.Kind {
+		case litrpcclient.ContractOffered:
+			answer(ctx, client, ev.Contract, *maxFunding, *maxExposure)
+		case litrpcclient.ContractFunded, litrpcclient.ContractSettled:
+			log.Printf("contract %d %s", ev.Contract.Idx, ev.Kind)
+		}
+	}
+}
+
+// answer accepts or declines the offered [contract]
+func answer(ctx context.Context, client *litrpcclient.LitRpcClient, contract *lnutil.DlcContract, maxFunding, maxExposure int64) {
+	reason := ""
+	exposure, err := openExposure(ctx, client)
+	switch {
+	case err != nil:
+		log.Printf("contract %d: %v", contract.Idx, err)
+		return
+	case contract.OurFundingAmount > maxFunding:
+		reason = "funding above limit"
+	case exposure+contract.OurFundingAmount > maxExposure:
+		reason = "exposure above limit"
+	default:
+		err = client.CheckContractCtx(ctx, contract.Idx)
+		if err != nil {
+			reason = err.Error()
+		}
+	}
+
+	if reason != "" {
+		log.Printf("declining contract %d: %s", contract.Idx, reason)
+		err = client.DeclineContractCtx(ctx, contract.Idx)
+	} else {
+		log.Printf("accepting contract %d", contract.Idx)
+		err = client.AcceptContractCtx(ctx, contract.Idx)
+	}
+	if err != nil {
+		log.Printf("contract %d: %v", contract.Idx, err)
+	}
+}
+
+// openExposure returns the total amount we funded contracts with that are
+// accepted but not yet settled
+func openExposure(ctx context.Context, client *litrpcclient.LitRpcClient) (int64, error) {
+	contracts, err := client.ListContractsCtx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, c := range contracts {
+		switch c.Status {
+		case lnutil.ContractStatusAccepting, lnutil.ContractStatusAccepted, lnutil.ContractStatusAcknowledged,
+			lnutil.ContractStatusActive, lnutil.ContractStatusSettling:
+			total += c.OurFundingAmount
+		}
+	}
+	return total, nil
+}
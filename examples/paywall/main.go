@@ -0,0 +1,123 @@
+// Command paywall serves content that is unlocked by a payment over a LIT
+// channel. A visitor requests /invoice to get a payment reference, pushes the
+// price to the paywall's node with the reference as push data, and then
+// fetches /content?ref=<reference>.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	litrpcclient "github.com/mit-dci/lit-rpc-client-go"
+)
+
+// invoice is a payment reference handed to a visitor
+type invoice struct {
+	data [32]byte
+	paid bool
+}
+
+type paywall struct {
+	client *litrpcclient.LitRpcClient
+	price  int64
+
+	mtx      sync.Mutex
+	invoices map[string]*invoice
+}
+
+func main() {
+	host := flag.String("host", "127.0.0.1", "host of the LIT node")
+	port := flag.Int("port", 8001, "RPC port of the LIT node")
+	listen := flag.String("listen", ":8080", "address to serve HTTP on")
+	price := flag.Int64("price", 1000, "price of the content in satoshi")
+	flag.Parse()
+
+	client, err := litrpcclient.NewClient(*host, int32(*port))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	p := &paywall{client: client, price: *price, invoices: make(map[string]*invoice)}
+	http.HandleFunc("/invoice", p.handleInvoice)
+	http.HandleFunc("/content", p.handleContent)
+	log.Fatal(http.ListenAndServe(*listen, nil))
+}
+
+// handleInvoice creates a new payment reference
+func (p *paywall) handleInvoice(w http.ResponseWriter, r *http.Request) {
+	address, err := p.client.GetLNAddressCtx(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	inv := new(invoice)
+	_, err = rand.Read(inv.data[:])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ref := hex.EncodeToString(inv.data[:])
+
+	p.mtx.Lock()
+	p.invoices[ref] = inv
+	p.mtx.Unlock()
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"lnAddress": address,
+		"amount":    p.price,
+		"reference": ref,
+	})
+}
+
+// handleContent serves the content if the reference was paid
+func (p *paywall) handleContent(w http.ResponseWriter, r *http.Request) {
+	ref := r.URL.Query().Get("ref")
+	p.mtx.Lock()
+	inv, ok := p.invoices[ref]
+	paid := ok && inv.paid
+	p.mtx.Unlock()
+	if !ok {
+		http.Error(w, "unknown reference", http.StatusNotFound)
+		return
+	}
+
+	if !paid {
+		paid, err := p.isPaid(r, inv)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		if !paid {
+			http.Error(w, "not paid yet", http.StatusPaymentRequired)
+			return
+		}
+	}
+	fmt.Fprintln(w, "Thank you for your payment. Here is the content.")
+}
+
+// isPaid returns true if the latest state of one of our channels carries the
+// invoice's reference, which the visitor set as push data. A production
+// paywall would also check the amount of the push, for instance by keeping
+// the channel balances from before the invoice was created.
+func (p *paywall) isPaid(r *http.Request, inv *invoice) (bool, error) {
+	channels, err := p.client.ListChannelsCtx(r.Context())
+	if err != nil {
+		return false, err
+	}
+	for _, ch := range channels {
+		if !ch.Closed && ch.Data == inv.data {
+			p.mtx.Lock()
+			inv.paid = true
+			p.mtx.Unlock()
+			return true, nil
+		}
+	}
+	return false, nil
+}
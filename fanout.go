@@ -0,0 +1,46 @@
+package litrpcclient
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+// Fanout splits wallet funds into [numOutputs] outputs of [amountPerOutput]
+// satoshi each, paid to [destAddress] in a single transaction, for instance to
+// prepare UTXOs for funding many channels. It returns the id of the transaction
+// and, if [destAddress] belongs to our wallet, the outpoints of the new outputs.
+func (c *LitRpcClient) Fanout(destAddress string, numOutputs uint32, amountPerOutput int64) (string, []string, error) {
+	return c.FanoutCtx(context.Background(), destAddress, numOutputs, amountPerOutput)
+}
+
+// FanoutCtx is like Fanout, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) FanoutCtx(ctx context.Context, destAddress string, numOutputs uint32, amountPerOutput int64) (string, []string, error) {
+	args := new(litrpc.FanArgs)
+	args.DestAdr = destAddress
+	args.NumOutputs = numOutputs
+	args.AmtPerOutput = amountPerOutput
+	reply := new(litrpc.TxidsReply)
+	err := c.callCtx(ctx, "LitRPC.Fanout", args, reply)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(reply.Txids) == 0 {
+		return "", nil, remoteError("LitRPC.Fanout", "Unexpected response from server")
+	}
+	txid := reply.Txids[0]
+
+	// LIT doesn't return the outputs, find them in the wallet
+	utxos, err := c.ListUtxosCtx(ctx)
+	if err != nil {
+		return txid, nil, err
+	}
+	var outpoints []string
+	for _, utxo := range utxos {
+		if strings.HasPrefix(utxo.OutPoint, txid+":") && utxo.Amt == amountPerOutput {
+			outpoints = append(outpoints, utxo.OutPoint)
+		}
+	}
+	return txid, outpoints, nil
+}
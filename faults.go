@@ -0,0 +1,178 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// FaultProfile describes the faults WithFaults injects into calls. Rates are
+// probabilities between 0 and 1, decided per call.
+type FaultProfile struct {
+	// Seed seeds the random decisions, so a run can be reproduced
+	Seed int64
+	// Latency is added to every call, plus a random part up to Jitter
+	Latency time.Duration
+	Jitter  time.Duration
+	// DropRate is the chance the node's response is lost. The call is executed
+	// by the node, but returns ErrTimeout.
+	DropRate float64
+	// DuplicateRate is the chance the node's response arrives twice, and is
+	// decoded into the reply a second time. The request is only sent once, so
+	// the node never executes a call twice.
+	DuplicateRate float64
+	// ReorderRate is the chance a response is held back after it arrived,
+	// long enough for responses to calls made later to overtake it: twice
+	// Latency plus Jitter, and at least minReorderDelay
+	ReorderRate float64
+}
+
+// WithFaults makes the client inject latency, lost responses, duplicate
+// responses and reordered responses into its calls, according to [profile].
+// This is meant for testing how applications cope with an unreliable
+// connection, and should not be used in production.
+func WithFaults(profile FaultProfile) Option {
+	return func(o *clientOptions) {
+		o.faults = &profile
+	}
+}
+
+// faultTransport injects faults into the calls of the wrapped transport
+type faultTransport struct {
+	transport
+	profile FaultProfile
+
+	mtx sync.Mutex
+	rnd *rand.Rand
+}
+
+func newFaultTransport(inner transport, profile FaultProfile) *faultTransport {
+	return &faultTransport{
+		transport: inner,
+		profile:   profile,
+		rnd:       rand.New(rand.NewSource(profile.Seed)),
+	}
+}
+
+// minReorderDelay is the shortest time a reordered response is held back
+const minReorderDelay = 20 * time.Millisecond
+
+// faultTimeout is returned for dropped responses. It's a net.Error, so it is
+// reported as ErrTimeout.
+type faultTimeout struct{}
+
+func (faultTimeout) Error() string   { return "injected fault: response dropped" }
+func (faultTimeout) Timeout() bool   { return true }
+func (faultTimeout) Temporary() bool { return true }
+
+func (t *faultTransport) Go(ctx context.Context, method string, args interface{}, reply interface{}, done func(error)) func(error) {
+	t.mtx.Lock()
+	delay := t.profile.Latency
+	if t.profile.Jitter > 0 {
+		delay += time.Duration(t.rnd.Int63n(int64(t.profile.Jitter)))
+	}
+	drop := t.rnd.Float64() < t.profile.DropRate
+	duplicate := t.rnd.Float64() < t.profile.DuplicateRate
+	var holdBack time.Duration
+	if t.rnd.Float64() < t.profile.ReorderRate {
+		holdBack = 2 * (t.profile.Latency + t.profile.Jitter)
+		if holdBack < minReorderDelay {
+			holdBack = minReorderDelay
+		}
+	}
+	t.mtx.Unlock()
+
+	// The reply is decoded into [reply] only once the call is completed
+	// here, so a cancelled call never writes to it afterwards
+	call := &faultCall{done: done}
+	var raw json.RawMessage
+	deliver := func(err error) {
+		if !call.finish() {
+			return
+		}
+		if drop {
+			err = faultTimeout{}
+		} else if err == nil {
+			err = json.Unmarshal(raw, reply)
+			if err == nil && duplicate {
+				err = json.Unmarshal(raw, reply)
+			}
+		}
+		done(err)
+	}
+	complete := func(err error) {
+		if holdBack > 0 {
+			time.AfterFunc(holdBack, func() { deliver(err) })
+			return
+		}
+		deliver(err)
+	}
+	send := func() {
+		if call.isFinished() {
+			return
+		}
+		cancel := t.transport.Go(ctx, method, args, &raw, complete)
+		call.mtx.Lock()
+		call.cancelInner = cancel
+		finished := call.finished
+		call.mtx.Unlock()
+		if finished {
+			// Cancelled while it was being sent
+			cancel(context.Canceled)
+		}
+	}
+	if delay > 0 {
+		call.mtx.Lock()
+		call.timer = time.AfterFunc(delay, send)
+		call.mtx.Unlock()
+	} else {
+		send()
+	}
+	return call.cancel
+}
+
+// faultCall is a call through the fault transport
+type faultCall struct {
+	done func(error)
+
+	mtx         sync.Mutex
+	finished    bool
+	timer       *time.Timer
+	cancelInner func(error)
+}
+
+// finish marks the call completed, and returns false if it was already
+func (c *faultCall) finish() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.finished {
+		return false
+	}
+	c.finished = true
+	return true
+}
+
+func (c *faultCall) isFinished() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.finished
+}
+
+// cancel abandons the call, whether it was sent yet or not
+func (c *faultCall) cancel(err error) {
+	if !c.finish() {
+		return
+	}
+	c.mtx.Lock()
+	timer, cancelInner := c.timer, c.cancelInner
+	c.mtx.Unlock()
+	if timer != nil {
+		timer.Stop()
+	}
+	if cancelInner != nil {
+		cancelInner(err)
+	}
+	c.done(err)
+}
@@ -0,0 +1,52 @@
+package litrpcclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+func TestDuplicateFaultSendsRequestOnce(t *testing.T) {
+	node := newFakeNode()
+	node.reply("LitRPC.Send", litrpc.TxidsReply{Txids: []string{"abcd"}})
+	client := newTestClient(node)
+	client.conn = newFaultTransport(node, FaultProfile{DuplicateRate: 1})
+
+	txid, err := client.Send("tb1qtest", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txid != "abcd" {
+		t.Errorf("got txid %q, want abcd", txid)
+	}
+	if n := node.count("LitRPC.Send"); n != 1 {
+		t.Errorf("node received %d requests, want 1", n)
+	}
+}
+
+func TestReorderFaultHoldsBackResponse(t *testing.T) {
+	node := newFakeNode()
+	node.reply("LitRPC.Balance", litrpc.BalanceReply{})
+	transport := newFaultTransport(node, FaultProfile{ReorderRate: 1})
+
+	done := make(chan error, 1)
+	start := time.Now()
+	transport.Go(context.Background(), "LitRPC.Balance", nil, new(litrpc.BalanceReply), func(err error) { done <- err })
+	// The request is sent right away, only the response is held back
+	if n := node.count("LitRPC.Balance"); n != 1 {
+		t.Fatalf("node received %d requests, want 1", n)
+	}
+	select {
+	case <-done:
+		t.Fatal("response wasn't held back")
+	default:
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if held := time.Since(start); held < minReorderDelay {
+		t.Fatalf("response held back for %s, want at least %s", held, minReorderDelay)
+	}
+}
@@ -0,0 +1,65 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrExcessiveFee is returned by Send and FundChannel on a client created
+// with WithFeeGuard, when the estimated fee of the transaction is larger than
+// the allowed share of the amount
+var ErrExcessiveFee = errors.New("fee too high for amount")
+
+// Estimated sizes (in vbytes) of the transactions LIT builds, assuming a
+// single P2WPKH input and a change output
+const (
+	sendTxSize    = 141
+	fundingTxSize = 153
+)
+
+// WithFeeGuard makes Send and FundChannel estimate the fee of their
+// transaction from the fee rate GetFee reports, and refuse with
+// ErrExcessiveFee when it's more than [maxPercent] percent of the amount.
+// This protects against a misconfigured fee rate. The estimate assumes a
+// single input, so transactions spending many small UTXOs can pay more.
+func WithFeeGuard(maxPercent float64) Option {
+	return func(o *clientOptions) {
+		o.maxFeePercent = maxPercent
+	}
+}
+
+// checkFee returns ErrExcessiveFee if a transaction of [size] vbytes of coin
+// type [coinType] would cost more than the allowed share of [amount]
+func (c *LitRpcClient) checkFee(ctx context.Context, coinType uint32, size, amount int64) error {
+	if c.opts.maxFeePercent <= 0 {
+		return nil
+	}
+	feeRate, err := c.GetFeeCtx(ctx, coinType)
+	if err != nil {
+		return err
+	}
+	fee := feeRate * size
+	if float64(fee) > float64(amount)*c.opts.maxFeePercent/100 {
+		return fmt.Errorf("%w: estimated fee of %d at %d sat/byte is more than %g%% of %d",
+			ErrExcessiveFee, fee, feeRate, c.opts.maxFeePercent, amount)
+	}
+	return nil
+}
+
+// addressCoinType returns the coin type of bech32 address [address], and
+// false for legacy addresses and unknown prefixes
+func addressCoinType(address string) (uint32, bool) {
+	sep := strings.LastIndex(address, "1")
+	if sep < 1 {
+		return 0, false
+	}
+	prefix := strings.ToLower(address[:sep])
+	for coinType, p := range coinBech32Prefixes {
+		if p == prefix {
+			return coinType, true
+		}
+	}
+	return 0, false
+}
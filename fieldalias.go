@@ -0,0 +1,155 @@
+package litrpcclient
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FieldAlias makes replies to Method that contain field Alias decode as if it
+// was called Field. Field names are already matched case insensitively, this
+// is meant for LIT versions that renamed reply fields. The alias is only used
+// when the reply does not contain Field itself (in any case), so the client
+// works with both old and new nodes.
+type FieldAlias struct {
+	Method string
+	Field  string
+	Alias  string
+	// Until is the last LIT version that uses Alias. Empty if the alias
+	// applies to all versions. The client can't detect the node's version,
+	// so selecting aliases by version is manual: only clients created with
+	// WithNodeVersion for a later version skip the alias.
+	Until string
+}
+
+// knownFieldAliases are the renames of reply fields between LIT versions
+// that every client handles, with the last version that used the old name.
+// Only renames confirmed against LIT's history belong here, since a wrong
+// alias fills a missing field with the value of another. None are confirmed
+// yet; callers that meet a renamed field pass it with WithFieldAliases.
+var knownFieldAliases []FieldAlias
+
+// WithFieldAliases makes the client decode replies with [aliases], on top of
+// the renames it knows of
+func WithFieldAliases(aliases ...FieldAlias) Option {
+	return func(o *clientOptions) {
+		o.fieldAliases = append(o.fieldAliases, aliases...)
+	}
+}
+
+// WithNodeVersion tells the client which LIT version the node runs (like
+// "0.3.1"), which LIT doesn't report over RPC. The client then only uses the
+// field aliases for renames made after that version. Without it, all aliases
+// are used.
+func WithNodeVersion(version string) Option {
+	return func(o *clientOptions) {
+		o.nodeVersion = version
+	}
+}
+
+// aliasTable holds the field aliases of a client by method, built on first use
+type aliasTable struct {
+	once     sync.Once
+	byMethod map[string]map[string][]string
+}
+
+// aliasesFor returns the field aliases that apply to replies to [method], if any
+func (c *LitRpcClient) aliasesFor(method string) map[string][]string {
+	c.aliases.once.Do(func() {
+		for _, list := range [][]FieldAlias{knownFieldAliases, c.opts.fieldAliases} {
+			for _, alias := range list {
+				if alias.Until != "" && c.opts.nodeVersion != "" && compareVersions(c.opts.nodeVersion, alias.Until) > 0 {
+					continue
+				}
+				if c.aliases.byMethod == nil {
+					c.aliases.byMethod = make(map[string]map[string][]string)
+				}
+				fields := c.aliases.byMethod[alias.Method]
+				if fields == nil {
+					fields = make(map[string][]string)
+					c.aliases.byMethod[alias.Method] = fields
+				}
+				fields[alias.Field] = append(fields[alias.Field], alias.Alias)
+			}
+		}
+	})
+	return c.aliases.byMethod[method]
+}
+
+// compareVersions compares dotted versions [a] and [b] (like "v0.3.1" or
+// "0.3.1-rc1", suffixes are ignored) by their numbers, and returns -1, 0 or 1
+func compareVersions(a, b string) int {
+	parse := func(v string) []int {
+		v = strings.TrimPrefix(v, "v")
+		if i := strings.IndexAny(v, "-+ "); i >= 0 {
+			v = v[:i]
+		}
+		var numbers []int
+		for _, part := range strings.Split(v, ".") {
+			n, _ := strconv.Atoi(part)
+			numbers = append(numbers, n)
+		}
+		return numbers
+	}
+	na, nb := parse(a), parse(b)
+	for i := 0; i < len(na) || i < len(nb); i++ {
+		var x, y int
+		if i < len(na) {
+			x = na[i]
+		}
+		if i < len(nb) {
+			y = nb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// decodeWithAliases decodes [raw] into [reply], after renaming aliased fields
+// to the names [reply] expects
+func decodeWithAliases(raw json.RawMessage, aliases map[string][]string, reply interface{}) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(raw, &fields)
+	if err != nil {
+		// Not an object, so there are no fields to rename
+		return json.Unmarshal(raw, reply)
+	}
+	for field, names := range aliases {
+		if _, ok := lookupField(fields, field); ok {
+			continue
+		}
+		for _, alias := range names {
+			if key, ok := lookupField(fields, alias); ok {
+				fields[field] = fields[key]
+				delete(fields, key)
+				break
+			}
+		}
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, reply)
+}
+
+// lookupField returns the key of [fields] that encoding/json would decode
+// into a field named [name]: [name] itself, or else a key that differs only
+// in case
+func lookupField(fields map[string]json.RawMessage, name string) (string, bool) {
+	if _, ok := fields[name]; ok {
+		return name, true
+	}
+	for key := range fields {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,57 @@
+package litrpcclient
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFieldAliasesPerClientAndVersion(t *testing.T) {
+	node := newFakeNode()
+	node.reply("LitRPC.Push", map[string]interface{}{"StateIdx": 5})
+	alias := FieldAlias{Method: "LitRPC.Push", Field: "StateIndex", Alias: "StateIdx", Until: "0.2.0"}
+
+	for _, test := range []struct {
+		name string
+		opts []Option
+		want uint64
+	}{
+		{"without aliases", nil, 0},
+		{"with alias", []Option{WithFieldAliases(alias)}, 5},
+		{"old node", []Option{WithFieldAliases(alias), WithNodeVersion("v0.1.9")}, 5},
+		{"same version", []Option{WithFieldAliases(alias), WithNodeVersion("0.2.0-rc1")}, 5},
+		{"newer node", []Option{WithFieldAliases(alias), WithNodeVersion("0.2.1")}, 0},
+	} {
+		client := newTestClient(node, test.opts...)
+		var reply struct{ StateIndex uint64 }
+		err := client.callCtx(context.Background(), "LitRPC.Push", nil, &reply)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if reply.StateIndex != test.want {
+			t.Errorf("%s: got state index %d, want %d", test.name, reply.StateIndex, test.want)
+		}
+	}
+}
+
+func TestFieldAliasesIgnoreCase(t *testing.T) {
+	node := newFakeNode()
+	alias := FieldAlias{Method: "LitRPC.Push", Field: "StateIndex", Alias: "StateIdx"}
+	client := newTestClient(node, WithFieldAliases(alias))
+	for _, test := range []struct {
+		reply map[string]interface{}
+		want  uint64
+	}{
+		{map[string]interface{}{"stateidx": 5}, 5},
+		{map[string]interface{}{"stateIndex": 6, "StateIdx": 5}, 6},
+	} {
+		node.reply("LitRPC.Push", test.reply)
+		var reply struct{ StateIndex uint64 }
+		err := client.callCtx(context.Background(), "LitRPC.Push", nil, &reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reply.StateIndex != test.want {
+			t.Errorf("reply %v: got state index %d, want %d", test.reply, reply.StateIndex, test.want)
+		}
+	}
+}
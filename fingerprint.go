@@ -0,0 +1,53 @@
+package litrpcclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// ContractFingerprint computes a fingerprint of the terms of [contract]: the
+// coin type, oracle public key, R-point, settlement time, funding amounts and
+// division. Contract indexes differ between the two parties, but both parties
+// compute the same fingerprint for the same contract, so it can be used to
+// reference a contract unambiguously (also from external systems).
+func ContractFingerprint(contract *lnutil.DlcContract) [32]byte {
+	ours := contractTerms(contract, false)
+	theirs := contractTerms(contract, true)
+
+	// The terms as seen by each party are mirrored. Always hash the smallest
+	// of both, so the result doesn't depend on which side we're on
+	if bytes.Compare(theirs, ours) < 0 {
+		return sha256.Sum256(theirs)
+	}
+	return sha256.Sum256(ours)
+}
+
+// contractTerms serializes the terms of [contract], from the perspective of
+// our counterparty if [mirror] is true
+func contractTerms(contract *lnutil.DlcContract, mirror bool) []byte {
+	ourAmount, theirAmount := contract.OurFundingAmount, contract.TheirFundingAmount
+	if mirror {
+		ourAmount, theirAmount = theirAmount, ourAmount
+	}
+	total := ourAmount + theirAmount
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, contract.CoinType)
+	buf.Write(contract.OracleA[:])
+	buf.Write(contract.OracleR[:])
+	binary.Write(&buf, binary.BigEndian, contract.OracleTimestamp)
+	binary.Write(&buf, binary.BigEndian, ourAmount)
+	binary.Write(&buf, binary.BigEndian, theirAmount)
+	for _, d := range contract.Division {
+		valueOurs := d.ValueOurs
+		if mirror {
+			valueOurs = total - valueOurs
+		}
+		binary.Write(&buf, binary.BigEndian, d.OracleValue)
+		binary.Write(&buf, binary.BigEndian, valueOurs)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,140 @@
+package litrpcclient
+
+import (
+	"context"
+	"time"
+)
+
+// FundingStage describes how far the funding of a channel has progressed
+type FundingStage int
+
+const (
+	// FundingRequested is reported when the funding request is sent to the node
+	FundingRequested FundingStage = iota
+	// FundingBroadcast is reported when the node has built and broadcast the
+	// funding transaction, and exchanged the initial state with the peer
+	FundingBroadcast
+	// FundingConfirmation is reported every time the funding transaction gains
+	// a confirmation, until the channel is active
+	FundingConfirmation
+	// FundingActive is reported when the funding transaction has the number of
+	// confirmations required for the coin type (see RequiredConfirmations)
+	FundingActive
+)
+
+func (s FundingStage) String() string {
+	switch s {
+	case FundingRequested:
+		return "requested"
+	case FundingBroadcast:
+		return "broadcast"
+	case FundingConfirmation:
+		return "confirmation"
+	}
+	return "active"
+}
+
+// FundingProgress is reported by FundChannelProgress
+type FundingProgress struct {
+	Stage FundingStage
+	// Channel is the new channel, nil for FundingRequested
+	Channel *ChannelStatus
+	// Confirmations is the number of confirmations of the funding transaction
+	Confirmations int32
+}
+
+// FundChannelProgress funds a channel like FundChannel, and then polls the node
+// every [interval] until the funding transaction has the number of
+// confirmations required for [coinType] (see RequiredConfirmations). [progress] is
+// called for every stage the funding reaches, so UIs can show how far it got.
+// It returns the status of the new channel once it is active, or an error when
+// [ctx] is done first.
+func (c *LitRpcClient) FundChannelProgress(ctx context.Context, peerIndex, coinType uint32, amount, initialSend int64, data []byte, interval time.Duration, progress func(FundingProgress)) (*ChannelStatus, error) {
+	before, err := c.ListChannelsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(before))
+	for _, ch := range before {
+		existing[ch.OutPoint] = true
+	}
+
+	progress(FundingProgress{Stage: FundingRequested})
+	err = c.FundChannelCtx(ctx, peerIndex, coinType, amount, initialSend, data)
+	if err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	required := c.RequiredConfirmations(coinType)
+	var channel *ChannelStatus
+	for {
+		statuses, err := c.ListChannelStatusesCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i, st := range statuses {
+			if existing[st.OutPoint] || st.PeerIdx != peerIndex || st.CoinType != coinType || st.Capacity != amount {
+				continue
+			}
+			if channel == nil {
+				progress(FundingProgress{Stage: FundingBroadcast, Channel: &statuses[i]})
+			} else if st.Confirmations > channel.Confirmations {
+				progress(FundingProgress{Stage: FundingConfirmation, Channel: &statuses[i], Confirmations: st.Confirmations})
+			}
+			channel = &statuses[i]
+			break
+		}
+		if channel == nil {
+			return nil, remoteError("LitRPC.FundChannel", "Funded channel not found in channel list")
+		}
+		if channel.State == ChannelOpen && channel.Confirmations >= required {
+			progress(FundingProgress{Stage: FundingActive, Channel: channel, Confirmations: channel.Confirmations})
+			return channel, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForChannelOpen polls the node every [interval] until the funding
+// transaction of channel [channelIndex] has the number of confirmations
+// required for its coin type (see RequiredConfirmations), and returns the
+// channel's status. It returns ErrChannelNotFound if the node doesn't know
+// the channel, an error matching ErrRemote if the channel was closed before
+// it opened, and the context's error when [ctx] is done first.
+func (c *LitRpcClient) WaitForChannelOpen(ctx context.Context, channelIndex uint32, interval time.Duration) (*ChannelStatus, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		statuses, err := c.ListChannelStatusesCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var channel *ChannelStatus
+		for i := range statuses {
+			if statuses[i].CIdx == channelIndex {
+				channel = &statuses[i]
+			}
+		}
+		switch {
+		case channel == nil:
+			return nil, ErrChannelNotFound
+		case channel.State == ChannelClosed:
+			return nil, remoteError("LitRPC.ChannelList", "Channel %d closed before it opened", channelIndex)
+		case channel.State == ChannelOpen && channel.Confirmations >= c.RequiredConfirmations(channel.CoinType):
+			return channel, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,17 @@
+//go:build go1.18
+
+package litrpcclient
+
+import "context"
+
+// Call calls RPC [method] on the node [c] is connected to with [args], and
+// returns the reply decoded into a TReply. Like CallContext, it reaches RPCs
+// that have no wrapper in this client, with the argument and reply types of
+// the litrpc package:
+//
+//	reply, err := litrpcclient.Call[litrpc.NoArgs, litrpc.ListConnectionsReply](ctx, c, "LitRPC.ListConnections", litrpc.NoArgs{})
+func Call[TArgs, TReply any](ctx context.Context, c *LitRpcClient, method string, args TArgs) (TReply, error) {
+	var reply TReply
+	err := c.callCtx(ctx, method, &args, &reply)
+	return reply, err
+}
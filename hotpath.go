@@ -0,0 +1,64 @@
+package litrpcclient
+
+import (
+	"context"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+// ListBalancesInto is like ListBalances, but decodes the balances into the
+// backing array of [dst] when it has enough capacity, instead of allocating a
+// new slice. Monitoring loops that poll often can pass the result of the
+// previous call to keep garbage down; the previous contents are overwritten.
+func (c *LitRpcClient) ListBalancesInto(dst []litrpc.CoinBalReply) ([]litrpc.CoinBalReply, error) {
+	return c.ListBalancesIntoCtx(context.Background(), dst)
+}
+
+// ListBalancesIntoCtx is like ListBalancesInto, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListBalancesIntoCtx(ctx context.Context, dst []litrpc.CoinBalReply) ([]litrpc.CoinBalReply, error) {
+	reply := litrpc.BalanceReply{Balances: resetBalances(dst)}
+	err := c.callCtx(ctx, "LitRPC.Balance", noArgs, &reply)
+	if err != nil {
+		return dst[:0], err
+	}
+	return reply.Balances, nil
+}
+
+// ListChannelsInto is like ListChannels, but decodes the channels into the
+// backing array of [dst] when it has enough capacity, like ListBalancesInto
+func (c *LitRpcClient) ListChannelsInto(dst []litrpc.ChannelInfo) ([]litrpc.ChannelInfo, error) {
+	return c.ListChannelsIntoCtx(context.Background(), dst)
+}
+
+// ListChannelsIntoCtx is like ListChannelsInto, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListChannelsIntoCtx(ctx context.Context, dst []litrpc.ChannelInfo) ([]litrpc.ChannelInfo, error) {
+	reply := litrpc.ChannelListReply{Channels: resetChannels(dst)}
+	err := c.callCtx(ctx, "LitRPC.ChannelList", noArgs, &reply)
+	if err != nil {
+		return dst[:0], err
+	}
+	return reply.Channels, nil
+}
+
+// noArgs is shared by the calls without arguments on hot paths
+var noArgs = new(litrpc.NoArgs)
+
+// resetBalances zeroes [dst] up to its capacity and returns it with length 0.
+// encoding/json doesn't clear reused slice elements, so fields missing from a
+// reply would otherwise keep the values of the previous call.
+func resetBalances(dst []litrpc.CoinBalReply) []litrpc.CoinBalReply {
+	dst = dst[:cap(dst)]
+	for i := range dst {
+		dst[i] = litrpc.CoinBalReply{}
+	}
+	return dst[:0]
+}
+
+// resetChannels is like resetBalances, for channels
+func resetChannels(dst []litrpc.ChannelInfo) []litrpc.ChannelInfo {
+	dst = dst[:cap(dst)]
+	for i := range dst {
+		dst[i] = litrpc.ChannelInfo{}
+	}
+	return dst[:0]
+}
@@ -0,0 +1,170 @@
+package litrpcclient
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+// HTLCEventKind describes what happened to an HTLC
+type HTLCEventKind int
+
+const (
+	// HTLCAdded is emitted when an HTLC was added to a channel
+	HTLCAdded HTLCEventKind = iota
+	// HTLCSettled is emitted when an HTLC was cleared with its preimage
+	HTLCSettled
+	// HTLCFailed is emitted when an HTLC was cleared without preimage, returning
+	// the funds to the sender
+	HTLCFailed
+)
+
+func (k HTLCEventKind) String() string {
+	switch k {
+	case HTLCAdded:
+		return "added"
+	case HTLCSettled:
+		return "settled"
+	}
+	return "failed"
+}
+
+// HTLCEvent is emitted by WatchHTLCs
+type HTLCEvent struct {
+	Kind         HTLCEventKind
+	ChannelIndex uint32
+	HTLCIndex    uint32
+	// StateIndex is the channel state that added or cleared the HTLC
+	StateIndex uint64
+}
+
+// htlcEventBuffer is the number of events a slow WatchHTLCs reader can fall behind
+const htlcEventBuffer = 64
+
+// htlcWatchers holds the channels of the WatchHTLCs callers
+type htlcWatchers struct {
+	mtx  sync.Mutex
+	subs map[chan HTLCEvent]struct{}
+}
+
+// emit sends [ev] to all watchers. Watchers whose buffer is full miss the event.
+func (w *htlcWatchers) emit(ev HTLCEvent) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	for sub := range w.subs {
+		select {
+		case sub <- ev:
+		default:
+		}
+	}
+}
+
+// NewPreimage generates a random HTLC preimage and returns it together with
+// its hash, to use with AddHTLC
+func NewPreimage() (preimage [16]byte, hash [32]byte, err error) {
+	_, err = rand.Read(preimage[:])
+	if err != nil {
+		return preimage, hash, err
+	}
+	return preimage, PreimageHash(preimage), nil
+}
+
+// PreimageHash returns the hash of HTLC preimage [preimage], as used by LIT
+func PreimageHash(preimage [16]byte) [32]byte {
+	return sha256.Sum256(preimage[:])
+}
+
+// AddHTLC adds an HTLC of [amount] satoshi to channel [channelIndex], that
+// our peer can claim by revealing the preimage of [hash] before block height
+// [lockTime]. [data] can be used to associate arbitrary data with it, like
+// with Push. Returns the index of the HTLC within the channel, and the state
+// index. Returns an *UnsupportedError if the node has no HTLC support.
+func (c *LitRpcClient) AddHTLC(channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (uint32, uint64, error) {
+	return c.AddHTLCCtx(context.Background(), channelIndex, amount, lockTime, hash, data)
+}
+
+// AddHTLCCtx is like AddHTLC, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) AddHTLCCtx(ctx context.Context, channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (uint32, uint64, error) {
+	args := new(litrpc.AddHTLCArgs)
+	args.ChanIdx = channelIndex
+	args.Amt = amount
+	args.LockTime = lockTime
+	args.RHash = hash
+	var err error
+	args.Data, err = stateData(data)
+	if err != nil {
+		return 0, 0, err
+	}
+	reply := new(litrpc.AddHTLCReply)
+	err = c.callCtx(ctx, "LitRPC.AddHTLC", args, reply)
+	if err != nil {
+		return 0, 0, err
+	}
+	c.htlcs.emit(HTLCEvent{Kind: HTLCAdded, ChannelIndex: channelIndex, HTLCIndex: reply.HTLCIndex, StateIndex: reply.StateIndex})
+	return reply.HTLCIndex, reply.StateIndex, nil
+}
+
+// SettleHTLC clears HTLC [htlcIndex] in channel [channelIndex] by revealing
+// its [preimage], which pays out the HTLC. Returns the new state index.
+func (c *LitRpcClient) SettleHTLC(channelIndex, htlcIndex uint32, preimage [16]byte) (uint64, error) {
+	return c.SettleHTLCCtx(context.Background(), channelIndex, htlcIndex, preimage)
+}
+
+// SettleHTLCCtx is like SettleHTLC, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SettleHTLCCtx(ctx context.Context, channelIndex, htlcIndex uint32, preimage [16]byte) (uint64, error) {
+	return c.clearHTLC(ctx, channelIndex, htlcIndex, preimage, HTLCSettled)
+}
+
+// FailHTLC clears HTLC [htlcIndex] in channel [channelIndex] without preimage,
+// which returns the funds to the sender. Returns the new state index.
+func (c *LitRpcClient) FailHTLC(channelIndex, htlcIndex uint32) (uint64, error) {
+	return c.FailHTLCCtx(context.Background(), channelIndex, htlcIndex)
+}
+
+// FailHTLCCtx is like FailHTLC, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) FailHTLCCtx(ctx context.Context, channelIndex, htlcIndex uint32) (uint64, error) {
+	return c.clearHTLC(ctx, channelIndex, htlcIndex, [16]byte{}, HTLCFailed)
+}
+
+// clearHTLC calls LitRPC.ClearHTLC. LIT clears the HTLC as failed when the
+// preimage is empty.
+func (c *LitRpcClient) clearHTLC(ctx context.Context, channelIndex, htlcIndex uint32, preimage [16]byte, kind HTLCEventKind) (uint64, error) {
+	args := new(litrpc.ClearHTLCArgs)
+	args.ChanIdx = channelIndex
+	args.HTLCIdx = htlcIndex
+	args.R = preimage
+	reply := new(litrpc.ClearHTLCReply)
+	err := c.callCtx(ctx, "LitRPC.ClearHTLC", args, reply)
+	if err != nil {
+		return 0, err
+	}
+	c.htlcs.emit(HTLCEvent{Kind: kind, ChannelIndex: channelIndex, HTLCIndex: htlcIndex, StateIndex: reply.StateIndex})
+	return reply.StateIndex, nil
+}
+
+// WatchHTLCs returns a channel that receives an event for every HTLC added,
+// settled or failed through this client. LIT has no RPC to list the HTLCs of
+// a channel, so HTLCs added by the peer are not reported. A reader that falls
+// more than 64 events behind misses events. The channel is closed when [ctx]
+// is cancelled.
+func (c *LitRpcClient) WatchHTLCs(ctx context.Context) <-chan HTLCEvent {
+	sub := make(chan HTLCEvent, htlcEventBuffer)
+	c.htlcs.mtx.Lock()
+	if c.htlcs.subs == nil {
+		c.htlcs.subs = make(map[chan HTLCEvent]struct{})
+	}
+	c.htlcs.subs[sub] = struct{}{}
+	c.htlcs.mtx.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		c.htlcs.mtx.Lock()
+		delete(c.htlcs.subs, sub)
+		close(sub)
+		c.htlcs.mtx.Unlock()
+	}()
+	return sub
+}
@@ -0,0 +1,80 @@
+package litrpcclient
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/mit-dci/lit/crypto/koblitz"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// Keyring holds the remote control keys of a process that talks to several
+// nodes, which may each have authorized a different key. Clients created with
+// WithKeyring pick the key registered for the node they connect to.
+type Keyring struct {
+	mtx   sync.Mutex
+	keys  map[string]*koblitz.PrivateKey
+	deflt *koblitz.PrivateKey
+}
+
+// NewKeyring creates an empty Keyring
+func NewKeyring() *Keyring {
+	return &Keyring{keys: make(map[string]*koblitz.PrivateKey)}
+}
+
+// Add registers [key] as the identity to use for the node at [host]:[port]
+func (k *Keyring) Add(host string, port int32, key *koblitz.PrivateKey) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	k.keys[net.JoinHostPort(host, fmt.Sprint(port))] = key
+}
+
+// SetDefault sets [key] as the identity for nodes that have no key
+// registered. Without a default, clients connect to those nodes over the
+// websocket RPC port.
+func (k *Keyring) SetDefault(key *koblitz.PrivateKey) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	k.deflt = key
+}
+
+// Key returns the key to use for the node at [host]:[port], or nil if
+// there is none
+func (k *Keyring) Key(host string, port int32) *koblitz.PrivateKey {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	if key, ok := k.keys[net.JoinHostPort(host, fmt.Sprint(port))]; ok {
+		return key
+	}
+	return k.deflt
+}
+
+// WithKeyring makes the client connect to the remote control interface with
+// the key [keyring] holds for the node, like WithRemoteControl. It takes
+// precedence over WithRemoteControl when the keyring has a key for the node.
+func WithKeyring(keyring *Keyring) Option {
+	return func(o *clientOptions) {
+		o.keyring = keyring
+	}
+}
+
+// IdentityAddress returns the LN address of remote control key [key]. The
+// address identifies the client to the node, for instance when authorizing
+// the key with AuthorizeRemoteControl from another client.
+func IdentityAddress(key *koblitz.PrivateKey) string {
+	var pub [33]byte
+	copy(pub[:], key.PubKey().SerializeCompressed())
+	return lnutil.LitAdrFromPubkey(pub)
+}
+
+// remoteControlKey returns the key to connect to the node at [host]:[port]
+// with, or nil to use the websocket RPC port
+func (c *LitRpcClient) remoteControlKey(host string, port int32) *koblitz.PrivateKey {
+	if c.opts.keyring != nil {
+		if key := c.opts.keyring.Key(host, port); key != nil {
+			return key
+		}
+	}
+	return c.opts.rcKey
+}
@@ -0,0 +1,229 @@
+// Code generated by internal/mockgen. DO NOT EDIT.
+
+package litrpcclient
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/mit-dci/lit-rpc-client-go/tracker"
+	"github.com/mit-dci/lit/crypto/koblitz"
+	"github.com/mit-dci/lit/dlc"
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/lnutil"
+	"github.com/mit-dci/lit/qln"
+)
+
+// LitClient contains all methods of LitRpcClient, so applications can depend
+// on the interface and substitute a mock (like litmock.Client) in unit tests.
+// See LitRpcClient for the documentation of the methods.
+type LitClient interface {
+	AcceptContract(contractIndex uint64) error
+	AcceptContractCtx(ctx context.Context, contractIndex uint64) error
+	AddHTLC(channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (uint32, uint64, error)
+	AddHTLCCtx(ctx context.Context, channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (uint32, uint64, error)
+	AddHTLCResult(channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (*OperationResult, error)
+	AddHTLCResultCtx(ctx context.Context, channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (*OperationResult, error)
+	AddOracle(pubKeyHex, name string) (*dlc.DlcOracle, error)
+	AddOracleCtx(ctx context.Context, pubKeyHex, name string) (*dlc.DlcOracle, error)
+	ArchiveStates(w io.Writer, keep uint64) (int, error)
+	ArchiveStatesCtx(ctx context.Context, w io.Writer, keep uint64) (int, error)
+	AssignNickname(peerIndex uint32, nickname string) error
+	AssignNicknameCtx(ctx context.Context, peerIndex uint32, nickname string) error
+	AuthorizeRemoteControl(pubKey *koblitz.PublicKey) error
+	AuthorizeRemoteControlCtx(ctx context.Context, pubKey *koblitz.PublicKey) error
+	BreakChannel(channelIndex uint32) error
+	BreakChannelCtx(ctx context.Context, channelIndex uint32) error
+	BreakChannelResult(channelIndex uint32) (*OperationResult, error)
+	BreakChannelResultCtx(ctx context.Context, channelIndex uint32) (*OperationResult, error)
+	BreakChannelWithReason(channelIndex uint32, reason string) error
+	BreakChannelWithReasonCtx(ctx context.Context, channelIndex uint32, reason string) error
+	CallAsync(ctx context.Context, method string, args interface{}) *PendingCall
+	CallContext(ctx context.Context, method string, args interface{}, reply interface{}) error
+	ChannelTemplate(name string) (ChannelTemplate, bool)
+	CheckAddressReuse() ([]AddressReuseWarning, error)
+	CheckAddressReuseCtx(ctx context.Context) ([]AddressReuseWarning, error)
+	CheckCompatibility() ([]Incompatibility, error)
+	CheckCompatibilityCtx(ctx context.Context) ([]Incompatibility, error)
+	CheckContract(contractIndex uint64) error
+	CheckContractCtx(ctx context.Context, contractIndex uint64) error
+	Close()
+	CloseChannel(channelIndex uint32) error
+	CloseChannelCtx(ctx context.Context, channelIndex uint32) error
+	CloseChannelResult(channelIndex uint32) (*OperationResult, error)
+	CloseChannelResultCtx(ctx context.Context, channelIndex uint32) (*OperationResult, error)
+	ConnStats() ConnStats
+	Connect(address, host string, port uint32) error
+	ConnectCtx(ctx context.Context, address, host string, port uint32) error
+	ConnectPeer(address, host string, port uint32) (*ConnectResult, error)
+	ConnectPeerCtx(ctx context.Context, address, host string, port uint32) (*ConnectResult, error)
+	ConnectViaTracker(t *tracker.Client, address string) error
+	ConnectViaTrackerCtx(ctx context.Context, t *tracker.Client, address string) error
+	DeclineContract(contractIndex uint64) error
+	DeclineContractCtx(ctx context.Context, contractIndex uint64) error
+	DualFundAccept() error
+	DualFundAcceptCtx(ctx context.Context) error
+	DualFundChannel(peerIndex, coinType uint32, ourAmount, theirAmount int64) error
+	DualFundChannelCtx(ctx context.Context, peerIndex, coinType uint32, ourAmount, theirAmount int64) error
+	DualFundDecline() error
+	DualFundDeclineCtx(ctx context.Context) error
+	FailHTLC(channelIndex, htlcIndex uint32) (uint64, error)
+	FailHTLCCtx(ctx context.Context, channelIndex, htlcIndex uint32) (uint64, error)
+	FailHTLCResult(channelIndex, htlcIndex uint32) (*OperationResult, error)
+	FailHTLCResultCtx(ctx context.Context, channelIndex, htlcIndex uint32) (*OperationResult, error)
+	Fanout(destAddress string, numOutputs uint32, amountPerOutput int64) (string, []string, error)
+	FanoutCtx(ctx context.Context, destAddress string, numOutputs uint32, amountPerOutput int64) (string, []string, error)
+	FanoutResult(destAddress string, numOutputs uint32, amountPerOutput int64) (*OperationResult, error)
+	FanoutResultCtx(ctx context.Context, destAddress string, numOutputs uint32, amountPerOutput int64) (*OperationResult, error)
+	FundChannel(peerIndex, coinType uint32, amount, initialSend int64, data []byte) error
+	FundChannelCtx(ctx context.Context, peerIndex, coinType uint32, amount, initialSend int64, data []byte) error
+	FundChannelProgress(ctx context.Context, peerIndex, coinType uint32, amount, initialSend int64, data []byte, interval time.Duration, progress func(FundingProgress)) (*ChannelStatus, error)
+	FundChannelResult(peerIndex, coinType uint32, amount, initialSend int64, data []byte) (*OperationResult, error)
+	FundChannelResultCtx(ctx context.Context, peerIndex, coinType uint32, amount, initialSend int64, data []byte) (*OperationResult, error)
+	GetAddresses(coinType, numberToMake uint32, legacy bool) ([]string, error)
+	GetAddressesCtx(ctx context.Context, coinType, numberToMake uint32, legacy bool) ([]string, error)
+	GetChannel(channelIndex uint32) (*litrpc.ChannelInfo, error)
+	GetChannelCtx(ctx context.Context, channelIndex uint32) (*litrpc.ChannelInfo, error)
+	GetContract(contractIndex uint64) (*lnutil.DlcContract, error)
+	GetContractCtx(ctx context.Context, contractIndex uint64) (*lnutil.DlcContract, error)
+	GetFee(coinType uint32) (int64, error)
+	GetFeeCtx(ctx context.Context, coinType uint32) (int64, error)
+	GetLNAddress() (string, error)
+	GetLNAddressCtx(ctx context.Context) (string, error)
+	GetMessage() (*ChatMessage, error)
+	GetMessageCtx(ctx context.Context) (*ChatMessage, error)
+	GetNodeConfig() (*NodeConfig, error)
+	GetNodeConfigCtx(ctx context.Context) (*NodeConfig, error)
+	GetNodeSnapshot() (*NodeSnapshot, error)
+	GetNodeSnapshotCtx(ctx context.Context) (*NodeSnapshot, error)
+	GetTransaction(txid string, coinType uint32) (*Transaction, error)
+	GetTransactionCtx(ctx context.Context, txid string, coinType uint32) (*Transaction, error)
+	ImportOracle(url, name string) (*dlc.DlcOracle, error)
+	ImportOracleCtx(ctx context.Context, url, name string) (*dlc.DlcOracle, error)
+	ImportOraclesFromDirectory(url string, directoryKey *koblitz.PublicKey) ([]DirectoryImportResult, error)
+	ImportOraclesFromDirectoryCtx(ctx context.Context, url string, directoryKey *koblitz.PublicKey) ([]DirectoryImportResult, error)
+	IsListening() (bool, error)
+	IsListeningCtx(ctx context.Context) (bool, error)
+	ListBalances() ([]litrpc.CoinBalReply, error)
+	ListBalancesCtx(ctx context.Context) ([]litrpc.CoinBalReply, error)
+	ListBalancesInto(dst []litrpc.CoinBalReply) ([]litrpc.CoinBalReply, error)
+	ListBalancesIntoCtx(ctx context.Context, dst []litrpc.CoinBalReply) ([]litrpc.CoinBalReply, error)
+	ListChannelStatuses() ([]ChannelStatus, error)
+	ListChannelStatusesCtx(ctx context.Context) ([]ChannelStatus, error)
+	ListChannels() ([]litrpc.ChannelInfo, error)
+	ListChannelsCtx(ctx context.Context) ([]litrpc.ChannelInfo, error)
+	ListChannelsFiltered(filters ...ChannelFilter) ([]litrpc.ChannelInfo, error)
+	ListChannelsFilteredCtx(ctx context.Context, filters ...ChannelFilter) ([]litrpc.ChannelInfo, error)
+	ListChannelsInto(dst []litrpc.ChannelInfo) ([]litrpc.ChannelInfo, error)
+	ListChannelsIntoCtx(ctx context.Context, dst []litrpc.ChannelInfo) ([]litrpc.ChannelInfo, error)
+	ListConnections() ([]qln.PeerInfo, error)
+	ListConnectionsCtx(ctx context.Context) ([]qln.PeerInfo, error)
+	ListConnectionsFiltered(includeArchived bool) ([]qln.PeerInfo, error)
+	ListConnectionsFilteredCtx(ctx context.Context, includeArchived bool) ([]qln.PeerInfo, error)
+	ListContracts() ([]*lnutil.DlcContract, error)
+	ListContractsCtx(ctx context.Context) ([]*lnutil.DlcContract, error)
+	ListExistingAddresses(coinType uint32) ([]Address, error)
+	ListExistingAddressesCtx(ctx context.Context, coinType uint32) ([]Address, error)
+	ListMultihopPayments() ([]*qln.InFlightMultihop, error)
+	ListMultihopPaymentsCtx(ctx context.Context) ([]*qln.InFlightMultihop, error)
+	ListOracles() ([]*dlc.DlcOracle, error)
+	ListOraclesCtx(ctx context.Context) ([]*dlc.DlcOracle, error)
+	ListOraclesFiltered(includeArchived bool) ([]*dlc.DlcOracle, error)
+	ListOraclesFilteredCtx(ctx context.Context, includeArchived bool) ([]*dlc.DlcOracle, error)
+	ListRemoteAccessRequests() ([]*koblitz.PublicKey, error)
+	ListRemoteAccessRequestsCtx(ctx context.Context) ([]*koblitz.PublicKey, error)
+	ListUtxos() ([]litrpc.TxoInfo, error)
+	ListUtxosCtx(ctx context.Context) ([]litrpc.TxoInfo, error)
+	ListWallets() ([]Wallet, error)
+	ListWalletsCtx(ctx context.Context) ([]Wallet, error)
+	Listen(port string) error
+	ListenAny(ports ...string) (string, error)
+	ListenAnyCtx(ctx context.Context, ports ...string) (string, error)
+	ListenCtx(ctx context.Context, port string) error
+	MatchOffer(incomingIdx, localDraftIdx uint64) error
+	MatchOfferCtx(ctx context.Context, incomingIdx, localDraftIdx uint64) error
+	NewAddress(coinType uint32, legacy bool) (string, error)
+	NewAddressCtx(ctx context.Context, coinType uint32, legacy bool) (string, error)
+	NewContract() (*lnutil.DlcContract, error)
+	NewContractCtx(ctx context.Context) (*lnutil.DlcContract, error)
+	OfferContract(contractIndex uint64, peerIndex uint32) error
+	OfferContractCtx(ctx context.Context, contractIndex uint64, peerIndex uint32) error
+	OpenCircuits() []string
+	OpenFromTemplate(peerIndex uint32, templateName string) error
+	OpenFromTemplateCtx(ctx context.Context, peerIndex uint32, templateName string) error
+	PayMultihop(destLNAddr string, coinType uint32, amount int64) (*qln.InFlightMultihop, error)
+	PayMultihopAsync(ctx context.Context, destLNAddr string, coinType uint32, amount int64) *MultihopPayment
+	PayMultihopCtx(ctx context.Context, destLNAddr string, coinType uint32, amount int64) (*qln.InFlightMultihop, error)
+	PendingDualFund() (*DualFundRequest, error)
+	PendingDualFundCtx(ctx context.Context) (*DualFundRequest, error)
+	Push(channelIndex uint32, amount int64, data []byte) (uint64, error)
+	PushCtx(ctx context.Context, channelIndex uint32, amount int64, data []byte) (uint64, error)
+	PushResult(channelIndex uint32, amount int64, data []byte) (*OperationResult, error)
+	PushResultCtx(ctx context.Context, channelIndex uint32, amount int64, data []byte) (*OperationResult, error)
+	PushTx(rawTxHex string, coinType uint32) (string, error)
+	PushTxCtx(ctx context.Context, rawTxHex string, coinType uint32) (string, error)
+	PushWithReceipt(channelIndex uint32, amount int64, data []byte) (*PushReceipt, error)
+	PushWithReceiptCtx(ctx context.Context, channelIndex uint32, amount int64, data []byte) (*PushReceipt, error)
+	RegisterChannelTemplates(templates ...ChannelTemplate)
+	RequestRemoteAccess(pubKey *koblitz.PublicKey) error
+	RequestRemoteAccessCtx(ctx context.Context, pubKey *koblitz.PublicKey) error
+	RequiredConfirmations(coinType uint32) int32
+	RevokeRemoteControl(pubKey *koblitz.PublicKey) error
+	RevokeRemoteControlCtx(ctx context.Context, pubKey *koblitz.PublicKey) error
+	Say(peerIndex uint32, message string) error
+	SayCtx(ctx context.Context, peerIndex uint32, message string) error
+	SelfInfo() (*SelfInfo, error)
+	SelfInfoCtx(ctx context.Context) (*SelfInfo, error)
+	SelfTest(ctx context.Context) *SelfTestReport
+	Send(address string, amount int64) (string, error)
+	SendCtx(ctx context.Context, address string, amount int64) (string, error)
+	SendResult(address string, amount int64) (*OperationResult, error)
+	SendResultCtx(ctx context.Context, address string, amount int64) (*OperationResult, error)
+	SetContractCoinType(contractIndex uint64, coinType uint32) error
+	SetContractCoinTypeCtx(ctx context.Context, contractIndex uint64, coinType uint32) error
+	SetContractDivision(contractIndex uint64, valueFullyOurs, valueFullyTheirs int64) error
+	SetContractDivisionCtx(ctx context.Context, contractIndex uint64, valueFullyOurs, valueFullyTheirs int64) error
+	SetContractFunding(contractIndex uint64, ourAmount, theirAmount int64) error
+	SetContractFundingCtx(ctx context.Context, contractIndex uint64, ourAmount, theirAmount int64) error
+	SetContractOracle(contractIndex, oracleIndex uint64) error
+	SetContractOracleCtx(ctx context.Context, contractIndex, oracleIndex uint64) error
+	SetContractRPoint(contractIndex uint64, rPoint []byte) error
+	SetContractRPointCtx(ctx context.Context, contractIndex uint64, rPoint []byte) error
+	SetContractSettlementTime(contractIndex uint64, settlementTime uint64) error
+	SetContractSettlementTimeCtx(ctx context.Context, contractIndex uint64, settlementTime uint64) error
+	SetFee(coinType uint32, feePerByte int64) error
+	SetFeeCtx(ctx context.Context, coinType uint32, feePerByte int64) error
+	SetPeerPolicy(policy PeerPolicy)
+	SetPushCoalescing(enabled bool)
+	SetPushQueueDepth(depth int)
+	SettleContract(contractIndex uint64, oracleValue int64, oracleSignature []byte) error
+	SettleContractCtx(ctx context.Context, contractIndex uint64, oracleValue int64, oracleSignature []byte) error
+	SettleContractResult(contractIndex uint64, oracleValue int64, oracleSignature []byte) (*OperationResult, error)
+	SettleContractResultCtx(ctx context.Context, contractIndex uint64, oracleValue int64, oracleSignature []byte) (*OperationResult, error)
+	SettleHTLC(channelIndex, htlcIndex uint32, preimage [16]byte) (uint64, error)
+	SettleHTLCCtx(ctx context.Context, channelIndex, htlcIndex uint32, preimage [16]byte) (uint64, error)
+	SettleHTLCResult(channelIndex, htlcIndex uint32, preimage [16]byte) (*OperationResult, error)
+	SettleHTLCResultCtx(ctx context.Context, channelIndex, htlcIndex uint32, preimage [16]byte) (*OperationResult, error)
+	StateDump() ([]qln.JusticeTx, error)
+	StateDumpCtx(ctx context.Context) ([]qln.JusticeTx, error)
+	Stop() error
+	StopCtx(ctx context.Context) error
+	Stream(ctx context.Context, channelIndex uint32, ratePerSecond, totalCap int64) *PaymentStream
+	Supports(method string) (bool, error)
+	SupportsCtx(ctx context.Context, method string) (bool, error)
+	Sweep(coinType uint32, destAddress string, dryRun bool) ([]string, error)
+	SweepCtx(ctx context.Context, coinType uint32, destAddress string, dryRun bool) ([]string, error)
+	SweepResult(coinType uint32, destAddress string) (*OperationResult, error)
+	SweepResultCtx(ctx context.Context, coinType uint32, destAddress string) (*OperationResult, error)
+	SyncStatus() ([]CoinSyncStatus, error)
+	SyncStatusCtx(ctx context.Context) ([]CoinSyncStatus, error)
+	TakeBreakSnapshot(ctx context.Context, channelIndex uint32, reason string) (*BreakSnapshot, error)
+	UnsupportedMethods() []string
+	WaitForChannelOpen(ctx context.Context, channelIndex uint32, interval time.Duration) (*ChannelStatus, error)
+	WatchContracts(ctx context.Context, interval time.Duration) <-chan ContractEvent
+	WatchHTLCs(ctx context.Context) <-chan HTLCEvent
+	WatchMessages(ctx context.Context) <-chan ChatMessage
+}
+
+var _ LitClient = (*LitRpcClient)(nil)
@@ -0,0 +1,2084 @@
+// Code generated by internal/mockgen. DO NOT EDIT.
+
+// Package litmock provides a mock implementation of litrpcclient.LitClient,
+// for unit testing code that uses the client without a LIT node.
+package litmock
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	litrpcclient "github.com/mit-dci/lit-rpc-client-go"
+	"github.com/mit-dci/lit-rpc-client-go/tracker"
+	"github.com/mit-dci/lit/crypto/koblitz"
+	"github.com/mit-dci/lit/dlc"
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/lnutil"
+	"github.com/mit-dci/lit/qln"
+)
+
+// ErrNotMocked is returned by methods of Client whose function is not set
+var ErrNotMocked = errors.New("method not mocked")
+
+// Client implements litrpcclient.LitClient. Every method calls the function
+// in the field with the method's name plus Func, like ListBalancesFunc for
+// ListBalances. Methods whose function is nil return zero values, and
+// ErrNotMocked if they return an error. Client records the names of the
+// methods called, see Calls.
+type Client struct {
+	AcceptContractFunc                func(contractIndex uint64) error
+	AcceptContractCtxFunc             func(ctx context.Context, contractIndex uint64) error
+	AddHTLCFunc                       func(channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (uint32, uint64, error)
+	AddHTLCCtxFunc                    func(ctx context.Context, channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (uint32, uint64, error)
+	AddHTLCResultFunc                 func(channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (*litrpcclient.OperationResult, error)
+	AddHTLCResultCtxFunc              func(ctx context.Context, channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (*litrpcclient.OperationResult, error)
+	AddOracleFunc                     func(pubKeyHex, name string) (*dlc.DlcOracle, error)
+	AddOracleCtxFunc                  func(ctx context.Context, pubKeyHex, name string) (*dlc.DlcOracle, error)
+	ArchiveStatesFunc                 func(w io.Writer, keep uint64) (int, error)
+	ArchiveStatesCtxFunc              func(ctx context.Context, w io.Writer, keep uint64) (int, error)
+	AssignNicknameFunc                func(peerIndex uint32, nickname string) error
+	AssignNicknameCtxFunc             func(ctx context.Context, peerIndex uint32, nickname string) error
+	AuthorizeRemoteControlFunc        func(pubKey *koblitz.PublicKey) error
+	AuthorizeRemoteControlCtxFunc     func(ctx context.Context, pubKey *koblitz.PublicKey) error
+	BreakChannelFunc                  func(channelIndex uint32) error
+	BreakChannelCtxFunc               func(ctx context.Context, channelIndex uint32) error
+	BreakChannelResultFunc            func(channelIndex uint32) (*litrpcclient.OperationResult, error)
+	BreakChannelResultCtxFunc         func(ctx context.Context, channelIndex uint32) (*litrpcclient.OperationResult, error)
+	BreakChannelWithReasonFunc        func(channelIndex uint32, reason string) error
+	BreakChannelWithReasonCtxFunc     func(ctx context.Context, channelIndex uint32, reason string) error
+	CallAsyncFunc                     func(ctx context.Context, method string, args interface{}) *litrpcclient.PendingCall
+	CallContextFunc                   func(ctx context.Context, method string, args interface{}, reply interface{}) error
+	ChannelTemplateFunc               func(name string) (litrpcclient.ChannelTemplate, bool)
+	CheckAddressReuseFunc             func() ([]litrpcclient.AddressReuseWarning, error)
+	CheckAddressReuseCtxFunc          func(ctx context.Context) ([]litrpcclient.AddressReuseWarning, error)
+	CheckCompatibilityFunc            func() ([]litrpcclient.Incompatibility, error)
+	CheckCompatibilityCtxFunc         func(ctx context.Context) ([]litrpcclient.Incompatibility, error)
+	CheckContractFunc                 func(contractIndex uint64) error
+	CheckContractCtxFunc              func(ctx context.Context, contractIndex uint64) error
+	CloseFunc                         func()
+	CloseChannelFunc                  func(channelIndex uint32) error
+	CloseChannelCtxFunc               func(ctx context.Context, channelIndex uint32) error
+	CloseChannelResultFunc            func(channelIndex uint32) (*litrpcclient.OperationResult, error)
+	CloseChannelResultCtxFunc         func(ctx context.Context, channelIndex uint32) (*litrpcclient.OperationResult, error)
+	ConnStatsFunc                     func() litrpcclient.ConnStats
+	ConnectFunc                       func(address, host string, port uint32) error
+	ConnectCtxFunc                    func(ctx context.Context, address, host string, port uint32) error
+	ConnectPeerFunc                   func(address, host string, port uint32) (*litrpcclient.ConnectResult, error)
+	ConnectPeerCtxFunc                func(ctx context.Context, address, host string, port uint32) (*litrpcclient.ConnectResult, error)
+	ConnectViaTrackerFunc             func(t *tracker.Client, address string) error
+	ConnectViaTrackerCtxFunc          func(ctx context.Context, t *tracker.Client, address string) error
+	DeclineContractFunc               func(contractIndex uint64) error
+	DeclineContractCtxFunc            func(ctx context.Context, contractIndex uint64) error
+	DualFundAcceptFunc                func() error
+	DualFundAcceptCtxFunc             func(ctx context.Context) error
+	DualFundChannelFunc               func(peerIndex, coinType uint32, ourAmount, theirAmount int64) error
+	DualFundChannelCtxFunc            func(ctx context.Context, peerIndex, coinType uint32, ourAmount, theirAmount int64) error
+	DualFundDeclineFunc               func() error
+	DualFundDeclineCtxFunc            func(ctx context.Context) error
+	FailHTLCFunc                      func(channelIndex, htlcIndex uint32) (uint64, error)
+	FailHTLCCtxFunc                   func(ctx context.Context, channelIndex, htlcIndex uint32) (uint64, error)
+	FailHTLCResultFunc                func(channelIndex, htlcIndex uint32) (*litrpcclient.OperationResult, error)
+	FailHTLCResultCtxFunc             func(ctx context.Context, channelIndex, htlcIndex uint32) (*litrpcclient.OperationResult, error)
+	FanoutFunc                        func(destAddress string, numOutputs uint32, amountPerOutput int64) (string, []string, error)
+	FanoutCtxFunc                     func(ctx context.Context, destAddress string, numOutputs uint32, amountPerOutput int64) (string, []string, error)
+	FanoutResultFunc                  func(destAddress string, numOutputs uint32, amountPerOutput int64) (*litrpcclient.OperationResult, error)
+	FanoutResultCtxFunc               func(ctx context.Context, destAddress string, numOutputs uint32, amountPerOutput int64) (*litrpcclient.OperationResult, error)
+	FundChannelFunc                   func(peerIndex, coinType uint32, amount, initialSend int64, data []byte) error
+	FundChannelCtxFunc                func(ctx context.Context, peerIndex, coinType uint32, amount, initialSend int64, data []byte) error
+	FundChannelProgressFunc           func(ctx context.Context, peerIndex, coinType uint32, amount, initialSend int64, data []byte, interval time.Duration, progress func(litrpcclient.FundingProgress)) (*litrpcclient.ChannelStatus, error)
+	FundChannelResultFunc             func(peerIndex, coinType uint32, amount, initialSend int64, data []byte) (*litrpcclient.OperationResult, error)
+	FundChannelResultCtxFunc          func(ctx context.Context, peerIndex, coinType uint32, amount, initialSend int64, data []byte) (*litrpcclient.OperationResult, error)
+	GetAddressesFunc                  func(coinType, numberToMake uint32, legacy bool) ([]string, error)
+	GetAddressesCtxFunc               func(ctx context.Context, coinType, numberToMake uint32, legacy bool) ([]string, error)
+	GetChannelFunc                    func(channelIndex uint32) (*litrpc.ChannelInfo, error)
+	GetChannelCtxFunc                 func(ctx context.Context, channelIndex uint32) (*litrpc.ChannelInfo, error)
+	GetContractFunc                   func(contractIndex uint64) (*lnutil.DlcContract, error)
+	GetContractCtxFunc                func(ctx context.Context, contractIndex uint64) (*lnutil.DlcContract, error)
+	GetFeeFunc                        func(coinType uint32) (int64, error)
+	GetFeeCtxFunc                     func(ctx context.Context, coinType uint32) (int64, error)
+	GetLNAddressFunc                  func() (string, error)
+	GetLNAddressCtxFunc               func(ctx context.Context) (string, error)
+	GetMessageFunc                    func() (*litrpcclient.ChatMessage, error)
+	GetMessageCtxFunc                 func(ctx context.Context) (*litrpcclient.ChatMessage, error)
+	GetNodeConfigFunc                 func() (*litrpcclient.NodeConfig, error)
+	GetNodeConfigCtxFunc              func(ctx context.Context) (*litrpcclient.NodeConfig, error)
+	GetNodeSnapshotFunc               func() (*litrpcclient.NodeSnapshot, error)
+	GetNodeSnapshotCtxFunc            func(ctx context.Context) (*litrpcclient.NodeSnapshot, error)
+	GetTransactionFunc                func(txid string, coinType uint32) (*litrpcclient.Transaction, error)
+	GetTransactionCtxFunc             func(ctx context.Context, txid string, coinType uint32) (*litrpcclient.Transaction, error)
+	ImportOracleFunc                  func(url, name string) (*dlc.DlcOracle, error)
+	ImportOracleCtxFunc               func(ctx context.Context, url, name string) (*dlc.DlcOracle, error)
+	ImportOraclesFromDirectoryFunc    func(url string, directoryKey *koblitz.PublicKey) ([]litrpcclient.DirectoryImportResult, error)
+	ImportOraclesFromDirectoryCtxFunc func(ctx context.Context, url string, directoryKey *koblitz.PublicKey) ([]litrpcclient.DirectoryImportResult, error)
+	IsListeningFunc                   func() (bool, error)
+	IsListeningCtxFunc                func(ctx context.Context) (bool, error)
+	ListBalancesFunc                  func() ([]litrpc.CoinBalReply, error)
+	ListBalancesCtxFunc               func(ctx context.Context) ([]litrpc.CoinBalReply, error)
+	ListBalancesIntoFunc              func(dst []litrpc.CoinBalReply) ([]litrpc.CoinBalReply, error)
+	ListBalancesIntoCtxFunc           func(ctx context.Context, dst []litrpc.CoinBalReply) ([]litrpc.CoinBalReply, error)
+	ListChannelStatusesFunc           func() ([]litrpcclient.ChannelStatus, error)
+	ListChannelStatusesCtxFunc        func(ctx context.Context) ([]litrpcclient.ChannelStatus, error)
+	ListChannelsFunc                  func() ([]litrpc.ChannelInfo, error)
+	ListChannelsCtxFunc               func(ctx context.Context) ([]litrpc.ChannelInfo, error)
+	ListChannelsFilteredFunc          func(filters ...litrpcclient.ChannelFilter) ([]litrpc.ChannelInfo, error)
+	ListChannelsFilteredCtxFunc       func(ctx context.Context, filters ...litrpcclient.ChannelFilter) ([]litrpc.ChannelInfo, error)
+	ListChannelsIntoFunc              func(dst []litrpc.ChannelInfo) ([]litrpc.ChannelInfo, error)
+	ListChannelsIntoCtxFunc           func(ctx context.Context, dst []litrpc.ChannelInfo) ([]litrpc.ChannelInfo, error)
+	ListConnectionsFunc               func() ([]qln.PeerInfo, error)
+	ListConnectionsCtxFunc            func(ctx context.Context) ([]qln.PeerInfo, error)
+	ListConnectionsFilteredFunc       func(includeArchived bool) ([]qln.PeerInfo, error)
+	ListConnectionsFilteredCtxFunc    func(ctx context.Context, includeArchived bool) ([]qln.PeerInfo, error)
+	ListContractsFunc                 func() ([]*lnutil.DlcContract, error)
+	ListContractsCtxFunc              func(ctx context.Context) ([]*lnutil.DlcContract, error)
+	ListExistingAddressesFunc         func(coinType uint32) ([]litrpcclient.Address, error)
+	ListExistingAddressesCtxFunc      func(ctx context.Context, coinType uint32) ([]litrpcclient.Address, error)
+	ListMultihopPaymentsFunc          func() ([]*qln.InFlightMultihop, error)
+	ListMultihopPaymentsCtxFunc       func(ctx context.Context) ([]*qln.InFlightMultihop, error)
+	ListOraclesFunc                   func() ([]*dlc.DlcOracle, error)
+	ListOraclesCtxFunc                func(ctx context.Context) ([]*dlc.DlcOracle, error)
+	ListOraclesFilteredFunc           func(includeArchived bool) ([]*dlc.DlcOracle, error)
+	ListOraclesFilteredCtxFunc        func(ctx context.Context, includeArchived bool) ([]*dlc.DlcOracle, error)
+	ListRemoteAccessRequestsFunc      func() ([]*koblitz.PublicKey, error)
+	ListRemoteAccessRequestsCtxFunc   func(ctx context.Context) ([]*koblitz.PublicKey, error)
+	ListUtxosFunc                     func() ([]litrpc.TxoInfo, error)
+	ListUtxosCtxFunc                  func(ctx context.Context) ([]litrpc.TxoInfo, error)
+	ListWalletsFunc                   func() ([]litrpcclient.Wallet, error)
+	ListWalletsCtxFunc                func(ctx context.Context) ([]litrpcclient.Wallet, error)
+	ListenFunc                        func(port string) error
+	ListenAnyFunc                     func(ports ...string) (string, error)
+	ListenAnyCtxFunc                  func(ctx context.Context, ports ...string) (string, error)
+	ListenCtxFunc                     func(ctx context.Context, port string) error
+	MatchOfferFunc                    func(incomingIdx, localDraftIdx uint64) error
+	MatchOfferCtxFunc                 func(ctx context.Context, incomingIdx, localDraftIdx uint64) error
+	NewAddressFunc                    func(coinType uint32, legacy bool) (string, error)
+	NewAddressCtxFunc                 func(ctx context.Context, coinType uint32, legacy bool) (string, error)
+	NewContractFunc                   func() (*lnutil.DlcContract, error)
+	NewContractCtxFunc                func(ctx context.Context) (*lnutil.DlcContract, error)
+	OfferContractFunc                 func(contractIndex uint64, peerIndex uint32) error
+	OfferContractCtxFunc              func(ctx context.Context, contractIndex uint64, peerIndex uint32) error
+	OpenCircuitsFunc                  func() []string
+	OpenFromTemplateFunc              func(peerIndex uint32, templateName string) error
+	OpenFromTemplateCtxFunc           func(ctx context.Context, peerIndex uint32, templateName string) error
+	PayMultihopFunc                   func(destLNAddr string, coinType uint32, amount int64) (*qln.InFlightMultihop, error)
+	PayMultihopAsyncFunc              func(ctx context.Context, destLNAddr string, coinType uint32, amount int64) *litrpcclient.MultihopPayment
+	PayMultihopCtxFunc                func(ctx context.Context, destLNAddr string, coinType uint32, amount int64) (*qln.InFlightMultihop, error)
+	PendingDualFundFunc               func() (*litrpcclient.DualFundRequest, error)
+	PendingDualFundCtxFunc            func(ctx context.Context) (*litrpcclient.DualFundRequest, error)
+	PushFunc                          func(channelIndex uint32, amount int64, data []byte) (uint64, error)
+	PushCtxFunc                       func(ctx context.Context, channelIndex uint32, amount int64, data []byte) (uint64, error)
+	PushResultFunc                    func(channelIndex uint32, amount int64, data []byte) (*litrpcclient.OperationResult, error)
+	PushResultCtxFunc                 func(ctx context.Context, channelIndex uint32, amount int64, data []byte) (*litrpcclient.OperationResult, error)
+	PushTxFunc                        func(rawTxHex string, coinType uint32) (string, error)
+	PushTxCtxFunc                     func(ctx context.Context, rawTxHex string, coinType uint32) (string, error)
+	PushWithReceiptFunc               func(channelIndex uint32, amount int64, data []byte) (*litrpcclient.PushReceipt, error)
+	PushWithReceiptCtxFunc            func(ctx context.Context, channelIndex uint32, amount int64, data []byte) (*litrpcclient.PushReceipt, error)
+	RegisterChannelTemplatesFunc      func(templates ...litrpcclient.ChannelTemplate)
+	RequestRemoteAccessFunc           func(pubKey *koblitz.PublicKey) error
+	RequestRemoteAccessCtxFunc        func(ctx context.Context, pubKey *koblitz.PublicKey) error
+	RequiredConfirmationsFunc         func(coinType uint32) int32
+	RevokeRemoteControlFunc           func(pubKey *koblitz.PublicKey) error
+	RevokeRemoteControlCtxFunc        func(ctx context.Context, pubKey *koblitz.PublicKey) error
+	SayFunc                           func(peerIndex uint32, message string) error
+	SayCtxFunc                        func(ctx context.Context, peerIndex uint32, message string) error
+	SelfInfoFunc                      func() (*litrpcclient.SelfInfo, error)
+	SelfInfoCtxFunc                   func(ctx context.Context) (*litrpcclient.SelfInfo, error)
+	SelfTestFunc                      func(ctx context.Context) *litrpcclient.SelfTestReport
+	SendFunc                          func(address string, amount int64) (string, error)
+	SendCtxFunc                       func(ctx context.Context, address string, amount int64) (string, error)
+	SendResultFunc                    func(address string, amount int64) (*litrpcclient.OperationResult, error)
+	SendResultCtxFunc                 func(ctx context.Context, address string, amount int64) (*litrpcclient.OperationResult, error)
+	SetContractCoinTypeFunc           func(contractIndex uint64, coinType uint32) error
+	SetContractCoinTypeCtxFunc        func(ctx context.Context, contractIndex uint64, coinType uint32) error
+	SetContractDivisionFunc           func(contractIndex uint64, valueFullyOurs, valueFullyTheirs int64) error
+	SetContractDivisionCtxFunc        func(ctx context.Context, contractIndex uint64, valueFullyOurs, valueFullyTheirs int64) error
+	SetContractFundingFunc            func(contractIndex uint64, ourAmount, theirAmount int64) error
+	SetContractFundingCtxFunc         func(ctx context.Context, contractIndex uint64, ourAmount, theirAmount int64) error
+	SetContractOracleFunc             func(contractIndex, oracleIndex uint64) error
+	SetContractOracleCtxFunc          func(ctx context.Context, contractIndex, oracleIndex uint64) error
+	SetContractRPointFunc             func(contractIndex uint64, rPoint []byte) error
+	SetContractRPointCtxFunc          func(ctx context.Context, contractIndex uint64, rPoint []byte) error
+	SetContractSettlementTimeFunc     func(contractIndex uint64, settlementTime uint64) error
+	SetContractSettlementTimeCtxFunc  func(ctx context.Context, contractIndex uint64, settlementTime uint64) error
+	SetFeeFunc                        func(coinType uint32, feePerByte int64) error
+	SetFeeCtxFunc                     func(ctx context.Context, coinType uint32, feePerByte int64) error
+	SetPeerPolicyFunc                 func(policy litrpcclient.PeerPolicy)
+	SetPushCoalescingFunc             func(enabled bool)
+	SetPushQueueDepthFunc             func(depth int)
+	SettleContractFunc                func(contractIndex uint64, oracleValue int64, oracleSignature []byte) error
+	SettleContractCtxFunc             func(ctx context.Context, contractIndex uint64, oracleValue int64, oracleSignature []byte) error
+	SettleContractResultFunc          func(contractIndex uint64, oracleValue int64, oracleSignature []byte) (*litrpcclient.OperationResult, error)
+	SettleContractResultCtxFunc       func(ctx context.Context, contractIndex uint64, oracleValue int64, oracleSignature []byte) (*litrpcclient.OperationResult, error)
+	SettleHTLCFunc                    func(channelIndex, htlcIndex uint32, preimage [16]byte) (uint64, error)
+	SettleHTLCCtxFunc                 func(ctx context.Context, channelIndex, htlcIndex uint32, preimage [16]byte) (uint64, error)
+	SettleHTLCResultFunc              func(channelIndex, htlcIndex uint32, preimage [16]byte) (*litrpcclient.OperationResult, error)
+	SettleHTLCResultCtxFunc           func(ctx context.Context, channelIndex, htlcIndex uint32, preimage [16]byte) (*litrpcclient.OperationResult, error)
+	StateDumpFunc                     func() ([]qln.JusticeTx, error)
+	StateDumpCtxFunc                  func(ctx context.Context) ([]qln.JusticeTx, error)
+	StopFunc                          func() error
+	StopCtxFunc                       func(ctx context.Context) error
+	StreamFunc                        func(ctx context.Context, channelIndex uint32, ratePerSecond, totalCap int64) *litrpcclient.PaymentStream
+	SupportsFunc                      func(method string) (bool, error)
+	SupportsCtxFunc                   func(ctx context.Context, method string) (bool, error)
+	SweepFunc                         func(coinType uint32, destAddress string, dryRun bool) ([]string, error)
+	SweepCtxFunc                      func(ctx context.Context, coinType uint32, destAddress string, dryRun bool) ([]string, error)
+	SweepResultFunc                   func(coinType uint32, destAddress string) (*litrpcclient.OperationResult, error)
+	SweepResultCtxFunc                func(ctx context.Context, coinType uint32, destAddress string) (*litrpcclient.OperationResult, error)
+	SyncStatusFunc                    func() ([]litrpcclient.CoinSyncStatus, error)
+	SyncStatusCtxFunc                 func(ctx context.Context) ([]litrpcclient.CoinSyncStatus, error)
+	TakeBreakSnapshotFunc             func(ctx context.Context, channelIndex uint32, reason string) (*litrpcclient.BreakSnapshot, error)
+	UnsupportedMethodsFunc            func() []string
+	WaitForChannelOpenFunc            func(ctx context.Context, channelIndex uint32, interval time.Duration) (*litrpcclient.ChannelStatus, error)
+	WatchContractsFunc                func(ctx context.Context, interval time.Duration) <-chan litrpcclient.ContractEvent
+	WatchHTLCsFunc                    func(ctx context.Context) <-chan litrpcclient.HTLCEvent
+	WatchMessagesFunc                 func(ctx context.Context) <-chan litrpcclient.ChatMessage
+
+	mtx   sync.Mutex
+	calls []string
+}
+
+var _ litrpcclient.LitClient = (*Client)(nil)
+
+// record adds a call of [method] to the recorded calls
+func (m *Client) record(method string) {
+	m.mtx.Lock()
+	m.calls = append(m.calls, method)
+	m.mtx.Unlock()
+}
+
+// Calls returns the names of the methods called on the mock, in order
+func (m *Client) Calls() []string {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+func (m *Client) AcceptContract(contractIndex uint64) (err error) {
+	m.record("AcceptContract")
+	if m.AcceptContractFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.AcceptContractFunc(contractIndex)
+}
+
+func (m *Client) AcceptContractCtx(ctx context.Context, contractIndex uint64) (err error) {
+	m.record("AcceptContractCtx")
+	if m.AcceptContractCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.AcceptContractCtxFunc(ctx, contractIndex)
+}
+
+func (m *Client) AddHTLC(channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (r0 uint32, r1 uint64, err error) {
+	m.record("AddHTLC")
+	if m.AddHTLCFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.AddHTLCFunc(channelIndex, amount, lockTime, hash, data)
+}
+
+func (m *Client) AddHTLCCtx(ctx context.Context, channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (r0 uint32, r1 uint64, err error) {
+	m.record("AddHTLCCtx")
+	if m.AddHTLCCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.AddHTLCCtxFunc(ctx, channelIndex, amount, lockTime, hash, data)
+}
+
+func (m *Client) AddHTLCResult(channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("AddHTLCResult")
+	if m.AddHTLCResultFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.AddHTLCResultFunc(channelIndex, amount, lockTime, hash, data)
+}
+
+func (m *Client) AddHTLCResultCtx(ctx context.Context, channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("AddHTLCResultCtx")
+	if m.AddHTLCResultCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.AddHTLCResultCtxFunc(ctx, channelIndex, amount, lockTime, hash, data)
+}
+
+func (m *Client) AddOracle(pubKeyHex, name string) (r0 *dlc.DlcOracle, err error) {
+	m.record("AddOracle")
+	if m.AddOracleFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.AddOracleFunc(pubKeyHex, name)
+}
+
+func (m *Client) AddOracleCtx(ctx context.Context, pubKeyHex, name string) (r0 *dlc.DlcOracle, err error) {
+	m.record("AddOracleCtx")
+	if m.AddOracleCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.AddOracleCtxFunc(ctx, pubKeyHex, name)
+}
+
+func (m *Client) ArchiveStates(w io.Writer, keep uint64) (r0 int, err error) {
+	m.record("ArchiveStates")
+	if m.ArchiveStatesFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ArchiveStatesFunc(w, keep)
+}
+
+func (m *Client) ArchiveStatesCtx(ctx context.Context, w io.Writer, keep uint64) (r0 int, err error) {
+	m.record("ArchiveStatesCtx")
+	if m.ArchiveStatesCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ArchiveStatesCtxFunc(ctx, w, keep)
+}
+
+func (m *Client) AssignNickname(peerIndex uint32, nickname string) (err error) {
+	m.record("AssignNickname")
+	if m.AssignNicknameFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.AssignNicknameFunc(peerIndex, nickname)
+}
+
+func (m *Client) AssignNicknameCtx(ctx context.Context, peerIndex uint32, nickname string) (err error) {
+	m.record("AssignNicknameCtx")
+	if m.AssignNicknameCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.AssignNicknameCtxFunc(ctx, peerIndex, nickname)
+}
+
+func (m *Client) AuthorizeRemoteControl(pubKey *koblitz.PublicKey) (err error) {
+	m.record("AuthorizeRemoteControl")
+	if m.AuthorizeRemoteControlFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.AuthorizeRemoteControlFunc(pubKey)
+}
+
+func (m *Client) AuthorizeRemoteControlCtx(ctx context.Context, pubKey *koblitz.PublicKey) (err error) {
+	m.record("AuthorizeRemoteControlCtx")
+	if m.AuthorizeRemoteControlCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.AuthorizeRemoteControlCtxFunc(ctx, pubKey)
+}
+
+func (m *Client) BreakChannel(channelIndex uint32) (err error) {
+	m.record("BreakChannel")
+	if m.BreakChannelFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.BreakChannelFunc(channelIndex)
+}
+
+func (m *Client) BreakChannelCtx(ctx context.Context, channelIndex uint32) (err error) {
+	m.record("BreakChannelCtx")
+	if m.BreakChannelCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.BreakChannelCtxFunc(ctx, channelIndex)
+}
+
+func (m *Client) BreakChannelResult(channelIndex uint32) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("BreakChannelResult")
+	if m.BreakChannelResultFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.BreakChannelResultFunc(channelIndex)
+}
+
+func (m *Client) BreakChannelResultCtx(ctx context.Context, channelIndex uint32) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("BreakChannelResultCtx")
+	if m.BreakChannelResultCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.BreakChannelResultCtxFunc(ctx, channelIndex)
+}
+
+func (m *Client) BreakChannelWithReason(channelIndex uint32, reason string) (err error) {
+	m.record("BreakChannelWithReason")
+	if m.BreakChannelWithReasonFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.BreakChannelWithReasonFunc(channelIndex, reason)
+}
+
+func (m *Client) BreakChannelWithReasonCtx(ctx context.Context, channelIndex uint32, reason string) (err error) {
+	m.record("BreakChannelWithReasonCtx")
+	if m.BreakChannelWithReasonCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.BreakChannelWithReasonCtxFunc(ctx, channelIndex, reason)
+}
+
+func (m *Client) CallAsync(ctx context.Context, method string, args interface{}) (r0 *litrpcclient.PendingCall) {
+	m.record("CallAsync")
+	if m.CallAsyncFunc == nil {
+		return
+	}
+	return m.CallAsyncFunc(ctx, method, args)
+}
+
+func (m *Client) CallContext(ctx context.Context, method string, args interface{}, reply interface{}) (err error) {
+	m.record("CallContext")
+	if m.CallContextFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.CallContextFunc(ctx, method, args, reply)
+}
+
+func (m *Client) ChannelTemplate(name string) (r0 litrpcclient.ChannelTemplate, r1 bool) {
+	m.record("ChannelTemplate")
+	if m.ChannelTemplateFunc == nil {
+		return
+	}
+	return m.ChannelTemplateFunc(name)
+}
+
+func (m *Client) CheckAddressReuse() (r0 []litrpcclient.AddressReuseWarning, err error) {
+	m.record("CheckAddressReuse")
+	if m.CheckAddressReuseFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.CheckAddressReuseFunc()
+}
+
+func (m *Client) CheckAddressReuseCtx(ctx context.Context) (r0 []litrpcclient.AddressReuseWarning, err error) {
+	m.record("CheckAddressReuseCtx")
+	if m.CheckAddressReuseCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.CheckAddressReuseCtxFunc(ctx)
+}
+
+func (m *Client) CheckCompatibility() (r0 []litrpcclient.Incompatibility, err error) {
+	m.record("CheckCompatibility")
+	if m.CheckCompatibilityFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.CheckCompatibilityFunc()
+}
+
+func (m *Client) CheckCompatibilityCtx(ctx context.Context) (r0 []litrpcclient.Incompatibility, err error) {
+	m.record("CheckCompatibilityCtx")
+	if m.CheckCompatibilityCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.CheckCompatibilityCtxFunc(ctx)
+}
+
+func (m *Client) CheckContract(contractIndex uint64) (err error) {
+	m.record("CheckContract")
+	if m.CheckContractFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.CheckContractFunc(contractIndex)
+}
+
+func (m *Client) CheckContractCtx(ctx context.Context, contractIndex uint64) (err error) {
+	m.record("CheckContractCtx")
+	if m.CheckContractCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.CheckContractCtxFunc(ctx, contractIndex)
+}
+
+func (m *Client) Close() {
+	m.record("Close")
+	if m.CloseFunc == nil {
+		return
+	}
+	m.CloseFunc()
+}
+
+func (m *Client) CloseChannel(channelIndex uint32) (err error) {
+	m.record("CloseChannel")
+	if m.CloseChannelFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.CloseChannelFunc(channelIndex)
+}
+
+func (m *Client) CloseChannelCtx(ctx context.Context, channelIndex uint32) (err error) {
+	m.record("CloseChannelCtx")
+	if m.CloseChannelCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.CloseChannelCtxFunc(ctx, channelIndex)
+}
+
+func (m *Client) CloseChannelResult(channelIndex uint32) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("CloseChannelResult")
+	if m.CloseChannelResultFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.CloseChannelResultFunc(channelIndex)
+}
+
+func (m *Client) CloseChannelResultCtx(ctx context.Context, channelIndex uint32) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("CloseChannelResultCtx")
+	if m.CloseChannelResultCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.CloseChannelResultCtxFunc(ctx, channelIndex)
+}
+
+func (m *Client) ConnStats() (r0 litrpcclient.ConnStats) {
+	m.record("ConnStats")
+	if m.ConnStatsFunc == nil {
+		return
+	}
+	return m.ConnStatsFunc()
+}
+
+func (m *Client) Connect(address, host string, port uint32) (err error) {
+	m.record("Connect")
+	if m.ConnectFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ConnectFunc(address, host, port)
+}
+
+func (m *Client) ConnectCtx(ctx context.Context, address, host string, port uint32) (err error) {
+	m.record("ConnectCtx")
+	if m.ConnectCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ConnectCtxFunc(ctx, address, host, port)
+}
+
+func (m *Client) ConnectPeer(address, host string, port uint32) (r0 *litrpcclient.ConnectResult, err error) {
+	m.record("ConnectPeer")
+	if m.ConnectPeerFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ConnectPeerFunc(address, host, port)
+}
+
+func (m *Client) ConnectPeerCtx(ctx context.Context, address, host string, port uint32) (r0 *litrpcclient.ConnectResult, err error) {
+	m.record("ConnectPeerCtx")
+	if m.ConnectPeerCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ConnectPeerCtxFunc(ctx, address, host, port)
+}
+
+func (m *Client) ConnectViaTracker(t *tracker.Client, address string) (err error) {
+	m.record("ConnectViaTracker")
+	if m.ConnectViaTrackerFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ConnectViaTrackerFunc(t, address)
+}
+
+func (m *Client) ConnectViaTrackerCtx(ctx context.Context, t *tracker.Client, address string) (err error) {
+	m.record("ConnectViaTrackerCtx")
+	if m.ConnectViaTrackerCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ConnectViaTrackerCtxFunc(ctx, t, address)
+}
+
+func (m *Client) DeclineContract(contractIndex uint64) (err error) {
+	m.record("DeclineContract")
+	if m.DeclineContractFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.DeclineContractFunc(contractIndex)
+}
+
+func (m *Client) DeclineContractCtx(ctx context.Context, contractIndex uint64) (err error) {
+	m.record("DeclineContractCtx")
+	if m.DeclineContractCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.DeclineContractCtxFunc(ctx, contractIndex)
+}
+
+func (m *Client) DualFundAccept() (err error) {
+	m.record("DualFundAccept")
+	if m.DualFundAcceptFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.DualFundAcceptFunc()
+}
+
+func (m *Client) DualFundAcceptCtx(ctx context.Context) (err error) {
+	m.record("DualFundAcceptCtx")
+	if m.DualFundAcceptCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.DualFundAcceptCtxFunc(ctx)
+}
+
+func (m *Client) DualFundChannel(peerIndex, coinType uint32, ourAmount, theirAmount int64) (err error) {
+	m.record("DualFundChannel")
+	if m.DualFundChannelFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.DualFundChannelFunc(peerIndex, coinType, ourAmount, theirAmount)
+}
+
+func (m *Client) DualFundChannelCtx(ctx context.Context, peerIndex, coinType uint32, ourAmount, theirAmount int64) (err error) {
+	m.record("DualFundChannelCtx")
+	if m.DualFundChannelCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.DualFundChannelCtxFunc(ctx, peerIndex, coinType, ourAmount, theirAmount)
+}
+
+func (m *Client) DualFundDecline() (err error) {
+	m.record("DualFundDecline")
+	if m.DualFundDeclineFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.DualFundDeclineFunc()
+}
+
+func (m *Client) DualFundDeclineCtx(ctx context.Context) (err error) {
+	m.record("DualFundDeclineCtx")
+	if m.DualFundDeclineCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.DualFundDeclineCtxFunc(ctx)
+}
+
+func (m *Client) FailHTLC(channelIndex, htlcIndex uint32) (r0 uint64, err error) {
+	m.record("FailHTLC")
+	if m.FailHTLCFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FailHTLCFunc(channelIndex, htlcIndex)
+}
+
+func (m *Client) FailHTLCCtx(ctx context.Context, channelIndex, htlcIndex uint32) (r0 uint64, err error) {
+	m.record("FailHTLCCtx")
+	if m.FailHTLCCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FailHTLCCtxFunc(ctx, channelIndex, htlcIndex)
+}
+
+func (m *Client) FailHTLCResult(channelIndex, htlcIndex uint32) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("FailHTLCResult")
+	if m.FailHTLCResultFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FailHTLCResultFunc(channelIndex, htlcIndex)
+}
+
+func (m *Client) FailHTLCResultCtx(ctx context.Context, channelIndex, htlcIndex uint32) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("FailHTLCResultCtx")
+	if m.FailHTLCResultCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FailHTLCResultCtxFunc(ctx, channelIndex, htlcIndex)
+}
+
+func (m *Client) Fanout(destAddress string, numOutputs uint32, amountPerOutput int64) (r0 string, r1 []string, err error) {
+	m.record("Fanout")
+	if m.FanoutFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FanoutFunc(destAddress, numOutputs, amountPerOutput)
+}
+
+func (m *Client) FanoutCtx(ctx context.Context, destAddress string, numOutputs uint32, amountPerOutput int64) (r0 string, r1 []string, err error) {
+	m.record("FanoutCtx")
+	if m.FanoutCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FanoutCtxFunc(ctx, destAddress, numOutputs, amountPerOutput)
+}
+
+func (m *Client) FanoutResult(destAddress string, numOutputs uint32, amountPerOutput int64) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("FanoutResult")
+	if m.FanoutResultFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FanoutResultFunc(destAddress, numOutputs, amountPerOutput)
+}
+
+func (m *Client) FanoutResultCtx(ctx context.Context, destAddress string, numOutputs uint32, amountPerOutput int64) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("FanoutResultCtx")
+	if m.FanoutResultCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FanoutResultCtxFunc(ctx, destAddress, numOutputs, amountPerOutput)
+}
+
+func (m *Client) FundChannel(peerIndex, coinType uint32, amount, initialSend int64, data []byte) (err error) {
+	m.record("FundChannel")
+	if m.FundChannelFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FundChannelFunc(peerIndex, coinType, amount, initialSend, data)
+}
+
+func (m *Client) FundChannelCtx(ctx context.Context, peerIndex, coinType uint32, amount, initialSend int64, data []byte) (err error) {
+	m.record("FundChannelCtx")
+	if m.FundChannelCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FundChannelCtxFunc(ctx, peerIndex, coinType, amount, initialSend, data)
+}
+
+func (m *Client) FundChannelProgress(ctx context.Context, peerIndex, coinType uint32, amount, initialSend int64, data []byte, interval time.Duration, progress func(litrpcclient.FundingProgress)) (r0 *litrpcclient.ChannelStatus, err error) {
+	m.record("FundChannelProgress")
+	if m.FundChannelProgressFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FundChannelProgressFunc(ctx, peerIndex, coinType, amount, initialSend, data, interval, progress)
+}
+
+func (m *Client) FundChannelResult(peerIndex, coinType uint32, amount, initialSend int64, data []byte) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("FundChannelResult")
+	if m.FundChannelResultFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FundChannelResultFunc(peerIndex, coinType, amount, initialSend, data)
+}
+
+func (m *Client) FundChannelResultCtx(ctx context.Context, peerIndex, coinType uint32, amount, initialSend int64, data []byte) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("FundChannelResultCtx")
+	if m.FundChannelResultCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.FundChannelResultCtxFunc(ctx, peerIndex, coinType, amount, initialSend, data)
+}
+
+func (m *Client) GetAddresses(coinType, numberToMake uint32, legacy bool) (r0 []string, err error) {
+	m.record("GetAddresses")
+	if m.GetAddressesFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetAddressesFunc(coinType, numberToMake, legacy)
+}
+
+func (m *Client) GetAddressesCtx(ctx context.Context, coinType, numberToMake uint32, legacy bool) (r0 []string, err error) {
+	m.record("GetAddressesCtx")
+	if m.GetAddressesCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetAddressesCtxFunc(ctx, coinType, numberToMake, legacy)
+}
+
+func (m *Client) GetChannel(channelIndex uint32) (r0 *litrpc.ChannelInfo, err error) {
+	m.record("GetChannel")
+	if m.GetChannelFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetChannelFunc(channelIndex)
+}
+
+func (m *Client) GetChannelCtx(ctx context.Context, channelIndex uint32) (r0 *litrpc.ChannelInfo, err error) {
+	m.record("GetChannelCtx")
+	if m.GetChannelCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetChannelCtxFunc(ctx, channelIndex)
+}
+
+func (m *Client) GetContract(contractIndex uint64) (r0 *lnutil.DlcContract, err error) {
+	m.record("GetContract")
+	if m.GetContractFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetContractFunc(contractIndex)
+}
+
+func (m *Client) GetContractCtx(ctx context.Context, contractIndex uint64) (r0 *lnutil.DlcContract, err error) {
+	m.record("GetContractCtx")
+	if m.GetContractCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetContractCtxFunc(ctx, contractIndex)
+}
+
+func (m *Client) GetFee(coinType uint32) (r0 int64, err error) {
+	m.record("GetFee")
+	if m.GetFeeFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetFeeFunc(coinType)
+}
+
+func (m *Client) GetFeeCtx(ctx context.Context, coinType uint32) (r0 int64, err error) {
+	m.record("GetFeeCtx")
+	if m.GetFeeCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetFeeCtxFunc(ctx, coinType)
+}
+
+func (m *Client) GetLNAddress() (r0 string, err error) {
+	m.record("GetLNAddress")
+	if m.GetLNAddressFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetLNAddressFunc()
+}
+
+func (m *Client) GetLNAddressCtx(ctx context.Context) (r0 string, err error) {
+	m.record("GetLNAddressCtx")
+	if m.GetLNAddressCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetLNAddressCtxFunc(ctx)
+}
+
+func (m *Client) GetMessage() (r0 *litrpcclient.ChatMessage, err error) {
+	m.record("GetMessage")
+	if m.GetMessageFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetMessageFunc()
+}
+
+func (m *Client) GetMessageCtx(ctx context.Context) (r0 *litrpcclient.ChatMessage, err error) {
+	m.record("GetMessageCtx")
+	if m.GetMessageCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetMessageCtxFunc(ctx)
+}
+
+func (m *Client) GetNodeConfig() (r0 *litrpcclient.NodeConfig, err error) {
+	m.record("GetNodeConfig")
+	if m.GetNodeConfigFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetNodeConfigFunc()
+}
+
+func (m *Client) GetNodeConfigCtx(ctx context.Context) (r0 *litrpcclient.NodeConfig, err error) {
+	m.record("GetNodeConfigCtx")
+	if m.GetNodeConfigCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetNodeConfigCtxFunc(ctx)
+}
+
+func (m *Client) GetNodeSnapshot() (r0 *litrpcclient.NodeSnapshot, err error) {
+	m.record("GetNodeSnapshot")
+	if m.GetNodeSnapshotFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetNodeSnapshotFunc()
+}
+
+func (m *Client) GetNodeSnapshotCtx(ctx context.Context) (r0 *litrpcclient.NodeSnapshot, err error) {
+	m.record("GetNodeSnapshotCtx")
+	if m.GetNodeSnapshotCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetNodeSnapshotCtxFunc(ctx)
+}
+
+func (m *Client) GetTransaction(txid string, coinType uint32) (r0 *litrpcclient.Transaction, err error) {
+	m.record("GetTransaction")
+	if m.GetTransactionFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetTransactionFunc(txid, coinType)
+}
+
+func (m *Client) GetTransactionCtx(ctx context.Context, txid string, coinType uint32) (r0 *litrpcclient.Transaction, err error) {
+	m.record("GetTransactionCtx")
+	if m.GetTransactionCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.GetTransactionCtxFunc(ctx, txid, coinType)
+}
+
+func (m *Client) ImportOracle(url, name string) (r0 *dlc.DlcOracle, err error) {
+	m.record("ImportOracle")
+	if m.ImportOracleFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ImportOracleFunc(url, name)
+}
+
+func (m *Client) ImportOracleCtx(ctx context.Context, url, name string) (r0 *dlc.DlcOracle, err error) {
+	m.record("ImportOracleCtx")
+	if m.ImportOracleCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ImportOracleCtxFunc(ctx, url, name)
+}
+
+func (m *Client) ImportOraclesFromDirectory(url string, directoryKey *koblitz.PublicKey) (r0 []litrpcclient.DirectoryImportResult, err error) {
+	m.record("ImportOraclesFromDirectory")
+	if m.ImportOraclesFromDirectoryFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ImportOraclesFromDirectoryFunc(url, directoryKey)
+}
+
+func (m *Client) ImportOraclesFromDirectoryCtx(ctx context.Context, url string, directoryKey *koblitz.PublicKey) (r0 []litrpcclient.DirectoryImportResult, err error) {
+	m.record("ImportOraclesFromDirectoryCtx")
+	if m.ImportOraclesFromDirectoryCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ImportOraclesFromDirectoryCtxFunc(ctx, url, directoryKey)
+}
+
+func (m *Client) IsListening() (r0 bool, err error) {
+	m.record("IsListening")
+	if m.IsListeningFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.IsListeningFunc()
+}
+
+func (m *Client) IsListeningCtx(ctx context.Context) (r0 bool, err error) {
+	m.record("IsListeningCtx")
+	if m.IsListeningCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.IsListeningCtxFunc(ctx)
+}
+
+func (m *Client) ListBalances() (r0 []litrpc.CoinBalReply, err error) {
+	m.record("ListBalances")
+	if m.ListBalancesFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListBalancesFunc()
+}
+
+func (m *Client) ListBalancesCtx(ctx context.Context) (r0 []litrpc.CoinBalReply, err error) {
+	m.record("ListBalancesCtx")
+	if m.ListBalancesCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListBalancesCtxFunc(ctx)
+}
+
+func (m *Client) ListBalancesInto(dst []litrpc.CoinBalReply) (r0 []litrpc.CoinBalReply, err error) {
+	m.record("ListBalancesInto")
+	if m.ListBalancesIntoFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListBalancesIntoFunc(dst)
+}
+
+func (m *Client) ListBalancesIntoCtx(ctx context.Context, dst []litrpc.CoinBalReply) (r0 []litrpc.CoinBalReply, err error) {
+	m.record("ListBalancesIntoCtx")
+	if m.ListBalancesIntoCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListBalancesIntoCtxFunc(ctx, dst)
+}
+
+func (m *Client) ListChannelStatuses() (r0 []litrpcclient.ChannelStatus, err error) {
+	m.record("ListChannelStatuses")
+	if m.ListChannelStatusesFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListChannelStatusesFunc()
+}
+
+func (m *Client) ListChannelStatusesCtx(ctx context.Context) (r0 []litrpcclient.ChannelStatus, err error) {
+	m.record("ListChannelStatusesCtx")
+	if m.ListChannelStatusesCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListChannelStatusesCtxFunc(ctx)
+}
+
+func (m *Client) ListChannels() (r0 []litrpc.ChannelInfo, err error) {
+	m.record("ListChannels")
+	if m.ListChannelsFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListChannelsFunc()
+}
+
+func (m *Client) ListChannelsCtx(ctx context.Context) (r0 []litrpc.ChannelInfo, err error) {
+	m.record("ListChannelsCtx")
+	if m.ListChannelsCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListChannelsCtxFunc(ctx)
+}
+
+func (m *Client) ListChannelsFiltered(filters ...litrpcclient.ChannelFilter) (r0 []litrpc.ChannelInfo, err error) {
+	m.record("ListChannelsFiltered")
+	if m.ListChannelsFilteredFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListChannelsFilteredFunc(filters...)
+}
+
+func (m *Client) ListChannelsFilteredCtx(ctx context.Context, filters ...litrpcclient.ChannelFilter) (r0 []litrpc.ChannelInfo, err error) {
+	m.record("ListChannelsFilteredCtx")
+	if m.ListChannelsFilteredCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListChannelsFilteredCtxFunc(ctx, filters...)
+}
+
+func (m *Client) ListChannelsInto(dst []litrpc.ChannelInfo) (r0 []litrpc.ChannelInfo, err error) {
+	m.record("ListChannelsInto")
+	if m.ListChannelsIntoFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListChannelsIntoFunc(dst)
+}
+
+func (m *Client) ListChannelsIntoCtx(ctx context.Context, dst []litrpc.ChannelInfo) (r0 []litrpc.ChannelInfo, err error) {
+	m.record("ListChannelsIntoCtx")
+	if m.ListChannelsIntoCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListChannelsIntoCtxFunc(ctx, dst)
+}
+
+func (m *Client) ListConnections() (r0 []qln.PeerInfo, err error) {
+	m.record("ListConnections")
+	if m.ListConnectionsFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListConnectionsFunc()
+}
+
+func (m *Client) ListConnectionsCtx(ctx context.Context) (r0 []qln.PeerInfo, err error) {
+	m.record("ListConnectionsCtx")
+	if m.ListConnectionsCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListConnectionsCtxFunc(ctx)
+}
+
+func (m *Client) ListConnectionsFiltered(includeArchived bool) (r0 []qln.PeerInfo, err error) {
+	m.record("ListConnectionsFiltered")
+	if m.ListConnectionsFilteredFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListConnectionsFilteredFunc(includeArchived)
+}
+
+func (m *Client) ListConnectionsFilteredCtx(ctx context.Context, includeArchived bool) (r0 []qln.PeerInfo, err error) {
+	m.record("ListConnectionsFilteredCtx")
+	if m.ListConnectionsFilteredCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListConnectionsFilteredCtxFunc(ctx, includeArchived)
+}
+
+func (m *Client) ListContracts() (r0 []*lnutil.DlcContract, err error) {
+	m.record("ListContracts")
+	if m.ListContractsFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListContractsFunc()
+}
+
+func (m *Client) ListContractsCtx(ctx context.Context) (r0 []*lnutil.DlcContract, err error) {
+	m.record("ListContractsCtx")
+	if m.ListContractsCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListContractsCtxFunc(ctx)
+}
+
+func (m *Client) ListExistingAddresses(coinType uint32) (r0 []litrpcclient.Address, err error) {
+	m.record("ListExistingAddresses")
+	if m.ListExistingAddressesFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListExistingAddressesFunc(coinType)
+}
+
+func (m *Client) ListExistingAddressesCtx(ctx context.Context, coinType uint32) (r0 []litrpcclient.Address, err error) {
+	m.record("ListExistingAddressesCtx")
+	if m.ListExistingAddressesCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListExistingAddressesCtxFunc(ctx, coinType)
+}
+
+func (m *Client) ListMultihopPayments() (r0 []*qln.InFlightMultihop, err error) {
+	m.record("ListMultihopPayments")
+	if m.ListMultihopPaymentsFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListMultihopPaymentsFunc()
+}
+
+func (m *Client) ListMultihopPaymentsCtx(ctx context.Context) (r0 []*qln.InFlightMultihop, err error) {
+	m.record("ListMultihopPaymentsCtx")
+	if m.ListMultihopPaymentsCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListMultihopPaymentsCtxFunc(ctx)
+}
+
+func (m *Client) ListOracles() (r0 []*dlc.DlcOracle, err error) {
+	m.record("ListOracles")
+	if m.ListOraclesFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListOraclesFunc()
+}
+
+func (m *Client) ListOraclesCtx(ctx context.Context) (r0 []*dlc.DlcOracle, err error) {
+	m.record("ListOraclesCtx")
+	if m.ListOraclesCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListOraclesCtxFunc(ctx)
+}
+
+func (m *Client) ListOraclesFiltered(includeArchived bool) (r0 []*dlc.DlcOracle, err error) {
+	m.record("ListOraclesFiltered")
+	if m.ListOraclesFilteredFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListOraclesFilteredFunc(includeArchived)
+}
+
+func (m *Client) ListOraclesFilteredCtx(ctx context.Context, includeArchived bool) (r0 []*dlc.DlcOracle, err error) {
+	m.record("ListOraclesFilteredCtx")
+	if m.ListOraclesFilteredCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListOraclesFilteredCtxFunc(ctx, includeArchived)
+}
+
+func (m *Client) ListRemoteAccessRequests() (r0 []*koblitz.PublicKey, err error) {
+	m.record("ListRemoteAccessRequests")
+	if m.ListRemoteAccessRequestsFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListRemoteAccessRequestsFunc()
+}
+
+func (m *Client) ListRemoteAccessRequestsCtx(ctx context.Context) (r0 []*koblitz.PublicKey, err error) {
+	m.record("ListRemoteAccessRequestsCtx")
+	if m.ListRemoteAccessRequestsCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListRemoteAccessRequestsCtxFunc(ctx)
+}
+
+func (m *Client) ListUtxos() (r0 []litrpc.TxoInfo, err error) {
+	m.record("ListUtxos")
+	if m.ListUtxosFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListUtxosFunc()
+}
+
+func (m *Client) ListUtxosCtx(ctx context.Context) (r0 []litrpc.TxoInfo, err error) {
+	m.record("ListUtxosCtx")
+	if m.ListUtxosCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListUtxosCtxFunc(ctx)
+}
+
+func (m *Client) ListWallets() (r0 []litrpcclient.Wallet, err error) {
+	m.record("ListWallets")
+	if m.ListWalletsFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListWalletsFunc()
+}
+
+func (m *Client) ListWalletsCtx(ctx context.Context) (r0 []litrpcclient.Wallet, err error) {
+	m.record("ListWalletsCtx")
+	if m.ListWalletsCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListWalletsCtxFunc(ctx)
+}
+
+func (m *Client) Listen(port string) (err error) {
+	m.record("Listen")
+	if m.ListenFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListenFunc(port)
+}
+
+func (m *Client) ListenAny(ports ...string) (r0 string, err error) {
+	m.record("ListenAny")
+	if m.ListenAnyFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListenAnyFunc(ports...)
+}
+
+func (m *Client) ListenAnyCtx(ctx context.Context, ports ...string) (r0 string, err error) {
+	m.record("ListenAnyCtx")
+	if m.ListenAnyCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListenAnyCtxFunc(ctx, ports...)
+}
+
+func (m *Client) ListenCtx(ctx context.Context, port string) (err error) {
+	m.record("ListenCtx")
+	if m.ListenCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.ListenCtxFunc(ctx, port)
+}
+
+func (m *Client) MatchOffer(incomingIdx, localDraftIdx uint64) (err error) {
+	m.record("MatchOffer")
+	if m.MatchOfferFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.MatchOfferFunc(incomingIdx, localDraftIdx)
+}
+
+func (m *Client) MatchOfferCtx(ctx context.Context, incomingIdx, localDraftIdx uint64) (err error) {
+	m.record("MatchOfferCtx")
+	if m.MatchOfferCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.MatchOfferCtxFunc(ctx, incomingIdx, localDraftIdx)
+}
+
+func (m *Client) NewAddress(coinType uint32, legacy bool) (r0 string, err error) {
+	m.record("NewAddress")
+	if m.NewAddressFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.NewAddressFunc(coinType, legacy)
+}
+
+func (m *Client) NewAddressCtx(ctx context.Context, coinType uint32, legacy bool) (r0 string, err error) {
+	m.record("NewAddressCtx")
+	if m.NewAddressCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.NewAddressCtxFunc(ctx, coinType, legacy)
+}
+
+func (m *Client) NewContract() (r0 *lnutil.DlcContract, err error) {
+	m.record("NewContract")
+	if m.NewContractFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.NewContractFunc()
+}
+
+func (m *Client) NewContractCtx(ctx context.Context) (r0 *lnutil.DlcContract, err error) {
+	m.record("NewContractCtx")
+	if m.NewContractCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.NewContractCtxFunc(ctx)
+}
+
+func (m *Client) OfferContract(contractIndex uint64, peerIndex uint32) (err error) {
+	m.record("OfferContract")
+	if m.OfferContractFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.OfferContractFunc(contractIndex, peerIndex)
+}
+
+func (m *Client) OfferContractCtx(ctx context.Context, contractIndex uint64, peerIndex uint32) (err error) {
+	m.record("OfferContractCtx")
+	if m.OfferContractCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.OfferContractCtxFunc(ctx, contractIndex, peerIndex)
+}
+
+func (m *Client) OpenCircuits() (r0 []string) {
+	m.record("OpenCircuits")
+	if m.OpenCircuitsFunc == nil {
+		return
+	}
+	return m.OpenCircuitsFunc()
+}
+
+func (m *Client) OpenFromTemplate(peerIndex uint32, templateName string) (err error) {
+	m.record("OpenFromTemplate")
+	if m.OpenFromTemplateFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.OpenFromTemplateFunc(peerIndex, templateName)
+}
+
+func (m *Client) OpenFromTemplateCtx(ctx context.Context, peerIndex uint32, templateName string) (err error) {
+	m.record("OpenFromTemplateCtx")
+	if m.OpenFromTemplateCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.OpenFromTemplateCtxFunc(ctx, peerIndex, templateName)
+}
+
+func (m *Client) PayMultihop(destLNAddr string, coinType uint32, amount int64) (r0 *qln.InFlightMultihop, err error) {
+	m.record("PayMultihop")
+	if m.PayMultihopFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.PayMultihopFunc(destLNAddr, coinType, amount)
+}
+
+func (m *Client) PayMultihopAsync(ctx context.Context, destLNAddr string, coinType uint32, amount int64) (r0 *litrpcclient.MultihopPayment) {
+	m.record("PayMultihopAsync")
+	if m.PayMultihopAsyncFunc == nil {
+		return
+	}
+	return m.PayMultihopAsyncFunc(ctx, destLNAddr, coinType, amount)
+}
+
+func (m *Client) PayMultihopCtx(ctx context.Context, destLNAddr string, coinType uint32, amount int64) (r0 *qln.InFlightMultihop, err error) {
+	m.record("PayMultihopCtx")
+	if m.PayMultihopCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.PayMultihopCtxFunc(ctx, destLNAddr, coinType, amount)
+}
+
+func (m *Client) PendingDualFund() (r0 *litrpcclient.DualFundRequest, err error) {
+	m.record("PendingDualFund")
+	if m.PendingDualFundFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.PendingDualFundFunc()
+}
+
+func (m *Client) PendingDualFundCtx(ctx context.Context) (r0 *litrpcclient.DualFundRequest, err error) {
+	m.record("PendingDualFundCtx")
+	if m.PendingDualFundCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.PendingDualFundCtxFunc(ctx)
+}
+
+func (m *Client) Push(channelIndex uint32, amount int64, data []byte) (r0 uint64, err error) {
+	m.record("Push")
+	if m.PushFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.PushFunc(channelIndex, amount, data)
+}
+
+func (m *Client) PushCtx(ctx context.Context, channelIndex uint32, amount int64, data []byte) (r0 uint64, err error) {
+	m.record("PushCtx")
+	if m.PushCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.PushCtxFunc(ctx, channelIndex, amount, data)
+}
+
+func (m *Client) PushResult(channelIndex uint32, amount int64, data []byte) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("PushResult")
+	if m.PushResultFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.PushResultFunc(channelIndex, amount, data)
+}
+
+func (m *Client) PushResultCtx(ctx context.Context, channelIndex uint32, amount int64, data []byte) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("PushResultCtx")
+	if m.PushResultCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.PushResultCtxFunc(ctx, channelIndex, amount, data)
+}
+
+func (m *Client) PushTx(rawTxHex string, coinType uint32) (r0 string, err error) {
+	m.record("PushTx")
+	if m.PushTxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.PushTxFunc(rawTxHex, coinType)
+}
+
+func (m *Client) PushTxCtx(ctx context.Context, rawTxHex string, coinType uint32) (r0 string, err error) {
+	m.record("PushTxCtx")
+	if m.PushTxCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.PushTxCtxFunc(ctx, rawTxHex, coinType)
+}
+
+func (m *Client) PushWithReceipt(channelIndex uint32, amount int64, data []byte) (r0 *litrpcclient.PushReceipt, err error) {
+	m.record("PushWithReceipt")
+	if m.PushWithReceiptFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.PushWithReceiptFunc(channelIndex, amount, data)
+}
+
+func (m *Client) PushWithReceiptCtx(ctx context.Context, channelIndex uint32, amount int64, data []byte) (r0 *litrpcclient.PushReceipt, err error) {
+	m.record("PushWithReceiptCtx")
+	if m.PushWithReceiptCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.PushWithReceiptCtxFunc(ctx, channelIndex, amount, data)
+}
+
+func (m *Client) RegisterChannelTemplates(templates ...litrpcclient.ChannelTemplate) {
+	m.record("RegisterChannelTemplates")
+	if m.RegisterChannelTemplatesFunc == nil {
+		return
+	}
+	m.RegisterChannelTemplatesFunc(templates...)
+}
+
+func (m *Client) RequestRemoteAccess(pubKey *koblitz.PublicKey) (err error) {
+	m.record("RequestRemoteAccess")
+	if m.RequestRemoteAccessFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.RequestRemoteAccessFunc(pubKey)
+}
+
+func (m *Client) RequestRemoteAccessCtx(ctx context.Context, pubKey *koblitz.PublicKey) (err error) {
+	m.record("RequestRemoteAccessCtx")
+	if m.RequestRemoteAccessCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.RequestRemoteAccessCtxFunc(ctx, pubKey)
+}
+
+func (m *Client) RequiredConfirmations(coinType uint32) (r0 int32) {
+	m.record("RequiredConfirmations")
+	if m.RequiredConfirmationsFunc == nil {
+		return
+	}
+	return m.RequiredConfirmationsFunc(coinType)
+}
+
+func (m *Client) RevokeRemoteControl(pubKey *koblitz.PublicKey) (err error) {
+	m.record("RevokeRemoteControl")
+	if m.RevokeRemoteControlFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.RevokeRemoteControlFunc(pubKey)
+}
+
+func (m *Client) RevokeRemoteControlCtx(ctx context.Context, pubKey *koblitz.PublicKey) (err error) {
+	m.record("RevokeRemoteControlCtx")
+	if m.RevokeRemoteControlCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.RevokeRemoteControlCtxFunc(ctx, pubKey)
+}
+
+func (m *Client) Say(peerIndex uint32, message string) (err error) {
+	m.record("Say")
+	if m.SayFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SayFunc(peerIndex, message)
+}
+
+func (m *Client) SayCtx(ctx context.Context, peerIndex uint32, message string) (err error) {
+	m.record("SayCtx")
+	if m.SayCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SayCtxFunc(ctx, peerIndex, message)
+}
+
+func (m *Client) SelfInfo() (r0 *litrpcclient.SelfInfo, err error) {
+	m.record("SelfInfo")
+	if m.SelfInfoFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SelfInfoFunc()
+}
+
+func (m *Client) SelfInfoCtx(ctx context.Context) (r0 *litrpcclient.SelfInfo, err error) {
+	m.record("SelfInfoCtx")
+	if m.SelfInfoCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SelfInfoCtxFunc(ctx)
+}
+
+func (m *Client) SelfTest(ctx context.Context) (r0 *litrpcclient.SelfTestReport) {
+	m.record("SelfTest")
+	if m.SelfTestFunc == nil {
+		return
+	}
+	return m.SelfTestFunc(ctx)
+}
+
+func (m *Client) Send(address string, amount int64) (r0 string, err error) {
+	m.record("Send")
+	if m.SendFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SendFunc(address, amount)
+}
+
+func (m *Client) SendCtx(ctx context.Context, address string, amount int64) (r0 string, err error) {
+	m.record("SendCtx")
+	if m.SendCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SendCtxFunc(ctx, address, amount)
+}
+
+func (m *Client) SendResult(address string, amount int64) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("SendResult")
+	if m.SendResultFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SendResultFunc(address, amount)
+}
+
+func (m *Client) SendResultCtx(ctx context.Context, address string, amount int64) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("SendResultCtx")
+	if m.SendResultCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SendResultCtxFunc(ctx, address, amount)
+}
+
+func (m *Client) SetContractCoinType(contractIndex uint64, coinType uint32) (err error) {
+	m.record("SetContractCoinType")
+	if m.SetContractCoinTypeFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetContractCoinTypeFunc(contractIndex, coinType)
+}
+
+func (m *Client) SetContractCoinTypeCtx(ctx context.Context, contractIndex uint64, coinType uint32) (err error) {
+	m.record("SetContractCoinTypeCtx")
+	if m.SetContractCoinTypeCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetContractCoinTypeCtxFunc(ctx, contractIndex, coinType)
+}
+
+func (m *Client) SetContractDivision(contractIndex uint64, valueFullyOurs, valueFullyTheirs int64) (err error) {
+	m.record("SetContractDivision")
+	if m.SetContractDivisionFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetContractDivisionFunc(contractIndex, valueFullyOurs, valueFullyTheirs)
+}
+
+func (m *Client) SetContractDivisionCtx(ctx context.Context, contractIndex uint64, valueFullyOurs, valueFullyTheirs int64) (err error) {
+	m.record("SetContractDivisionCtx")
+	if m.SetContractDivisionCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetContractDivisionCtxFunc(ctx, contractIndex, valueFullyOurs, valueFullyTheirs)
+}
+
+func (m *Client) SetContractFunding(contractIndex uint64, ourAmount, theirAmount int64) (err error) {
+	m.record("SetContractFunding")
+	if m.SetContractFundingFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetContractFundingFunc(contractIndex, ourAmount, theirAmount)
+}
+
+func (m *Client) SetContractFundingCtx(ctx context.Context, contractIndex uint64, ourAmount, theirAmount int64) (err error) {
+	m.record("SetContractFundingCtx")
+	if m.SetContractFundingCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetContractFundingCtxFunc(ctx, contractIndex, ourAmount, theirAmount)
+}
+
+func (m *Client) SetContractOracle(contractIndex, oracleIndex uint64) (err error) {
+	m.record("SetContractOracle")
+	if m.SetContractOracleFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetContractOracleFunc(contractIndex, oracleIndex)
+}
+
+func (m *Client) SetContractOracleCtx(ctx context.Context, contractIndex, oracleIndex uint64) (err error) {
+	m.record("SetContractOracleCtx")
+	if m.SetContractOracleCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetContractOracleCtxFunc(ctx, contractIndex, oracleIndex)
+}
+
+func (m *Client) SetContractRPoint(contractIndex uint64, rPoint []byte) (err error) {
+	m.record("SetContractRPoint")
+	if m.SetContractRPointFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetContractRPointFunc(contractIndex, rPoint)
+}
+
+func (m *Client) SetContractRPointCtx(ctx context.Context, contractIndex uint64, rPoint []byte) (err error) {
+	m.record("SetContractRPointCtx")
+	if m.SetContractRPointCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetContractRPointCtxFunc(ctx, contractIndex, rPoint)
+}
+
+func (m *Client) SetContractSettlementTime(contractIndex uint64, settlementTime uint64) (err error) {
+	m.record("SetContractSettlementTime")
+	if m.SetContractSettlementTimeFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetContractSettlementTimeFunc(contractIndex, settlementTime)
+}
+
+func (m *Client) SetContractSettlementTimeCtx(ctx context.Context, contractIndex uint64, settlementTime uint64) (err error) {
+	m.record("SetContractSettlementTimeCtx")
+	if m.SetContractSettlementTimeCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetContractSettlementTimeCtxFunc(ctx, contractIndex, settlementTime)
+}
+
+func (m *Client) SetFee(coinType uint32, feePerByte int64) (err error) {
+	m.record("SetFee")
+	if m.SetFeeFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetFeeFunc(coinType, feePerByte)
+}
+
+func (m *Client) SetFeeCtx(ctx context.Context, coinType uint32, feePerByte int64) (err error) {
+	m.record("SetFeeCtx")
+	if m.SetFeeCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SetFeeCtxFunc(ctx, coinType, feePerByte)
+}
+
+func (m *Client) SetPeerPolicy(policy litrpcclient.PeerPolicy) {
+	m.record("SetPeerPolicy")
+	if m.SetPeerPolicyFunc == nil {
+		return
+	}
+	m.SetPeerPolicyFunc(policy)
+}
+
+func (m *Client) SetPushCoalescing(enabled bool) {
+	m.record("SetPushCoalescing")
+	if m.SetPushCoalescingFunc == nil {
+		return
+	}
+	m.SetPushCoalescingFunc(enabled)
+}
+
+func (m *Client) SetPushQueueDepth(depth int) {
+	m.record("SetPushQueueDepth")
+	if m.SetPushQueueDepthFunc == nil {
+		return
+	}
+	m.SetPushQueueDepthFunc(depth)
+}
+
+func (m *Client) SettleContract(contractIndex uint64, oracleValue int64, oracleSignature []byte) (err error) {
+	m.record("SettleContract")
+	if m.SettleContractFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SettleContractFunc(contractIndex, oracleValue, oracleSignature)
+}
+
+func (m *Client) SettleContractCtx(ctx context.Context, contractIndex uint64, oracleValue int64, oracleSignature []byte) (err error) {
+	m.record("SettleContractCtx")
+	if m.SettleContractCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SettleContractCtxFunc(ctx, contractIndex, oracleValue, oracleSignature)
+}
+
+func (m *Client) SettleContractResult(contractIndex uint64, oracleValue int64, oracleSignature []byte) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("SettleContractResult")
+	if m.SettleContractResultFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SettleContractResultFunc(contractIndex, oracleValue, oracleSignature)
+}
+
+func (m *Client) SettleContractResultCtx(ctx context.Context, contractIndex uint64, oracleValue int64, oracleSignature []byte) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("SettleContractResultCtx")
+	if m.SettleContractResultCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SettleContractResultCtxFunc(ctx, contractIndex, oracleValue, oracleSignature)
+}
+
+func (m *Client) SettleHTLC(channelIndex, htlcIndex uint32, preimage [16]byte) (r0 uint64, err error) {
+	m.record("SettleHTLC")
+	if m.SettleHTLCFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SettleHTLCFunc(channelIndex, htlcIndex, preimage)
+}
+
+func (m *Client) SettleHTLCCtx(ctx context.Context, channelIndex, htlcIndex uint32, preimage [16]byte) (r0 uint64, err error) {
+	m.record("SettleHTLCCtx")
+	if m.SettleHTLCCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SettleHTLCCtxFunc(ctx, channelIndex, htlcIndex, preimage)
+}
+
+func (m *Client) SettleHTLCResult(channelIndex, htlcIndex uint32, preimage [16]byte) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("SettleHTLCResult")
+	if m.SettleHTLCResultFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SettleHTLCResultFunc(channelIndex, htlcIndex, preimage)
+}
+
+func (m *Client) SettleHTLCResultCtx(ctx context.Context, channelIndex, htlcIndex uint32, preimage [16]byte) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("SettleHTLCResultCtx")
+	if m.SettleHTLCResultCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SettleHTLCResultCtxFunc(ctx, channelIndex, htlcIndex, preimage)
+}
+
+func (m *Client) StateDump() (r0 []qln.JusticeTx, err error) {
+	m.record("StateDump")
+	if m.StateDumpFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.StateDumpFunc()
+}
+
+func (m *Client) StateDumpCtx(ctx context.Context) (r0 []qln.JusticeTx, err error) {
+	m.record("StateDumpCtx")
+	if m.StateDumpCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.StateDumpCtxFunc(ctx)
+}
+
+func (m *Client) Stop() (err error) {
+	m.record("Stop")
+	if m.StopFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.StopFunc()
+}
+
+func (m *Client) StopCtx(ctx context.Context) (err error) {
+	m.record("StopCtx")
+	if m.StopCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.StopCtxFunc(ctx)
+}
+
+func (m *Client) Stream(ctx context.Context, channelIndex uint32, ratePerSecond, totalCap int64) (r0 *litrpcclient.PaymentStream) {
+	m.record("Stream")
+	if m.StreamFunc == nil {
+		return
+	}
+	return m.StreamFunc(ctx, channelIndex, ratePerSecond, totalCap)
+}
+
+func (m *Client) Supports(method string) (r0 bool, err error) {
+	m.record("Supports")
+	if m.SupportsFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SupportsFunc(method)
+}
+
+func (m *Client) SupportsCtx(ctx context.Context, method string) (r0 bool, err error) {
+	m.record("SupportsCtx")
+	if m.SupportsCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SupportsCtxFunc(ctx, method)
+}
+
+func (m *Client) Sweep(coinType uint32, destAddress string, dryRun bool) (r0 []string, err error) {
+	m.record("Sweep")
+	if m.SweepFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SweepFunc(coinType, destAddress, dryRun)
+}
+
+func (m *Client) SweepCtx(ctx context.Context, coinType uint32, destAddress string, dryRun bool) (r0 []string, err error) {
+	m.record("SweepCtx")
+	if m.SweepCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SweepCtxFunc(ctx, coinType, destAddress, dryRun)
+}
+
+func (m *Client) SweepResult(coinType uint32, destAddress string) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("SweepResult")
+	if m.SweepResultFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SweepResultFunc(coinType, destAddress)
+}
+
+func (m *Client) SweepResultCtx(ctx context.Context, coinType uint32, destAddress string) (r0 *litrpcclient.OperationResult, err error) {
+	m.record("SweepResultCtx")
+	if m.SweepResultCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SweepResultCtxFunc(ctx, coinType, destAddress)
+}
+
+func (m *Client) SyncStatus() (r0 []litrpcclient.CoinSyncStatus, err error) {
+	m.record("SyncStatus")
+	if m.SyncStatusFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SyncStatusFunc()
+}
+
+func (m *Client) SyncStatusCtx(ctx context.Context) (r0 []litrpcclient.CoinSyncStatus, err error) {
+	m.record("SyncStatusCtx")
+	if m.SyncStatusCtxFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.SyncStatusCtxFunc(ctx)
+}
+
+func (m *Client) TakeBreakSnapshot(ctx context.Context, channelIndex uint32, reason string) (r0 *litrpcclient.BreakSnapshot, err error) {
+	m.record("TakeBreakSnapshot")
+	if m.TakeBreakSnapshotFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.TakeBreakSnapshotFunc(ctx, channelIndex, reason)
+}
+
+func (m *Client) UnsupportedMethods() (r0 []string) {
+	m.record("UnsupportedMethods")
+	if m.UnsupportedMethodsFunc == nil {
+		return
+	}
+	return m.UnsupportedMethodsFunc()
+}
+
+func (m *Client) WaitForChannelOpen(ctx context.Context, channelIndex uint32, interval time.Duration) (r0 *litrpcclient.ChannelStatus, err error) {
+	m.record("WaitForChannelOpen")
+	if m.WaitForChannelOpenFunc == nil {
+		err = ErrNotMocked
+		return
+	}
+	return m.WaitForChannelOpenFunc(ctx, channelIndex, interval)
+}
+
+func (m *Client) WatchContracts(ctx context.Context, interval time.Duration) (r0 <-chan litrpcclient.ContractEvent) {
+	m.record("WatchContracts")
+	if m.WatchContractsFunc == nil {
+		return
+	}
+	return m.WatchContractsFunc(ctx, interval)
+}
+
+func (m *Client) WatchHTLCs(ctx context.Context) (r0 <-chan litrpcclient.HTLCEvent) {
+	m.record("WatchHTLCs")
+	if m.WatchHTLCsFunc == nil {
+		return
+	}
+	return m.WatchHTLCsFunc(ctx)
+}
+
+func (m *Client) WatchMessages(ctx context.Context) (r0 <-chan litrpcclient.ChatMessage) {
+	m.record("WatchMessages")
+	if m.WatchMessagesFunc == nil {
+		return
+	}
+	return m.WatchMessagesFunc(ctx)
+}
@@ -0,0 +1,232 @@
+// Package litrpctest provides an in-process fake LIT node that speaks the
+// remote control protocol over lndc, for integration tests of code using
+// litrpcclient. Replies are scripted per RPC method.
+package litrpctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	litrpcclient "github.com/mit-dci/lit-rpc-client-go"
+	"github.com/mit-dci/lit/crypto/koblitz"
+	"github.com/mit-dci/lit/lndc"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// maxMessageSize is the largest message lndc delivers in a single read
+const maxMessageSize = 65535
+
+// Handler produces the reply to a call, from the call's JSON encoded
+// arguments. A returned error is sent to the client as an error from the node.
+type Handler func(args json.RawMessage) (interface{}, error)
+
+// Request is a call received by the server
+type Request struct {
+	Method string
+	// Idx is the index (nonce) the client gave the call
+	Idx  uint64
+	Args json.RawMessage
+}
+
+// Server is a fake LIT node. Methods without handler are answered with the
+// error net/rpc gives for unknown methods, so the client reports them as not
+// supported.
+type Server struct {
+	// Host and Port are the address the server listens on
+	Host string
+	Port int32
+
+	listener *lndc.Listener
+
+	mtx      sync.Mutex
+	handlers map[string]Handler
+	delays   map[string]time.Duration
+	requests []Request
+	conns    map[net.Conn]struct{}
+	closed   bool
+}
+
+// NewServer starts a fake node listening on a random port on localhost
+func NewServer() (*Server, error) {
+	key, err := koblitz.NewPrivateKey(koblitz.S256())
+	if err != nil {
+		return nil, err
+	}
+	listener, err := lndc.NewListener(key, 0)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		Host:     "127.0.0.1",
+		Port:     int32(listener.Addr().(*net.TCPAddr).Port),
+		listener: listener,
+		handlers: make(map[string]Handler),
+		delays:   make(map[string]time.Duration),
+		conns:    make(map[net.Conn]struct{}),
+	}
+	go s.acceptLoop()
+	return s, nil
+}
+
+// Dial connects a client to the server with a new remote control key.
+// [opts] are passed to NewClient after WithRemoteControl.
+func (s *Server) Dial(opts ...litrpcclient.Option) (*litrpcclient.LitRpcClient, error) {
+	key, err := koblitz.NewPrivateKey(koblitz.S256())
+	if err != nil {
+		return nil, err
+	}
+	opts = append([]litrpcclient.Option{litrpcclient.WithRemoteControl(key)}, opts...)
+	return litrpcclient.NewClient(s.Host, s.Port, opts...)
+}
+
+// Handle makes the server answer calls to [method] (like "LitRPC.Balance")
+// with [handler]
+func (s *Server) Handle(method string, handler Handler) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.handlers[method] = handler
+}
+
+// Reply makes the server answer every call to [method] with [reply]
+func (s *Server) Reply(method string, reply interface{}) {
+	s.Handle(method, func(json.RawMessage) (interface{}, error) {
+		return reply, nil
+	})
+}
+
+// Fail makes the server answer every call to [method] with error [message]
+func (s *Server) Fail(method, message string) {
+	s.Handle(method, func(json.RawMessage) (interface{}, error) {
+		return nil, fmt.Errorf("%s", message)
+	})
+}
+
+// Delay makes the server wait [delay] before answering calls to [method], to
+// test timeouts and replies arriving out of order
+func (s *Server) Delay(method string, delay time.Duration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.delays[method] = delay
+}
+
+// Requests returns the calls the server received, in order
+func (s *Server) Requests() []Request {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Disconnect drops all client connections, like a node that restarts
+func (s *Server) Disconnect() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
+
+// Close stops the server and drops all client connections
+func (s *Server) Close() {
+	s.mtx.Lock()
+	s.closed = true
+	s.mtx.Unlock()
+	s.listener.Close()
+	s.Disconnect()
+}
+
+// acceptLoop accepts connections until the listener is closed
+func (s *Server) acceptLoop() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mtx.Lock()
+		if s.closed {
+			s.mtx.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.mtx.Unlock()
+		go s.serve(conn)
+	}
+}
+
+// serve reads requests from [conn] and answers each of them concurrently
+func (s *Server) serve(conn net.Conn) {
+	defer func() {
+		s.mtx.Lock()
+		delete(s.conns, conn)
+		s.mtx.Unlock()
+		conn.Close()
+	}()
+
+	// Like LIT, only answer requests made with the key the connection was
+	// opened with
+	var remotePub [33]byte
+	if lndcConn, ok := conn.(*lndc.Conn); ok {
+		copy(remotePub[:], lndcConn.RemotePub().SerializeCompressed())
+	}
+
+	var writeMtx sync.Mutex
+	buf := make([]byte, maxMessageSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return
+		}
+		if n == 0 || buf[0] != lnutil.MSGID_REMOTE_RPCREQUEST {
+			continue
+		}
+		msg, err := lnutil.NewRemoteControlRpcRequestMsgFromBytes(buf[:n], 0)
+		if err != nil {
+			continue
+		}
+
+		s.mtx.Lock()
+		s.requests = append(s.requests, Request{Method: msg.Method, Idx: msg.Idx, Args: msg.Args})
+		handler := s.handlers[msg.Method]
+		delay := s.delays[msg.Method]
+		s.mtx.Unlock()
+
+		if msg.PubKey != remotePub {
+			handler = unauthorized
+		}
+
+		go func() {
+			time.Sleep(delay)
+			response := s.respond(msg, handler)
+			writeMtx.Lock()
+			conn.Write(response.Bytes())
+			writeMtx.Unlock()
+		}()
+	}
+}
+
+// unauthorized answers requests made with a key other than the connection's
+func unauthorized(json.RawMessage) (interface{}, error) {
+	return nil, fmt.Errorf("Unauthorized")
+}
+
+// respond runs [handler] for [msg] and builds the response message
+func (s *Server) respond(msg lnutil.RemoteControlRpcRequestMsg, handler Handler) lnutil.RemoteControlRpcResponseMsg {
+	response := lnutil.RemoteControlRpcResponseMsg{Idx: msg.Idx}
+	if handler == nil {
+		response.Error = true
+		response.Result = []byte("rpc: can't find method " + msg.Method)
+		return response
+	}
+	reply, err := handler(msg.Args)
+	if err == nil {
+		response.Result, err = json.Marshal(reply)
+	}
+	if err != nil {
+		response.Error = true
+		response.Result = []byte(err.Error())
+	}
+	return response
+}
@@ -0,0 +1,158 @@
+package litrpcclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// readOnlyMethods are the RPC methods that don't change anything on the node
+var readOnlyMethods = map[string]bool{
+	"LitRPC.Balance":                              true,
+	"LitRPC.ChannelList":                          true,
+	"LitRPC.GetContract":                          true,
+	"LitRPC.GetFee":                               true,
+	"LitRPC.GetListeningPorts":                    true,
+	"LitRPC.GetMessages":                          true,
+	"LitRPC.ListConnections":                      true,
+	"LitRPC.ListContracts":                        true,
+	"LitRPC.ListMultihopPayments":                 true,
+	"LitRPC.ListOracles":                          true,
+	"LitRPC.ListPendingRemoteControlAuthRequests": true,
+	"LitRPC.PendingDualFund":                      true,
+	"LitRPC.StateDump":                            true,
+	"LitRPC.TxoList":                              true,
+}
+
+// WithReadOnly makes the client refuse every call that could change state on
+// the node (sending funds, pushing, creating contracts, ...) with ErrReadOnly,
+// without sending it. LIT authorizes remote control keys for all methods, so
+// this is enforced on the client side; combine it with a dedicated key from
+// WithRemoteControl for dashboards, so that key is never used for writes.
+func WithReadOnly() Option {
+	return func(o *clientOptions) {
+		o.readOnly = true
+	}
+}
+
+// checkReadOnly returns ErrReadOnly if the client is read-only and [method]
+// (called with [args]) is not a read
+func (c *LitRpcClient) checkReadOnly(method string, args interface{}) error {
+	if !c.opts.readOnly || readOnlyMethods[method] {
+		return nil
+	}
+	// Address with NumToMake 0 lists existing addresses instead of making new ones
+	if a, ok := args.(*litrpc.AddressArgs); ok && method == "LitRPC.Address" && a.NumToMake == 0 {
+		return nil
+	}
+	return ErrReadOnly
+}
+
+// MirrorSnapshot is a copy of the node's state at the time of a mirror refresh
+type MirrorSnapshot struct {
+	Balances  []litrpc.CoinBalReply
+	Channels  []litrpc.ChannelInfo
+	Contracts []*lnutil.DlcContract
+	// Updated is the time the snapshot was taken
+	Updated time.Time
+}
+
+// Mirror keeps an in-memory copy of the balances, channels and contracts of
+// a node, refreshed every Interval, so dashboards can read them without
+// calling the node. Use it with a client created with WithReadOnly.
+type Mirror struct {
+	// Interval is the time between refreshes
+	Interval time.Duration
+
+	client *LitRpcClient
+
+	mtx      sync.Mutex
+	snapshot *MirrorSnapshot
+	subs     map[chan *MirrorSnapshot]struct{}
+}
+
+// NewMirror creates a Mirror of the node [client] is connected to. Call Run
+// to start mirroring.
+func NewMirror(client *LitRpcClient) *Mirror {
+	return &Mirror{
+		Interval: 10 * time.Second,
+		client:   client,
+		subs:     make(map[chan *MirrorSnapshot]struct{}),
+	}
+}
+
+// Run refreshes the mirror every Interval until [ctx] is cancelled. Failed
+// refreshes keep the previous snapshot, and are retried as set with
+// WithWatchBackoff.
+func (m *Mirror) Run(ctx context.Context) {
+	pollEvery(ctx, m.Interval, m.client.opts.watchBackoff, func() error {
+		return m.Refresh(ctx)
+	})
+}
+
+// Refresh reads the node's state once, updates the mirror and notifies
+// the subscribers.
+func (m *Mirror) Refresh(ctx context.Context) error {
+	c := m.client.subscriptions()
+	balances, err := c.ListBalancesCtx(ctx)
+	if err != nil {
+		return err
+	}
+	channels, err := c.ListChannelsCtx(ctx)
+	if err != nil {
+		return err
+	}
+	contracts, err := c.ListContractsCtx(ctx)
+	if err != nil {
+		return err
+	}
+	snapshot := &MirrorSnapshot{
+		Balances:  balances,
+		Channels:  channels,
+		Contracts: contracts,
+		Updated:   time.Now(),
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.snapshot = snapshot
+	for sub := range m.subs {
+		// Subscribers only need the latest snapshot, replace one they didn't read yet
+		select {
+		case <-sub:
+		default:
+		}
+		sub <- snapshot
+	}
+	return nil
+}
+
+// Snapshot returns the latest snapshot, or nil if the mirror was not
+// refreshed yet. The snapshot must not be modified.
+func (m *Mirror) Snapshot() *MirrorSnapshot {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.snapshot
+}
+
+// Subscribe returns a channel that receives every new snapshot. Snapshots
+// are not queued: a subscriber that is slow to read only gets the latest one.
+// The channel is closed when [ctx] is cancelled.
+func (m *Mirror) Subscribe(ctx context.Context) <-chan *MirrorSnapshot {
+	sub := make(chan *MirrorSnapshot, 1)
+	m.mtx.Lock()
+	m.subs[sub] = struct{}{}
+	m.mtx.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.mtx.Lock()
+		delete(m.subs, sub)
+		close(sub)
+		m.mtx.Unlock()
+	}()
+	return sub
+}
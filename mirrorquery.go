@@ -0,0 +1,150 @@
+package litrpcclient
+
+import (
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// ChannelsBalanceBelow selects the channels in which our balance is less
+// than [amount] satoshi
+func ChannelsBalanceBelow(amount int64) ChannelFilter {
+	return func(ch litrpc.ChannelInfo) bool {
+		return ch.MyBalance < amount
+	}
+}
+
+// ChannelsBalanceAbove selects the channels in which our balance is more
+// than [amount] satoshi
+func ChannelsBalanceAbove(amount int64) ChannelFilter {
+	return func(ch litrpc.ChannelInfo) bool {
+		return ch.MyBalance > amount
+	}
+}
+
+// ChannelsWithPeers selects the channels with any of [peerIndexes]
+func ChannelsWithPeers(peerIndexes ...uint32) ChannelFilter {
+	peers := make(map[uint32]bool, len(peerIndexes))
+	for _, idx := range peerIndexes {
+		peers[idx] = true
+	}
+	return func(ch litrpc.ChannelInfo) bool {
+		return peers[ch.PeerIdx]
+	}
+}
+
+// AnyChannel selects the channels that match at least one of [filters]
+func AnyChannel(filters ...ChannelFilter) ChannelFilter {
+	return func(ch litrpc.ChannelInfo) bool {
+		for _, f := range filters {
+			if f(ch) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NotChannel selects the channels that don't match [filter]
+func NotChannel(filter ChannelFilter) ChannelFilter {
+	return func(ch litrpc.ChannelInfo) bool {
+		return !filter(ch)
+	}
+}
+
+// ContractFilter selects contracts in Mirror.Contracts
+type ContractFilter func(contract *lnutil.DlcContract) bool
+
+// ContractsWithStatus selects the contracts that have any of [statuses]
+func ContractsWithStatus(statuses ...lnutil.DlcContractStatus) ContractFilter {
+	return func(contract *lnutil.DlcContract) bool {
+		for _, status := range statuses {
+			if contract.Status == status {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ContractsWithPeer selects the contracts with peer [peerIndex]
+func ContractsWithPeer(peerIndex uint32) ContractFilter {
+	return func(contract *lnutil.DlcContract) bool {
+		return contract.PeerIdx == peerIndex
+	}
+}
+
+// ContractsSettlingBetween selects the contracts whose settlement time lies
+// in [from, to)
+func ContractsSettlingBetween(from, to time.Time) ContractFilter {
+	return func(contract *lnutil.DlcContract) bool {
+		settles := time.Unix(int64(contract.OracleTimestamp), 0)
+		return !settles.Before(from) && settles.Before(to)
+	}
+}
+
+// AnyContract selects the contracts that match at least one of [filters]
+func AnyContract(filters ...ContractFilter) ContractFilter {
+	return func(contract *lnutil.DlcContract) bool {
+		for _, f := range filters {
+			if f(contract) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Channels returns the channels in the latest snapshot that match all of
+// [filters], or nil if the mirror was not refreshed yet. For example, the
+// open channels with peers 1 or 2 in which we have less than 10000 satoshi:
+//
+//	m.Channels(OpenChannels, ChannelsWithPeers(1, 2), ChannelsBalanceBelow(10000))
+func (m *Mirror) Channels(filters ...ChannelFilter) []litrpc.ChannelInfo {
+	snapshot := m.Snapshot()
+	if snapshot == nil {
+		return nil
+	}
+	return filterChannels(append([]litrpc.ChannelInfo(nil), snapshot.Channels...), filters)
+}
+
+// Contracts returns the contracts in the latest snapshot that match all of
+// [filters], or nil if the mirror was not refreshed yet. For example, the
+// active contracts that settle in the coming week:
+//
+//	m.Contracts(ContractsWithStatus(lnutil.ContractStatusActive),
+//		ContractsSettlingBetween(time.Now(), time.Now().AddDate(0, 0, 7)))
+func (m *Mirror) Contracts(filters ...ContractFilter) []*lnutil.DlcContract {
+	snapshot := m.Snapshot()
+	if snapshot == nil {
+		return nil
+	}
+	var matched []*lnutil.DlcContract
+outer:
+	for _, contract := range snapshot.Contracts {
+		for _, f := range filters {
+			if !f(contract) {
+				continue outer
+			}
+		}
+		matched = append(matched, contract)
+	}
+	return matched
+}
+
+// filterChannels returns the channels that match all of [filters], reusing
+// the backing array of [channels]
+func filterChannels(channels []litrpc.ChannelInfo, filters []ChannelFilter) []litrpc.ChannelInfo {
+	matched := channels[:0]
+outer:
+	for _, ch := range channels {
+		for _, f := range filters {
+			if !f(ch) {
+				continue outer
+			}
+		}
+		matched = append(matched, ch)
+	}
+	return matched
+}
@@ -0,0 +1,395 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/qln"
+)
+
+// multihopPollInterval is the time between checks of a multihop payment's status
+const multihopPollInterval = time.Second
+
+// defaultMultihopTimeout is the time a multihop payment has to succeed, unless
+// set with WithMultihopTimeout
+const defaultMultihopTimeout = 5 * time.Minute
+
+// WithMultihopTimeout sets the time a multihop payment has to succeed before
+// it's considered failed with ErrTimeout, 5 minutes by default. LIT doesn't
+// report multihop payments that fail after they were started, so a payment
+// that doesn't succeed in time is assumed to have failed. A [timeout] of 0
+// means payments only fail when their context is done.
+func WithMultihopTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.multihopTimeout = &timeout
+	}
+}
+
+// PaymentStatus is the status of a multihop payment
+type PaymentStatus int
+
+const (
+	// PaymentPending means the payment is still being routed
+	PaymentPending PaymentStatus = iota
+	// PaymentSucceeded means the destination revealed the preimage
+	PaymentSucceeded
+	// PaymentFailed means the payment could not be completed, see Err()
+	PaymentFailed
+)
+
+func (s PaymentStatus) String() string {
+	switch s {
+	case PaymentSucceeded:
+		return "succeeded"
+	case PaymentFailed:
+		return "failed"
+	}
+	return "pending"
+}
+
+// MultihopPayment is a handle to a multihop payment started with PayMultihopAsync
+type MultihopPayment struct {
+	DestLNAddr string
+	CoinType   uint32
+	Amount     int64
+
+	mtx     sync.Mutex
+	status  PaymentStatus
+	payment *qln.InFlightMultihop
+	err     error
+	done    chan struct{}
+}
+
+// Status returns the current status of the payment
+func (p *MultihopPayment) Status() PaymentStatus {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.status
+}
+
+// Done returns a channel that's closed when the payment succeeded or failed
+func (p *MultihopPayment) Done() <-chan struct{} {
+	return p.done
+}
+
+// Err returns the reason the payment failed, or nil if it did not (yet) fail
+func (p *MultihopPayment) Err() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.err
+}
+
+// Payment returns the node's record of the payment, including the route it took
+// and the preimage once it succeeded. Returns nil until the node reports the payment.
+func (p *MultihopPayment) Payment() *qln.InFlightMultihop {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.payment
+}
+
+// PayMultihopAsync starts a payment of [amount] satoshi of coin type [coinType] to
+// the node with LN address [destLNAddr], routed over intermediate channels. It returns
+// immediately with a handle that tracks the status of the payment. The payment is
+// considered failed when the node refuses it, or when [ctx] is done or the
+// timeout set with WithMultihopTimeout passes before it succeeded.
+func (c *LitRpcClient) PayMultihopAsync(ctx context.Context, destLNAddr string, coinType uint32, amount int64) *MultihopPayment {
+	p := &MultihopPayment{
+		DestLNAddr: destLNAddr,
+		CoinType:   coinType,
+		Amount:     amount,
+		done:       make(chan struct{}),
+	}
+	go c.trackMultihop(ctx, p)
+	return p
+}
+
+// PayMultihop pays [amount] satoshi of coin type [coinType] to the node with
+// LN address [destLNAddr], routed over intermediate channels, and waits until
+// the payment succeeded or failed. It returns the node's record of the payment,
+// which includes the route it took and the preimage. The wait is bounded by
+// the timeout set with WithMultihopTimeout.
+func (c *LitRpcClient) PayMultihop(destLNAddr string, coinType uint32, amount int64) (*qln.InFlightMultihop, error) {
+	return c.PayMultihopCtx(context.Background(), destLNAddr, coinType, amount)
+}
+
+// PayMultihopCtx is like PayMultihop, but uses [ctx] for cancellation and
+// deadlines. It returns as soon as the payment fails, or [ctx] is done; a
+// payment that is still in flight then is reported as failed, although the
+// node may still complete it. With WithMultihopTimeout(0) and no deadline on
+// [ctx], it waits until the payment succeeds or the node refuses it.
+func (c *LitRpcClient) PayMultihopCtx(ctx context.Context, destLNAddr string, coinType uint32, amount int64) (*qln.InFlightMultihop, error) {
+	p := c.PayMultihopAsync(ctx, destLNAddr, coinType, amount)
+	<-p.Done()
+	return p.Payment(), p.Err()
+}
+
+// trackMultihop starts the payment and polls the node's multihop payments until
+// the new payment shows up as succeeded. LIT doesn't return the hash of the
+// payment it started, so the tracker claims the first new payment of the
+// amount to the destination as its own, and follows that payment by hash.
+// Claims are exclusive, so concurrent payments of the same amount each get
+// their own payment.
+func (c *LitRpcClient) trackMultihop(ctx context.Context, p *MultihopPayment) {
+	defer close(p.done)
+
+	timeout := defaultMultihopTimeout
+	if c.opts.multihopTimeout != nil {
+		timeout = *c.opts.multihopTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var hash [32]byte
+	claimed := false
+	c.multihops.wait()
+	defer func() {
+		if claimed {
+			c.multihops.release(hash)
+		} else {
+			c.multihops.stopWaiting()
+		}
+	}()
+
+	before, err := c.listMultihopPayments(ctx)
+	if err != nil {
+		p.finish(PaymentFailed, nil, err)
+		return
+	}
+	known := make(map[[32]byte]bool, len(before))
+	for _, payment := range before {
+		known[payment.HHash] = true
+	}
+	dest, destKnown := lnAddressPKH(p.DestLNAddr)
+	ours := func(payment *qln.InFlightMultihop) bool {
+		if known[payment.HHash] || payment.Amt != p.Amount {
+			return false
+		}
+		return !destKnown || (len(payment.Path) > 0 && payment.Path[len(payment.Path)-1].Node == dest)
+	}
+
+	args := new(litrpc.PayMultihopArgs)
+	args.DestLNAdr = p.DestLNAddr
+	args.CoinType = p.CoinType
+	args.Amt = p.Amount
+	reply := new(litrpc.StatusReply)
+	err = c.callCtx(ctx, "LitRPC.PayMultihop", args, reply)
+	if err != nil {
+		p.finish(PaymentFailed, nil, err)
+		return
+	}
+
+	ticker := time.NewTicker(multihopPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			err := ctx.Err()
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = fmt.Errorf("multihop payment to %s not completed: %w", p.DestLNAddr, ErrTimeout)
+			}
+			p.finish(PaymentFailed, p.Payment(), err)
+			return
+		case <-ticker.C:
+		}
+
+		payments, err := c.listMultihopPayments(ctx)
+		if err != nil {
+			continue
+		}
+		if !claimed {
+			payment := c.multihops.claim(payments, ours)
+			if payment == nil {
+				continue
+			}
+			hash = payment.HHash
+			claimed = true
+		}
+		for _, payment := range payments {
+			if payment.HHash != hash {
+				continue
+			}
+			if payment.Succeeded {
+				p.finish(PaymentSucceeded, payment, nil)
+				return
+			}
+			p.mtx.Lock()
+			p.payment = payment
+			p.mtx.Unlock()
+		}
+	}
+}
+
+// multihopClaims holds the hashes of the multihop payments that trackers
+// took as theirs. The value is false once the tracker is done with the
+// payment. Such a hash is kept while other trackers are waiting to claim a
+// payment, since they could otherwise take the finished payment for theirs,
+// and is removed when none are.
+type multihopClaims struct {
+	mtx     sync.Mutex
+	hashes  map[[32]byte]bool
+	waiting int
+}
+
+// wait registers a tracker that will claim a payment
+func (m *multihopClaims) wait() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.waiting++
+}
+
+// stopWaiting unregisters a tracker that ended without claiming a payment
+func (m *multihopClaims) stopWaiting() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.waiting--
+	m.prune()
+}
+
+// release marks the payment with hash [hash] as no longer tracked
+func (m *multihopClaims) release(hash [32]byte) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.hashes[hash] = false
+	m.prune()
+}
+
+// prune removes the released hashes if no tracker is waiting. Callers must
+// hold m.mtx.
+func (m *multihopClaims) prune() {
+	if m.waiting > 0 {
+		return
+	}
+	for hash, tracked := range m.hashes {
+		if !tracked {
+			delete(m.hashes, hash)
+		}
+	}
+}
+
+// claim returns the first of [payments] that [match]es and isn't claimed
+// yet, and claims it for a tracker registered with wait. It returns nil if
+// there is none.
+func (m *multihopClaims) claim(payments []*qln.InFlightMultihop, match func(*qln.InFlightMultihop) bool) *qln.InFlightMultihop {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, payment := range payments {
+		if _, ok := m.hashes[payment.HHash]; ok || !match(payment) {
+			continue
+		}
+		if m.hashes == nil {
+			m.hashes = make(map[[32]byte]bool)
+		}
+		m.hashes[payment.HHash] = true
+		m.waiting--
+		m.prune()
+		return payment
+	}
+	return nil
+}
+
+// bech32Charset maps the 5 bit values of bech32 to characters
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// lnAddressPKH decodes LN address [address] (bech32 with prefix "ln") to the
+// 20 bytes that identify the node in multihop routes. It returns false if
+// the address isn't valid.
+func lnAddressPKH(address string) ([20]byte, bool) {
+	var pkh [20]byte
+	address = strings.ToLower(address)
+	sep := strings.LastIndex(address, "1")
+	if sep < 1 || len(address)-sep-1 < 6 {
+		return pkh, false
+	}
+	hrp := address[:sep]
+	var values []byte
+	for _, ch := range hrp {
+		values = append(values, byte(ch>>5))
+	}
+	values = append(values, 0)
+	for _, ch := range hrp {
+		values = append(values, byte(ch&31))
+	}
+	var data []byte
+	for _, ch := range address[sep+1:] {
+		v := strings.IndexRune(bech32Charset, ch)
+		if v < 0 {
+			return pkh, false
+		}
+		data = append(data, byte(v))
+	}
+	if bech32Polymod(append(values, data...)) != 1 {
+		return pkh, false
+	}
+
+	// Regroup the 5 bit values without the checksum into bytes
+	var decoded []byte
+	var acc, bits uint
+	for _, v := range data[:len(data)-6] {
+		acc = acc<<5 | uint(v)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			decoded = append(decoded, byte(acc>>bits))
+		}
+	}
+	if len(decoded) != len(pkh) {
+		return pkh, false
+	}
+	copy(pkh[:], decoded)
+	return pkh, true
+}
+
+// bech32Polymod computes the bech32 checksum of [values]
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// finish sets the final status of the payment
+func (p *MultihopPayment) finish(status PaymentStatus, payment *qln.InFlightMultihop, err error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.status = status
+	p.payment = payment
+	p.err = err
+}
+
+// ListMultihopPayments returns the multihop payments known to the node, both
+// the ones it sent and the ones it forwarded or received
+func (c *LitRpcClient) ListMultihopPayments() ([]*qln.InFlightMultihop, error) {
+	return c.ListMultihopPaymentsCtx(context.Background())
+}
+
+// ListMultihopPaymentsCtx is like ListMultihopPayments, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListMultihopPaymentsCtx(ctx context.Context) ([]*qln.InFlightMultihop, error) {
+	return c.listMultihopPayments(ctx)
+}
+
+// listMultihopPayments returns the multihop payments known to the node
+func (c *LitRpcClient) listMultihopPayments(ctx context.Context) ([]*qln.InFlightMultihop, error) {
+	args := new(litrpc.NoArgs)
+	reply := new(litrpc.MultihopPaymentsReply)
+	err := c.subscriptions().callCtx(ctx, "LitRPC.ListMultihopPayments", args, reply)
+	if err != nil {
+		return nil, err
+	}
+	return reply.Payments, nil
+}
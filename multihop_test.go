@@ -0,0 +1,184 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/lnutil"
+	"github.com/mit-dci/lit/qln"
+)
+
+// lnAddress encodes [pkh] as an LN address
+func lnAddress(pkh [20]byte) string {
+	var data []byte
+	var acc, bits uint
+	for _, b := range pkh {
+		acc = acc<<8 | uint(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			data = append(data, byte(acc>>bits&31))
+		}
+	}
+	if bits > 0 {
+		data = append(data, byte(acc<<(5-bits)&31))
+	}
+	values := append([]byte{3, 3, 0, 12, 14}, data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ 1
+	for i := 0; i < 6; i++ {
+		data = append(data, byte(polymod>>uint(5*(5-i))&31))
+	}
+	address := "ln1"
+	for _, v := range data {
+		address += string(bech32Charset[v])
+	}
+	return address
+}
+
+// multihopNode is a fake node that records multihop payments
+type multihopNode struct {
+	*fakeNode
+	mtx      sync.Mutex
+	payments []*qln.InFlightMultihop
+}
+
+func newMultihopNode() *multihopNode {
+	n := &multihopNode{fakeNode: newFakeNode()}
+	n.handle("LitRPC.PayMultihop", func(args interface{}) (interface{}, error) {
+		a := args.(*litrpc.PayMultihopArgs)
+		dest, ok := lnAddressPKH(a.DestLNAdr)
+		if !ok {
+			return nil, errors.New("invalid address")
+		}
+		n.mtx.Lock()
+		defer n.mtx.Unlock()
+		payment := &qln.InFlightMultihop{Amt: a.Amt, Path: []lnutil.RouteHop{{}, {Node: dest}}}
+		payment.HHash[0] = byte(len(n.payments) + 1)
+		n.payments = append(n.payments, payment)
+		return litrpc.StatusReply{}, nil
+	})
+	n.handle("LitRPC.ListMultihopPayments", func(interface{}) (interface{}, error) {
+		n.mtx.Lock()
+		defer n.mtx.Unlock()
+		var payments []qln.InFlightMultihop
+		for _, p := range n.payments {
+			payments = append(payments, *p)
+		}
+		return litrpc.MultihopPaymentsReply{Payments: toPointers(payments)}, nil
+	})
+	return n
+}
+
+func toPointers(payments []qln.InFlightMultihop) []*qln.InFlightMultihop {
+	ptrs := make([]*qln.InFlightMultihop, len(payments))
+	for i := range payments {
+		ptrs[i] = &payments[i]
+	}
+	return ptrs
+}
+
+// succeed marks the payment to [dest] as succeeded, once the node has it
+func (n *multihopNode) succeed(dest [20]byte) {
+	for {
+		n.mtx.Lock()
+		for _, p := range n.payments {
+			if p.Path[len(p.Path)-1].Node == dest {
+				p.Succeeded = true
+				n.mtx.Unlock()
+				return
+			}
+		}
+		n.mtx.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMultihopConcurrentPaymentsOfSameAmount(t *testing.T) {
+	node := newMultihopNode()
+	client := newTestClient(node.fakeNode)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	destA, destB := [20]byte{1}, [20]byte{2}
+	a := client.PayMultihopAsync(ctx, lnAddress(destA), 1, 1000)
+	b := client.PayMultihopAsync(ctx, lnAddress(destB), 1, 1000)
+
+	node.succeed(destB)
+	<-b.Done()
+	if b.Status() != PaymentSucceeded {
+		t.Fatalf("payment to B: %s (%v)", b.Status(), b.Err())
+	}
+	if a.Status() != PaymentPending {
+		t.Fatalf("payment to A is %s, want pending", a.Status())
+	}
+	if a.Payment() != nil && a.Payment().HHash == b.Payment().HHash {
+		t.Fatal("both handles follow the same payment")
+	}
+
+	node.succeed(destA)
+	<-a.Done()
+	if a.Status() != PaymentSucceeded || a.Payment().Path[1].Node != destA {
+		t.Fatalf("payment to A: %s (%v)", a.Status(), a.Err())
+	}
+	if n := client.multihops.tracked(); n != 0 {
+		t.Fatalf("%d payment hashes kept after the payments finished", n)
+	}
+}
+
+// tracked returns the number of payment hashes the claims hold
+func (m *multihopClaims) tracked() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return len(m.hashes)
+}
+
+func TestMultihopTimeout(t *testing.T) {
+	node := newMultihopNode()
+	client := newTestClient(node.fakeNode, WithMultihopTimeout(1500*time.Millisecond))
+
+	_, err := client.PayMultihopCtx(context.Background(), lnAddress([20]byte{1}), 1, 1000)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("got %v, want ErrTimeout", err)
+	}
+	if n := client.multihops.tracked(); n != 0 {
+		t.Fatalf("%d payment hashes kept after the payment timed out", n)
+	}
+}
+
+func TestPayMultihopReturnsOnFailure(t *testing.T) {
+	node := newMultihopNode()
+	node.handle("LitRPC.PayMultihop", func(interface{}) (interface{}, error) {
+		return nil, errors.New("no route to destination")
+	})
+	client := newTestClient(node.fakeNode, WithMultihopTimeout(0))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.PayMultihopCtx(context.Background(), lnAddress([20]byte{1}), 1, 1000)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrRemote) {
+			t.Fatalf("got %v, want ErrRemote", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("PayMultihopCtx didn't return after the node refused the payment")
+	}
+}
+
+func TestPayMultihopReturnsWhenContextDone(t *testing.T) {
+	node := newMultihopNode()
+	client := newTestClient(node.fakeNode, WithMultihopTimeout(0))
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+
+	_, err := client.PayMultihopCtx(ctx, lnAddress([20]byte{1}), 1, 1000)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("got %v, want ErrTimeout", err)
+	}
+}
@@ -0,0 +1,64 @@
+package litrpcclient
+
+import (
+	"context"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+// CoinConfig is the configuration of a single coin type the node is connected to
+type CoinConfig struct {
+	CoinType   uint32
+	FeePerByte int64
+	SyncHeight int32
+}
+
+// NodeConfig is a snapshot of the node's settings, as far as they are exposed over RPC
+type NodeConfig struct {
+	// LNAddress is the node's LN address
+	LNAddress string
+	// ListeningPorts are the addresses the node listens on for peer connections.
+	// Empty if the node is not listening.
+	ListeningPorts []string
+	// Coins contains an entry per coin type the node has a wallet (and thus a
+	// connected coin daemon) for
+	Coins []CoinConfig
+}
+
+// GetNodeConfig returns a snapshot of the node's settings, so automation can
+// check the node is configured as expected before operating on it. Settings
+// the node does not expose over RPC (like the tracker URL) are not included.
+func (c *LitRpcClient) GetNodeConfig() (*NodeConfig, error) {
+	return c.GetNodeConfigCtx(context.Background())
+}
+
+// GetNodeConfigCtx is like GetNodeConfig, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) GetNodeConfigCtx(ctx context.Context) (*NodeConfig, error) {
+	args := new(litrpc.NoArgs)
+	reply := new(litrpc.ListeningPortsReply)
+	err := c.callCtx(ctx, "LitRPC.GetListeningPorts", args, reply)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := new(NodeConfig)
+	cfg.LNAddress = reply.Adr
+	cfg.ListeningPorts = reply.LisIpPorts
+
+	balances, err := c.ListBalancesCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, bal := range balances {
+		fee, err := c.GetFeeCtx(ctx, bal.CoinType)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Coins = append(cfg.Coins, CoinConfig{
+			CoinType:   bal.CoinType,
+			FeePerByte: fee,
+			SyncHeight: bal.SyncHeight,
+		})
+	}
+	return cfg, nil
+}
@@ -0,0 +1,83 @@
+package litrpcclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/qln"
+)
+
+// NodeSnapshot is the state of a node, read with GetNodeSnapshot
+type NodeSnapshot struct {
+	Balances    []litrpc.CoinBalReply
+	Channels    []litrpc.ChannelInfo
+	Connections []qln.PeerInfo
+	Utxos       []litrpc.TxoInfo
+	Listening   bool
+	// Time is the time the reads were started
+	Time time.Time
+}
+
+// GetNodeSnapshot reads the node's balances, channels, connections, UTXOs
+// and listening status with concurrent calls, and returns them together.
+// LIT can't read them atomically, so a payment or block that arrives while
+// the calls are in flight can show up in some parts and not in others;
+// the reads are as close together as the node allows. It returns the first
+// error if any call fails.
+func (c *LitRpcClient) GetNodeSnapshot() (*NodeSnapshot, error) {
+	return c.GetNodeSnapshotCtx(context.Background())
+}
+
+// GetNodeSnapshotCtx is like GetNodeSnapshot, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) GetNodeSnapshotCtx(ctx context.Context) (*NodeSnapshot, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	snapshot := &NodeSnapshot{Time: time.Now()}
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	read := func(f func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := f()
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					// The snapshot fails anyway, don't wait for the other calls
+					cancel()
+				})
+			}
+		}()
+	}
+
+	read(func() (err error) {
+		snapshot.Balances, err = c.ListBalancesCtx(ctx)
+		return err
+	})
+	read(func() (err error) {
+		snapshot.Channels, err = c.ListChannelsCtx(ctx)
+		return err
+	})
+	read(func() (err error) {
+		snapshot.Connections, err = c.ListConnectionsCtx(ctx)
+		return err
+	})
+	read(func() (err error) {
+		snapshot.Utxos, err = c.ListUtxosCtx(ctx)
+		return err
+	})
+	read(func() (err error) {
+		snapshot.Listening, err = c.IsListeningCtx(ctx)
+		return err
+	})
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return snapshot, nil
+}
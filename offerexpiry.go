@@ -0,0 +1,167 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// ExpiredOffer is a contract we offered that the peer didn't accept in time
+type ExpiredOffer struct {
+	Contract *lnutil.DlcContract
+	// Offered is the time the offer was first seen
+	Offered time.Time
+	// Declined is true if the node declined the offer. LIT only lets the
+	// receiving side decline an offer, so this is normally false and the
+	// offer is only marked stale.
+	Declined bool
+}
+
+// expiringOffer is an outgoing offer tracked by OfferExpiry
+type expiringOffer struct {
+	Offered time.Time `json:"offered"`
+	Stale   bool      `json:"stale,omitempty"`
+}
+
+// OfferExpiry watches the contracts we offered, and expires the offers that
+// the peer did not accept within Expiry: it tries to decline them and marks
+// them stale, so applications can stop counting on the funds the offer
+// reserves. LIT doesn't record when a contract was offered, so offers are
+// timed from when OfferExpiry first sees them; the times are persisted so
+// they survive restarts.
+type OfferExpiry struct {
+	// Interval is the time between checks of the contracts
+	Interval time.Duration
+	// Expiry is the time after which an offer expires
+	Expiry time.Duration
+	// OnExpired, if set, is called once for every offer that expires
+	OnExpired func(ExpiredOffer)
+
+	client *LitRpcClient
+	path   string
+
+	mtx    sync.Mutex
+	offers map[uint64]*expiringOffer
+}
+
+// NewOfferExpiry creates an OfferExpiry for the offers of [client] that
+// expires them after [expiry], persisting the offer times to the file at
+// [path]. Previously persisted offers are loaded from it.
+func NewOfferExpiry(client *LitRpcClient, path string, expiry time.Duration) (*OfferExpiry, error) {
+	e := &OfferExpiry{
+		Interval: time.Minute,
+		Expiry:   expiry,
+		client:   client,
+		path:     path,
+		offers:   make(map[uint64]*expiringOffer),
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return e, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, &e.offers)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Stale returns true if the offer of contract [contractIndex] expired
+func (e *OfferExpiry) Stale(contractIndex uint64) bool {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	o, ok := e.offers[contractIndex]
+	return ok && o.Stale
+}
+
+// StaleOffers returns the indexes of the contracts whose offer expired, sorted
+func (e *OfferExpiry) StaleOffers() []uint64 {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	var stale []uint64
+	for idx, o := range e.offers {
+		if o.Stale {
+			stale = append(stale, idx)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i] < stale[j] })
+	return stale
+}
+
+// Run checks the offers every Interval until [ctx] is cancelled. Errors are
+// passed to the handler set with WithAsyncErrorHandler, and failed checks are
+// retried as set with WithWatchBackoff.
+func (e *OfferExpiry) Run(ctx context.Context) {
+	pollEvery(ctx, e.Interval, e.client.opts.watchBackoff, func() error {
+		err := e.check(ctx)
+		if err != nil {
+			e.client.asyncError(err)
+		}
+		return err
+	})
+}
+
+// check starts tracking new offers, expires old ones and forgets the ones
+// that were answered
+func (e *OfferExpiry) check(ctx context.Context) error {
+	contracts, err := e.client.ListContractsCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	var expired []ExpiredOffer
+	e.mtx.Lock()
+	offered := make(map[uint64]bool)
+	for _, c := range contracts {
+		if c.Status != lnutil.ContractStatusOfferedByMe {
+			continue
+		}
+		offered[c.Idx] = true
+		o, ok := e.offers[c.Idx]
+		if !ok {
+			e.offers[c.Idx] = &expiringOffer{Offered: time.Now()}
+			continue
+		}
+		if !o.Stale && time.Since(o.Offered) > e.Expiry {
+			o.Stale = true
+			expired = append(expired, ExpiredOffer{Contract: c, Offered: o.Offered})
+		}
+	}
+	for idx := range e.offers {
+		if !offered[idx] {
+			delete(e.offers, idx)
+		}
+	}
+	err = e.save()
+	e.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, exp := range expired {
+		exp.Declined = e.client.DeclineContractCtx(ctx, exp.Contract.Idx) == nil
+		if e.OnExpired != nil {
+			e.OnExpired(exp)
+		}
+	}
+	return nil
+}
+
+// save persists the offers. Callers must hold e.mtx.
+func (e *OfferExpiry) save() error {
+	b, err := json.MarshalIndent(e.offers, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(e.path, b, 0600)
+}
@@ -0,0 +1,80 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// ErrNotOffered is returned by MatchOffer when the incoming contract is not
+// an offer to us
+var ErrNotOffered = errors.New("contract is not an offer to us")
+
+// OfferMismatchError is returned by MatchOffer when an incoming offer differs
+// from the expected draft
+type OfferMismatchError struct {
+	// Field is the name of the first contract field that differs
+	Field string
+}
+
+func (e *OfferMismatchError) Error() string {
+	return fmt.Sprintf("Offer does not match draft: %s differs", e.Field)
+}
+
+// MatchOffer verifies that the contract with id [incomingIdx], offered to us by
+// a peer, has exactly the same terms as our local draft contract with id
+// [localDraftIdx]. Both contracts have to agree on every field, seen from our
+// side; an offer that matches the draft with the roles swapped is rejected. Returns
+// nil if the offer matches, ErrNotOffered if [incomingIdx] is not an offer to us,
+// or an *OfferMismatchError naming the first field that differs.
+func (c *LitRpcClient) MatchOffer(incomingIdx, localDraftIdx uint64) error {
+	return c.MatchOfferCtx(context.Background(), incomingIdx, localDraftIdx)
+}
+
+// MatchOfferCtx is like MatchOffer, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) MatchOfferCtx(ctx context.Context, incomingIdx, localDraftIdx uint64) error {
+	incoming, err := c.GetContractCtx(ctx, incomingIdx)
+	if err != nil {
+		return err
+	}
+	draft, err := c.GetContractCtx(ctx, localDraftIdx)
+	if err != nil {
+		return err
+	}
+	if incoming.Status != lnutil.ContractStatusOfferedToMe {
+		return fmt.Errorf("contract %d: %w", incomingIdx, ErrNotOffered)
+	}
+	if field := contractMismatch(incoming, draft); field != "" {
+		return &OfferMismatchError{Field: field}
+	}
+	return nil
+}
+
+// contractMismatch returns the name of the first field in which the terms of
+// contracts [a] and [b] differ, or an empty string if they don't
+func contractMismatch(a, b *lnutil.DlcContract) string {
+	switch {
+	case a.CoinType != b.CoinType:
+		return "CoinType"
+	case a.OracleA != b.OracleA:
+		return "OracleA"
+	case a.OracleR != b.OracleR:
+		return "OracleR"
+	case a.OracleTimestamp != b.OracleTimestamp:
+		return "OracleTimestamp"
+	case a.OurFundingAmount != b.OurFundingAmount:
+		return "OurFundingAmount"
+	case a.TheirFundingAmount != b.TheirFundingAmount:
+		return "TheirFundingAmount"
+	case len(a.Division) != len(b.Division):
+		return "Division"
+	}
+	for i := range a.Division {
+		if a.Division[i] != b.Division[i] {
+			return fmt.Sprintf("Division[%d]", i)
+		}
+	}
+	return ""
+}
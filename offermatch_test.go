@@ -0,0 +1,29 @@
+package litrpcclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+func TestMatchOfferNotOffered(t *testing.T) {
+	node := newFakeNode()
+	node.handle("LitRPC.GetContract", func(args interface{}) (interface{}, error) {
+		idx := args.(*litrpc.GetContractArgs).Idx
+		status := lnutil.ContractStatusDraft
+		if idx == 1 {
+			status = lnutil.ContractStatusOfferedToMe
+		}
+		return litrpc.GetContractReply{Contract: &lnutil.DlcContract{Idx: idx, Status: status}}, nil
+	})
+	c := newTestClient(node)
+
+	if err := c.MatchOffer(1, 2); err != nil {
+		t.Fatalf("matching offer: %v", err)
+	}
+	if err := c.MatchOffer(3, 2); !errors.Is(err, ErrNotOffered) {
+		t.Fatalf("got %v, want ErrNotOffered", err)
+	}
+}
@@ -0,0 +1,141 @@
+// Package oracle is a client for the REST API of dlcoracle compatible
+// oracles, the API LIT imports oracles from. Applications can use it to fetch
+// the value and signature an oracle published for a contract, and settle the
+// contract with them:
+//
+//	pub, err := oracle.NewClient(url).Publication(ctx, contract.OracleR)
+//	...
+//	err = client.SettleContract(contract.Idx, pub.Value, pub.Signature[:])
+package oracle
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrNotPublished is returned by Publication when the oracle did not publish
+// a value for the R-point (yet)
+var ErrNotPublished = errors.New("oracle did not publish a value")
+
+// Datasource is a data feed an oracle publishes values for
+type Datasource struct {
+	Id          uint64 `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Value is the current value of the feed
+	Value int64 `json:"value"`
+}
+
+// Publication is a value an oracle published, with its signature
+type Publication struct {
+	Value     int64
+	Signature [32]byte
+}
+
+// Client talks to an oracle's REST API
+type Client struct {
+	// URL is the base URL of the oracle, as passed to ImportOracle
+	URL string
+	// HTTPClient is used for requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Client for the oracle at [url]
+func NewClient(url string) *Client {
+	return &Client{URL: strings.TrimSuffix(url, "/")}
+}
+
+// PubKey returns the public key (A) of the oracle
+func (c *Client) PubKey(ctx context.Context) ([33]byte, error) {
+	var reply struct {
+		A string
+	}
+	err := c.get(ctx, "/api/pubkey", &reply)
+	if err != nil {
+		return [33]byte{}, err
+	}
+	return decodePoint(reply.A)
+}
+
+// Datasources returns the data feeds of the oracle
+func (c *Client) Datasources(ctx context.Context) ([]Datasource, error) {
+	var datasources []Datasource
+	err := c.get(ctx, "/api/datasources", &datasources)
+	if err != nil {
+		return nil, err
+	}
+	return datasources, nil
+}
+
+// RPoint returns the R-point the oracle commits to for the value of
+// datasource [datasourceID] at unix time [timestamp]
+func (c *Client) RPoint(ctx context.Context, datasourceID, timestamp uint64) ([33]byte, error) {
+	var reply struct {
+		R string
+	}
+	err := c.get(ctx, fmt.Sprintf("/api/rpoint/%d/%d", datasourceID, timestamp), &reply)
+	if err != nil {
+		return [33]byte{}, err
+	}
+	return decodePoint(reply.R)
+}
+
+// Publication returns the value the oracle published for R-point [rPoint],
+// and its signature. Returns ErrNotPublished if there is none yet.
+func (c *Client) Publication(ctx context.Context, rPoint [33]byte) (*Publication, error) {
+	var reply struct {
+		Value     int64  `json:"value"`
+		Signature string `json:"signature"`
+	}
+	err := c.get(ctx, "/api/publication/"+hex.EncodeToString(rPoint[:]), &reply)
+	if err != nil {
+		return nil, err
+	}
+	pub := &Publication{Value: reply.Value}
+	sig, err := hex.DecodeString(reply.Signature)
+	if err != nil || len(sig) != len(pub.Signature) {
+		return nil, fmt.Errorf("oracle returned invalid signature %q", reply.Signature)
+	}
+	copy(pub.Signature[:], sig)
+	return pub, nil
+}
+
+// get fetches [path] from the oracle and decodes the JSON response into [v]
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequest("GET", c.URL+path, nil)
+	if err != nil {
+		return err
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound && strings.HasPrefix(path, "/api/publication/") {
+		return ErrNotPublished
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s%s: %s", c.URL, path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// decodePoint decodes a hex encoded compressed point
+func decodePoint(s string) ([33]byte, error) {
+	var point [33]byte
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(point) {
+		return point, fmt.Errorf("oracle returned invalid point %q", s)
+	}
+	copy(point[:], b)
+	return point, nil
+}
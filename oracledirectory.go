@@ -0,0 +1,169 @@
+package litrpcclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mit-dci/lit/crypto/koblitz"
+	"github.com/mit-dci/lit/dlc"
+)
+
+// ErrBadDirectorySignature is returned by ImportOraclesFromDirectory when the
+// directory's signature doesn't verify
+var ErrBadDirectorySignature = errors.New("oracle directory signature invalid")
+
+// DirectoryImportStatus describes what happened to an oracle listed in a directory
+type DirectoryImportStatus int
+
+const (
+	// DirectoryOracleAdded means the oracle was added to the node
+	DirectoryOracleAdded DirectoryImportStatus = iota
+	// DirectoryOracleKnown means the node already knew the oracle under the same name
+	DirectoryOracleKnown
+	// DirectoryOracleConflict means the node already knew the oracle's public key
+	// under a different name. The oracle is not changed.
+	DirectoryOracleConflict
+	// DirectoryOracleInvalid means the entry has no valid public key
+	DirectoryOracleInvalid
+	// DirectoryOracleFailed means adding the oracle failed, see Err
+	DirectoryOracleFailed
+)
+
+func (s DirectoryImportStatus) String() string {
+	switch s {
+	case DirectoryOracleAdded:
+		return "added"
+	case DirectoryOracleKnown:
+		return "known"
+	case DirectoryOracleConflict:
+		return "conflict"
+	case DirectoryOracleInvalid:
+		return "invalid"
+	}
+	return "failed"
+}
+
+// DirectoryEntry is an oracle listed in an oracle directory
+type DirectoryEntry struct {
+	Name   string `json:"name"`
+	PubKey string `json:"pubKey"`
+	Url    string `json:"url,omitempty"`
+}
+
+// DirectoryImportResult is the outcome of importing a single directory entry
+type DirectoryImportResult struct {
+	Entry  DirectoryEntry
+	Status DirectoryImportStatus
+	// Oracle is the node's oracle for the entry, if it has one
+	Oracle *dlc.DlcOracle
+	// Err is the reason adding the oracle failed, for DirectoryOracleFailed
+	Err error
+}
+
+// oracleDirectory is the document served by an oracle directory. Signature is
+// a DER encoded signature by the directory's key over the SHA256 hash of the
+// Oracles field, exactly as served.
+type oracleDirectory struct {
+	Oracles   json.RawMessage `json:"oracles"`
+	Signature string          `json:"signature"`
+}
+
+// ImportOraclesFromDirectory fetches the list of oracles from the directory at
+// [url], verifies it is signed by [directoryKey], and adds every listed oracle
+// the node doesn't know yet with AddOracle. Oracles whose public key the node
+// already knows are left alone. Returns the outcome for every entry, or
+// ErrBadDirectorySignature if the list isn't signed by [directoryKey].
+func (c *LitRpcClient) ImportOraclesFromDirectory(url string, directoryKey *koblitz.PublicKey) ([]DirectoryImportResult, error) {
+	return c.ImportOraclesFromDirectoryCtx(context.Background(), url, directoryKey)
+}
+
+// ImportOraclesFromDirectoryCtx is like ImportOraclesFromDirectory, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ImportOraclesFromDirectoryCtx(ctx context.Context, url string, directoryKey *koblitz.PublicKey) ([]DirectoryImportResult, error) {
+	var dir oracleDirectory
+	err := getJSON(ctx, url, &dir)
+	if err != nil {
+		return nil, err
+	}
+	sigBytes, err := hex.DecodeString(dir.Signature)
+	if err != nil {
+		return nil, ErrBadDirectorySignature
+	}
+	sig, err := koblitz.ParseDERSignature(sigBytes, koblitz.S256())
+	if err != nil {
+		return nil, ErrBadDirectorySignature
+	}
+	hash := sha256.Sum256(dir.Oracles)
+	if !sig.Verify(hash[:], directoryKey) {
+		return nil, ErrBadDirectorySignature
+	}
+	var entries []DirectoryEntry
+	err = json.Unmarshal(dir.Oracles, &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	oracles, err := c.ListOraclesCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[[33]byte]*dlc.DlcOracle, len(oracles))
+	for _, o := range oracles {
+		known[o.A] = o
+	}
+
+	results := make([]DirectoryImportResult, len(entries))
+	for i, entry := range entries {
+		results[i].Entry = entry
+		var pub [33]byte
+		b, err := hex.DecodeString(entry.PubKey)
+		if err == nil && len(b) == len(pub) {
+			_, err = koblitz.ParsePubKey(b, koblitz.S256())
+		}
+		if err != nil || len(b) != len(pub) {
+			results[i].Status = DirectoryOracleInvalid
+			continue
+		}
+		copy(pub[:], b)
+
+		if o, ok := known[pub]; ok {
+			results[i].Oracle = o
+			results[i].Status = DirectoryOracleKnown
+			if o.Name != entry.Name {
+				results[i].Status = DirectoryOracleConflict
+			}
+			continue
+		}
+		o, err := c.AddOracleCtx(ctx, entry.PubKey, entry.Name)
+		if err != nil {
+			results[i].Status = DirectoryOracleFailed
+			results[i].Err = err
+			continue
+		}
+		results[i].Oracle = o
+		results[i].Status = DirectoryOracleAdded
+		known[pub] = o
+	}
+	return results, nil
+}
+
+// getJSON fetches [url] and decodes the JSON response into [v]
+func getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
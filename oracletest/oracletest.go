@@ -0,0 +1,243 @@
+// Package oracletest provides an in-process oracle that serves the same REST
+// API as dlcoracle, so the full contract lifecycle (NewContract, Offer, Accept,
+// Settle) can be tested without external services.
+package oracletest
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mit-dci/lit/crypto/koblitz"
+)
+
+// Datasource is a data feed the oracle publishes values for
+type Datasource struct {
+	Id          uint64 `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Value       int64  `json:"value"`
+}
+
+// event is a (datasource, timestamp) the oracle committed to an R-point for
+type event struct {
+	datasource uint64
+	timestamp  uint64
+}
+
+// publication is a value the oracle signed for an R-point
+type publication struct {
+	value     int64
+	signature [32]byte
+}
+
+// Oracle is an in-process oracle. Create one with New.
+type Oracle struct {
+	// URL is the base URL of the oracle's REST API, to pass to ImportOracle
+	URL string
+
+	server *httptest.Server
+	priv   *big.Int
+	pub    [33]byte
+
+	mtx          sync.Mutex
+	datasources  []Datasource
+	keys         map[event]*big.Int
+	rpoints      map[event][33]byte
+	publications map[[33]byte]publication
+}
+
+// New starts an oracle with a random key and a single datasource (id 1)
+func New() (*Oracle, error) {
+	o := &Oracle{
+		datasources:  []Datasource{{Id: 1, Name: "Test", Description: "Test datasource"}},
+		keys:         make(map[event]*big.Int),
+		rpoints:      make(map[event][33]byte),
+		publications: make(map[[33]byte]publication),
+	}
+	var err error
+	o.priv, err = randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	o.pub = pubKey(o.priv)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/pubkey", o.handlePubKey)
+	mux.HandleFunc("/api/datasources", o.handleDatasources)
+	mux.HandleFunc("/api/rpoint/", o.handleRPoint)
+	mux.HandleFunc("/api/publication/", o.handlePublication)
+	o.server = httptest.NewServer(mux)
+	o.URL = o.server.URL
+	return o, nil
+}
+
+// Close stops the oracle's HTTP server
+func (o *Oracle) Close() {
+	o.server.Close()
+}
+
+// PubKey returns the oracle's public key (A)
+func (o *Oracle) PubKey() [33]byte {
+	return o.pub
+}
+
+// RPoint returns the R-point the oracle will use to sign the value of
+// [datasource] at [timestamp], generating it on first use
+func (o *Oracle) RPoint(datasource, timestamp uint64) ([33]byte, error) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	ev := event{datasource, timestamp}
+	if r, ok := o.rpoints[ev]; ok {
+		return r, nil
+	}
+	k, err := randomScalar()
+	if err != nil {
+		return [33]byte{}, err
+	}
+	o.keys[ev] = k
+	o.rpoints[ev] = pubKey(k)
+	return o.rpoints[ev], nil
+}
+
+// Publish signs [value] as the value of [datasource] at [timestamp], and
+// returns the signature to settle contracts with
+func (o *Oracle) Publish(datasource, timestamp uint64, value int64) ([32]byte, error) {
+	r, err := o.RPoint(datasource, timestamp)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	k := o.keys[event{datasource, timestamp}]
+
+	// s = k - h(m, R) * a
+	e, err := messageHash(value, r)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	s := new(big.Int).Mul(e, o.priv)
+	s.Sub(k, s)
+	s.Mod(s, koblitz.S256().Params().N)
+	if s.Sign() == 0 {
+		return [32]byte{}, fmt.Errorf("signature for value %d is zero", value)
+	}
+
+	var sig [32]byte
+	sBytes := s.Bytes()
+	copy(sig[32-len(sBytes):], sBytes)
+	o.publications[r] = publication{value, sig}
+	for i := range o.datasources {
+		if o.datasources[i].Id == datasource {
+			o.datasources[i].Value = value
+		}
+	}
+	return sig, nil
+}
+
+// messageHash computes h(m, R) the way LIT does when it checks the signature:
+// sha256 of m as 32 byte big endian, followed by the x coordinate of R without
+// leading zeros. LIT doesn't reduce the hash, but rejects hashes of N or more.
+func messageHash(value int64, r [33]byte) (*big.Int, error) {
+	message := make([]byte, 32)
+	binary.BigEndian.PutUint64(message[24:], uint64(value))
+	rx := new(big.Int).SetBytes(r[1:])
+	hash := sha256.Sum256(append(message, rx.Bytes()...))
+	e := new(big.Int).SetBytes(hash[:])
+	if e.Cmp(koblitz.S256().Params().N) >= 0 {
+		return nil, fmt.Errorf("hash of value %d and R-point is too big", value)
+	}
+	return e, nil
+}
+
+// randomScalar returns a random private key
+func randomScalar() (*big.Int, error) {
+	n := koblitz.S256().Params().N
+	k, err := rand.Int(rand.Reader, new(big.Int).Sub(n, big.NewInt(1)))
+	if err != nil {
+		return nil, err
+	}
+	return k.Add(k, big.NewInt(1)), nil
+}
+
+// pubKey returns the compressed public key for private key [k]
+func pubKey(k *big.Int) [33]byte {
+	x, y := koblitz.S256().ScalarBaseMult(k.Bytes())
+	var pub [33]byte
+	pub[0] = 0x02 + byte(y.Bit(0))
+	xBytes := x.Bytes()
+	copy(pub[33-len(xBytes):], xBytes)
+	return pub
+}
+
+func (o *Oracle) handlePubKey(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]string{"A": hex.EncodeToString(o.pub[:])})
+}
+
+func (o *Oracle) handleDatasources(w http.ResponseWriter, r *http.Request) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	writeJSON(w, o.datasources)
+}
+
+// handleRPoint serves /api/rpoint/{datasource}/{timestamp}
+func (o *Oracle) handleRPoint(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/rpoint/"), "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	datasource, err1 := strconv.ParseUint(parts[0], 10, 64)
+	timestamp, err2 := strconv.ParseUint(parts[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		http.Error(w, "invalid datasource or timestamp", http.StatusBadRequest)
+		return
+	}
+	rpoint, err := o.RPoint(datasource, timestamp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]string{"R": hex.EncodeToString(rpoint[:])})
+}
+
+// handlePublication serves /api/publication/{R}
+func (o *Oracle) handlePublication(w http.ResponseWriter, r *http.Request) {
+	rBytes, err := hex.DecodeString(strings.TrimPrefix(r.URL.Path, "/api/publication/"))
+	if err != nil || len(rBytes) != 33 {
+		http.Error(w, "invalid R-point", http.StatusBadRequest)
+		return
+	}
+	var rpoint [33]byte
+	copy(rpoint[:], rBytes)
+
+	o.mtx.Lock()
+	pub, ok := o.publications[rpoint]
+	o.mtx.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"value":     pub.value,
+		"signature": hex.EncodeToString(pub.signature[:]),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("encoding reply: %s", err), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,43 @@
+package oracletest
+
+import (
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// TestSignatureMatchesLIT checks that s·G is the point LIT computes from the
+// oracle's key and R-point, which is what contracts settle against
+func TestSignatureMatchesLIT(t *testing.T) {
+	o, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+
+	for i := 0; i < 20; i++ {
+		timestamp := uint64(1000 + i)
+		value := int64(i * 12345)
+		r, err := o.RPoint(1, timestamp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := o.Publish(1, timestamp, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		message := make([]byte, 32)
+		binary.BigEndian.PutUint64(message[24:], uint64(value))
+		want, err := lnutil.DlcCalcOracleSignaturePubKey(message, o.PubKey(), r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k := new(big.Int).SetBytes(sig[:])
+		if got := pubKey(k); got != want {
+			t.Fatalf("value %d: s·G is %x, LIT expects %x", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// OutboxEntry is a call queued in an Outbox
+type OutboxEntry struct {
+	// Key is the idempotency key the call was enqueued with
+	Key    string          `json:"key"`
+	Method string          `json:"method"`
+	Args   json.RawMessage `json:"args"`
+	// Enqueued is the time the call was enqueued
+	Enqueued time.Time `json:"enqueued"`
+	// Done is true once the node answered the call
+	Done bool `json:"done,omitempty"`
+	// Reply is the node's reply, if the call succeeded
+	Reply json.RawMessage `json:"reply,omitempty"`
+	// Error is the error the node returned, if the call failed
+	Error string `json:"error,omitempty"`
+}
+
+// Outbox is a persisted queue of calls that change state on the node, like
+// scheduled payouts. Calls are sent in the order they were enqueued; when the
+// node can't be reached they stay queued, also across restarts of the
+// application, and are sent once the connection is back. Every call has an
+// idempotency key, and a key that was enqueued before is never sent again.
+//
+// A call that reached the node but whose reply was lost (because the
+// connection dropped) is sent again, so calls are delivered at least once.
+type Outbox struct {
+	// Interval is the time between attempts to send queued calls
+	Interval time.Duration
+
+	client *LitRpcClient
+	path   string
+
+	mtx     sync.Mutex
+	entries []*OutboxEntry
+	// flushMtx makes sure only one flush sends calls at a time
+	flushMtx sync.Mutex
+}
+
+// NewOutbox creates an Outbox for [client] that persists its calls to the
+// file at [path]. Previously persisted calls are loaded from it.
+func NewOutbox(client *LitRpcClient, path string) (*Outbox, error) {
+	o := &Outbox{
+		Interval: 10 * time.Second,
+		client:   client,
+		path:     path,
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return o, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, &o.entries)
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Enqueue adds a call of RPC [method] (like "LitRPC.Push") with [args] to the
+// outbox, under idempotency key [key]. If [key] was enqueued before, the call
+// is ignored and false is returned. The call is sent by the next Flush.
+func (o *Outbox) Enqueue(key, method string, args interface{}) (bool, error) {
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return false, err
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	for _, e := range o.entries {
+		if e.Key == key {
+			return false, nil
+		}
+	}
+	o.entries = append(o.entries, &OutboxEntry{Key: key, Method: method, Args: argsJSON, Enqueued: time.Now()})
+	return true, o.save()
+}
+
+// Entry returns the call enqueued with [key], if any
+func (o *Outbox) Entry(key string) (OutboxEntry, bool) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	for _, e := range o.entries {
+		if e.Key == key {
+			return *e, true
+		}
+	}
+	return OutboxEntry{}, false
+}
+
+// Pending returns the calls that were not answered yet, in order
+func (o *Outbox) Pending() []OutboxEntry {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	var pending []OutboxEntry
+	for _, e := range o.entries {
+		if !e.Done {
+			pending = append(pending, *e)
+		}
+	}
+	return pending
+}
+
+// Forget removes the answered calls enqueued before [before] from the outbox.
+// Their keys can be enqueued again afterwards.
+func (o *Outbox) Forget(before time.Time) error {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	kept := o.entries[:0]
+	for _, e := range o.entries {
+		if !e.Done || !e.Enqueued.Before(before) {
+			kept = append(kept, e)
+		}
+	}
+	o.entries = kept
+	return o.save()
+}
+
+// Flush sends the queued calls in order, until all are answered or the node
+// can't be reached. Calls the node answers with an error are done as well;
+// their error is kept in the entry. Returns the error that stopped the flush.
+func (o *Outbox) Flush(ctx context.Context) error {
+	o.flushMtx.Lock()
+	defer o.flushMtx.Unlock()
+	for _, e := range o.Pending() {
+		var reply json.RawMessage
+		err := o.client.CallContext(ctx, e.Method, e.Args, &reply)
+		if err != nil && !errors.Is(err, ErrRemote) && !errors.Is(err, ErrNotSupported) {
+			return err
+		}
+
+		o.mtx.Lock()
+		for _, entry := range o.entries {
+			if entry.Key != e.Key {
+				continue
+			}
+			entry.Done = true
+			entry.Reply = reply
+			if err != nil {
+				entry.Error = err.Error()
+			}
+		}
+		err = o.save()
+		o.mtx.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run flushes the outbox every Interval until [ctx] is cancelled
+func (o *Outbox) Run(ctx context.Context) {
+	ticker := time.NewTicker(o.Interval)
+	defer ticker.Stop()
+	for {
+		o.Flush(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// save persists the outbox. Callers must hold o.mtx.
+func (o *Outbox) save() error {
+	b, err := json.MarshalIndent(o.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(o.path, b, 0600)
+}
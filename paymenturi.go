@@ -0,0 +1,61 @@
+package litrpcclient
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownCoinType is returned by BuildPaymentURI for coin types it has
+// no URI scheme for
+var ErrUnknownCoinType = errors.New("unknown coin type")
+
+// uriSchemes are the BIP21 URI schemes of the coin types LIT supports. The
+// test and regtest networks use the scheme of their main network.
+var uriSchemes = map[uint32]string{
+	0:     "bitcoin",
+	1:     "bitcoin",
+	257:   "bitcoin",
+	2:     "litecoin",
+	65537: "litecoin",
+	258:   "litecoin",
+	28:    "vertcoin",
+	65536: "vertcoin",
+	261:   "vertcoin",
+}
+
+// BuildPaymentURI returns a BIP21 payment request URI for [amount] satoshi
+// of coin type [coinType] to [address] (like one from GetAddresses), with
+// [label] describing the payment to the payer. An [amount] of 0 or an empty
+// [label] are left out. Use QRCodePNG to show the URI as a QR code.
+func BuildPaymentURI(coinType uint32, address string, amount int64, label string) (string, error) {
+	scheme, ok := uriSchemes[coinType]
+	if !ok {
+		return "", fmt.Errorf("%w: %d", ErrUnknownCoinType, coinType)
+	}
+	var params []string
+	if amount > 0 {
+		params = append(params, "amount="+formatCoins(amount))
+	}
+	if label != "" {
+		// BIP21 has no "+" for spaces, so percent-encode them
+		params = append(params, "label="+strings.Replace(url.QueryEscape(label), "+", "%20", -1))
+	}
+	uri := scheme + ":" + address
+	if len(params) > 0 {
+		uri += "?" + strings.Join(params, "&")
+	}
+	return uri, nil
+}
+
+// formatCoins formats [amount] satoshi as whole coins, without trailing
+// zeros
+func formatCoins(amount int64) string {
+	s := strconv.FormatInt(amount/1e8, 10)
+	if frac := amount % 1e8; frac != 0 {
+		s += "." + strings.TrimRight(fmt.Sprintf("%08d", frac), "0")
+	}
+	return s
+}
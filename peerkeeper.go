@@ -0,0 +1,192 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// KeptPeer describes a peer the PeerKeeper keeps connected to. The fields
+// match the arguments to Connect.
+type KeptPeer struct {
+	Address string `json:"address"`
+	Host    string `json:"host,omitempty"`
+	Port    uint32 `json:"port,omitempty"`
+
+	failures    int
+	nextAttempt time.Time
+}
+
+// PeerKeeper keeps a persisted set of peers connected. It periodically checks
+// the node's connection list and re-issues Connect (with exponential backoff)
+// for peers that dropped, for instance because the node was restarted.
+type PeerKeeper struct {
+	// Interval is the time between connection checks
+	Interval time.Duration
+	// MinBackoff and MaxBackoff bound the delay between reconnect attempts
+	// to a single peer
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Backoff, if set, decides the delay between reconnect attempts to a
+	// single peer instead of MinBackoff and MaxBackoff
+	Backoff Backoff
+
+	client *LitRpcClient
+	path   string
+
+	mtx   sync.Mutex
+	peers map[string]*KeptPeer
+}
+
+// NewPeerKeeper creates a PeerKeeper for [client] that persists its peers
+// to the file at [path]. Previously persisted peers are loaded from it.
+func NewPeerKeeper(client *LitRpcClient, path string) (*PeerKeeper, error) {
+	k := &PeerKeeper{
+		Interval:   10 * time.Second,
+		MinBackoff: 5 * time.Second,
+		MaxBackoff: 5 * time.Minute,
+		client:     client,
+		path:       path,
+		peers:      make(map[string]*KeptPeer),
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return k, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var peers []*KeptPeer
+	err = json.Unmarshal(b, &peers)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range peers {
+		k.peers[p.Address] = p
+	}
+	return k, nil
+}
+
+// Keep adds the peer with LN address [address] to the set of peers to stay
+// connected to. host and port can be left empty / 0, as with Connect.
+func (k *PeerKeeper) Keep(address, host string, port uint32) error {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	k.peers[address] = &KeptPeer{Address: address, Host: host, Port: port}
+	return k.save()
+}
+
+// Forget removes the peer with LN address [address] from the set of peers
+// to stay connected to. It does not disconnect from the peer.
+func (k *PeerKeeper) Forget(address string) error {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	delete(k.peers, address)
+	return k.save()
+}
+
+// Peers returns the peers that are kept connected, sorted by address
+func (k *PeerKeeper) Peers() []KeptPeer {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	peers := make([]KeptPeer, 0, len(k.peers))
+	for _, p := range k.peers {
+		peers = append(peers, *p)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].Address < peers[j].Address })
+	return peers
+}
+
+// Run checks the connections every Interval and reconnects dropped peers
+// until [ctx] is cancelled. Failed checks are retried as set with
+// WithWatchBackoff.
+func (k *PeerKeeper) Run(ctx context.Context) {
+	pollEvery(ctx, k.Interval, k.client.opts.watchBackoff, func() error {
+		return k.reconnect(ctx)
+	})
+}
+
+// reconnect issues Connect for all kept peers that are not in the node's
+// connection list and whose backoff has expired. Connections are matched to
+// peers by LN address. The calls are made without holding k.mtx, so Keep and
+// Forget don't wait for slow connects. It returns an error if the connection
+// list could not be read.
+func (k *PeerKeeper) reconnect(ctx context.Context) error {
+	connected, err := k.connectedAddresses(ctx)
+	if err != nil {
+		return err
+	}
+
+	k.mtx.Lock()
+	var due []*KeptPeer
+	for _, p := range k.peers {
+		if connected[p.Address] {
+			p.failures = 0
+			continue
+		}
+		if time.Now().Before(p.nextAttempt) {
+			continue
+		}
+		due = append(due, p)
+	}
+	k.mtx.Unlock()
+
+	for _, p := range due {
+		// Address, Host and Port never change after Keep, so they can be
+		// read without the lock
+		err := k.client.ConnectCtx(ctx, p.Address, p.Host, p.Port)
+
+		k.mtx.Lock()
+		// Skip peers that were forgotten or replaced while connecting
+		if k.peers[p.Address] == p {
+			if err != nil {
+				p.failures++
+				p.nextAttempt = time.Now().Add(k.backoff().Delay(p.failures))
+			} else {
+				p.failures = 0
+			}
+		}
+		k.mtx.Unlock()
+	}
+	return nil
+}
+
+// backoff returns the backoff between reconnect attempts to a peer
+func (k *PeerKeeper) backoff() Backoff {
+	if k.Backoff != nil {
+		return k.Backoff
+	}
+	return ExponentialBackoff{Min: k.MinBackoff, Max: k.MaxBackoff}
+}
+
+// connectedAddresses returns the set of LN addresses of the peers the node
+// is connected to
+func (k *PeerKeeper) connectedAddresses(ctx context.Context) (map[string]bool, error) {
+	conns, err := k.client.subscriptions().ListConnectionsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make(map[string]bool, len(conns))
+	for _, conn := range conns {
+		addresses[conn.LitAdr] = true
+	}
+	return addresses, nil
+}
+
+// save persists the kept peers. Callers must hold k.mtx.
+func (k *PeerKeeper) save() error {
+	peers := make([]*KeptPeer, 0, len(k.peers))
+	for _, p := range k.peers {
+		peers = append(peers, p)
+	}
+	b, err := json.MarshalIndent(peers, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(k.path, b, 0600)
+}
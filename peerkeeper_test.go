@@ -0,0 +1,60 @@
+package litrpcclient
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/qln"
+)
+
+func TestPeerKeeperReconnectsByAddress(t *testing.T) {
+	node := newFakeNode()
+	var mtx sync.Mutex
+	connected := []qln.PeerInfo{{PeerNumber: 1, RemoteHost: "10.0.0.1:2448", LitAdr: "ln1up"}}
+	node.handle("LitRPC.ListConnections", func(interface{}) (interface{}, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return litrpc.ListConnectionsReply{Connections: append([]qln.PeerInfo(nil), connected...)}, nil
+	})
+	node.handle("LitRPC.Connect", func(args interface{}) (interface{}, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		address := args.(*litrpc.ConnectArgs).LNAddr
+		connected = append(connected, qln.PeerInfo{PeerNumber: 2, RemoteHost: "10.0.0.2:2448", LitAdr: address})
+		return litrpc.StatusReply{Status: "connected to peer " + address}, nil
+	})
+	client := newTestClient(node)
+
+	path := filepath.Join(t.TempDir(), "peers.json")
+	keeper, err := NewPeerKeeper(client, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := keeper.Keep("ln1up", "", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := keeper.Keep("ln1down", "", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	keeper.reconnect(context.Background())
+	if n := node.count("LitRPC.Connect"); n != 1 {
+		t.Fatalf("connected %d times, want 1 (only the dropped peer)", n)
+	}
+
+	// A keeper loaded from the file finds both peers connected
+	keeper, err = NewPeerKeeper(client, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(keeper.Peers()); n != 2 {
+		t.Fatalf("loaded %d peers, want 2", n)
+	}
+	keeper.reconnect(context.Background())
+	if n := node.count("LitRPC.Connect"); n != 1 {
+		t.Fatalf("connected %d times, want no new connects", n)
+	}
+}
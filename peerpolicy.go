@@ -0,0 +1,153 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mit-dci/lit/qln"
+)
+
+// ErrPeerNotAllowed is returned when an operation involves a peer the peer
+// policy does not allow
+var ErrPeerNotAllowed = errors.New("peer not allowed by policy")
+
+// PeerPolicy restricts which peers the client deals with, by LN address. A peer
+// on the Deny list is never allowed. If the Allow list is not empty, only peers
+// on it are allowed.
+type PeerPolicy struct {
+	Allow []string
+	Deny  []string
+}
+
+// peerRegistry holds the peer policy, and the LN addresses of the peers we
+// connected to, by peer index
+type peerRegistry struct {
+	mtx       sync.Mutex
+	policy    PeerPolicy
+	addrByIdx map[uint32]string
+}
+
+// SetPeerPolicy sets the policy for which peers the client may connect to, make
+// or accept contract offers with and fund channels with. Peers are identified by
+// LN address; the client learns the address of a peer index from the node's
+// connection list, where LIT derives it from the key the peer connected with,
+// so peers that connected to the node are identified too. While a policy is
+// set, operations on peer indexes whose address can't be found (because the
+// peer isn't connected) are refused.
+func (c *LitRpcClient) SetPeerPolicy(policy PeerPolicy) {
+	c.peers.mtx.Lock()
+	c.peers.policy = policy
+	c.peers.mtx.Unlock()
+}
+
+// hasPolicy returns true if any peer restrictions are configured
+func (r *peerRegistry) hasPolicy() bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return len(r.policy.Allow) > 0 || len(r.policy.Deny) > 0
+}
+
+// checkAddress returns ErrPeerNotAllowed if the policy rejects LN address [address]
+func (r *peerRegistry) checkAddress(address string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.check(address, true)
+}
+
+// checkIndex returns ErrPeerNotAllowed if the policy rejects peer [peerIdx]
+func (r *peerRegistry) checkIndex(peerIdx uint32) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	address, ok := r.addrByIdx[peerIdx]
+	return r.check(address, ok)
+}
+
+// check applies the policy. Callers must hold r.mtx.
+func (r *peerRegistry) check(address string, known bool) error {
+	if !known {
+		if len(r.policy.Allow) == 0 && len(r.policy.Deny) == 0 {
+			return nil
+		}
+		// Without its address, the peer can't be told apart from a denied one
+		return fmt.Errorf("unknown peer: %w", ErrPeerNotAllowed)
+	}
+	for _, denied := range r.policy.Deny {
+		if address == denied {
+			return fmt.Errorf("%s: %w", address, ErrPeerNotAllowed)
+		}
+	}
+	if len(r.policy.Allow) == 0 {
+		return nil
+	}
+	for _, allowed := range r.policy.Allow {
+		if address == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %w", address, ErrPeerNotAllowed)
+}
+
+// learn records that peer [peerIdx] has LN address [address]
+func (r *peerRegistry) learn(peerIdx uint32, address string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.addrByIdx == nil {
+		r.addrByIdx = make(map[uint32]string)
+	}
+	r.addrByIdx[peerIdx] = address
+}
+
+// indexOf returns the peer index we learned for LN address [address]
+func (r *peerRegistry) indexOf(address string) (uint32, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for idx, adr := range r.addrByIdx {
+		if adr == address {
+			return idx, true
+		}
+	}
+	return 0, false
+}
+
+// checkPeer returns ErrPeerNotAllowed if the policy rejects peer [peerIdx].
+// The address of a peer the client doesn't know yet is looked up in the
+// node's connection list.
+func (c *LitRpcClient) checkPeer(ctx context.Context, peerIdx uint32) error {
+	if !c.peers.hasPolicy() {
+		return nil
+	}
+	c.peers.mtx.Lock()
+	_, known := c.peers.addrByIdx[peerIdx]
+	c.peers.mtx.Unlock()
+	if !known {
+		conns, err := c.ListConnectionsCtx(ctx)
+		if err != nil {
+			return err
+		}
+		for _, conn := range conns {
+			if conn.PeerNumber == peerIdx && conn.LitAdr != "" {
+				c.peers.learn(peerIdx, conn.LitAdr)
+			}
+		}
+	}
+	return c.peers.checkIndex(peerIdx)
+}
+
+// connectionTo returns the node's connection to the peer with LN address
+// [address], and records the peer's index. ok is false if the node isn't
+// connected to the peer.
+func (c *LitRpcClient) connectionTo(ctx context.Context, address string) (conn qln.PeerInfo, ok bool, err error) {
+	conns, err := c.ListConnectionsCtx(ctx)
+	if err != nil {
+		return conn, false, err
+	}
+	for _, conn := range conns {
+		if conn.LitAdr == address {
+			c.peers.learn(conn.PeerNumber, address)
+			return conn, true, nil
+		}
+	}
+	return conn, false, nil
+}
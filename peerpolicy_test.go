@@ -0,0 +1,95 @@
+package litrpcclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/qln"
+)
+
+func TestPeerPolicyCheck(t *testing.T) {
+	cases := []struct {
+		policy  PeerPolicy
+		address string
+		known   bool
+		allowed bool
+	}{
+		{PeerPolicy{}, "", false, true},
+		{PeerPolicy{Deny: []string{"ln1bad"}}, "ln1bad", true, false},
+		{PeerPolicy{Deny: []string{"ln1bad"}}, "ln1good", true, true},
+		// A denied peer of unknown address could be the denied one
+		{PeerPolicy{Deny: []string{"ln1bad"}}, "", false, false},
+		{PeerPolicy{Allow: []string{"ln1good"}}, "ln1good", true, true},
+		{PeerPolicy{Allow: []string{"ln1good"}}, "ln1other", true, false},
+		// Deny wins over Allow
+		{PeerPolicy{Allow: []string{"ln1bad"}, Deny: []string{"ln1bad"}}, "ln1bad", true, false},
+	}
+	for i, tc := range cases {
+		r := peerRegistry{policy: tc.policy}
+		err := r.check(tc.address, tc.known)
+		if allowed := err == nil; allowed != tc.allowed {
+			t.Errorf("case %d: allowed %v, want %v (err %v)", i, allowed, tc.allowed, err)
+		}
+		if err != nil && !errors.Is(err, ErrPeerNotAllowed) {
+			t.Errorf("case %d: err %v, want ErrPeerNotAllowed", i, err)
+		}
+	}
+}
+
+func TestPeerPolicyResolvesIncomingPeers(t *testing.T) {
+	node := newFakeNode()
+	// Peer 1 connected to the node, the client never connected to it
+	node.reply("LitRPC.ListConnections", litrpc.ListConnectionsReply{Connections: []qln.PeerInfo{
+		{PeerNumber: 1, LitAdr: "ln1bad"},
+		{PeerNumber: 2, LitAdr: "ln1good"},
+	}})
+	node.reply("LitRPC.Say", litrpc.StatusReply{Status: "ok"})
+	c := newTestClient(node)
+	c.SetPeerPolicy(PeerPolicy{Deny: []string{"ln1bad"}})
+
+	err := c.Say(1, "hi")
+	if !errors.Is(err, ErrPeerNotAllowed) {
+		t.Fatalf("Say to denied peer: %v, want ErrPeerNotAllowed", err)
+	}
+	err = c.Say(2, "hi")
+	if err != nil {
+		t.Fatalf("Say to allowed peer: %v", err)
+	}
+	err = c.Say(3, "hi")
+	if !errors.Is(err, ErrPeerNotAllowed) {
+		t.Fatalf("Say to peer that isn't connected: %v, want ErrPeerNotAllowed", err)
+	}
+	if n := node.count("LitRPC.Say"); n != 1 {
+		t.Fatalf("%d calls to Say reached the node, want 1", n)
+	}
+}
+
+func TestConnectLearnsIndexFromPeerAddress(t *testing.T) {
+	node := newFakeNode()
+	connected := []qln.PeerInfo{{PeerNumber: 1, LitAdr: "ln1other"}}
+	node.handle("LitRPC.ListConnections", func(interface{}) (interface{}, error) {
+		return litrpc.ListConnectionsReply{Connections: connected}, nil
+	})
+	node.handle("LitRPC.Connect", func(interface{}) (interface{}, error) {
+		// Another peer connects to the node at the same time
+		connected = append(connected,
+			qln.PeerInfo{PeerNumber: 2, LitAdr: "ln1incoming"},
+			qln.PeerInfo{PeerNumber: 3, LitAdr: "ln1good"})
+		return litrpc.StatusReply{Status: "connected to peer 3"}, nil
+	})
+	c := newTestClient(node)
+	c.SetPeerPolicy(PeerPolicy{Allow: []string{"ln1good"}})
+
+	result, err := c.ConnectPeer("ln1good", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PeerIdx != 3 {
+		t.Fatalf("peer index %d, want 3", result.PeerIdx)
+	}
+	idx, ok := c.peers.indexOf("ln1good")
+	if !ok || idx != 3 {
+		t.Fatalf("learned index %d (%v), want 3", idx, ok)
+	}
+}
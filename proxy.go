@@ -0,0 +1,42 @@
+package litrpcclient
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/net/proxy"
+)
+
+// WithSOCKS5 makes the client connect to the node through the SOCKS5 proxy at
+// [address], like "127.0.0.1:9050" for a local Tor daemon. Leave [user] and
+// [password] empty if the proxy doesn't require authentication. The node's
+// host name is resolved by the proxy, so Tor hidden services (.onion hosts)
+// can be reached.
+func WithSOCKS5(address, user, password string) Option {
+	return func(o *clientOptions) {
+		o.socksAddress = address
+		o.socksUser = user
+		o.socksPassword = password
+	}
+}
+
+// netDialer returns the function used to open the TCP connection to [host]
+func (c *LitRpcClient) netDialer(host string) (func(network, address string) (net.Conn, error), error) {
+	if c.opts.socksAddress == "" {
+		if strings.HasSuffix(host, ".onion") {
+			return nil, fmt.Errorf("%s is a Tor hidden service, use WithSOCKS5 to connect through Tor", host)
+		}
+		return net.Dial, nil
+	}
+
+	var auth *proxy.Auth
+	if c.opts.socksUser != "" || c.opts.socksPassword != "" {
+		auth = &proxy.Auth{User: c.opts.socksUser, Password: c.opts.socksPassword}
+	}
+	dialer, err := proxy.SOCKS5("tcp", c.opts.socksAddress, auth, proxy.Direct)
+	if err != nil {
+		return nil, err
+	}
+	return dialer.Dial, nil
+}
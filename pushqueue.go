@@ -0,0 +1,184 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrPushQueueFull is returned by Push when too many pushes to the same
+// channel are already waiting
+var ErrPushQueueFull = errors.New("push queue for channel is full")
+
+// pushQueue serializes pushes per channel. Pushes to the same channel are
+// executed one at a time, in the order they were issued.
+type pushQueue struct {
+	mtx      sync.Mutex
+	depth    int
+	channels map[uint32]*channelQueue
+
+	coalesce bool
+	batches  map[batchKey]*pushBatch
+}
+
+// batchKey identifies pushes that can be merged: same channel and same data
+type batchKey struct {
+	chanIdx uint32
+	data    [32]byte
+}
+
+// pushBatch is a set of merged pushes waiting to be executed as one
+type pushBatch struct {
+	amount   int64
+	done     chan struct{}
+	stateIdx uint64
+	err      error
+	// retry is set if the push that started the batch gave up before the
+	// batch was executed, so the pushes that joined it have to be retried
+	retry bool
+}
+
+// channelQueue is a FIFO lock for a single channel. Each waiting push has a
+// channel in waiting, which is closed when it is its turn.
+type channelQueue struct {
+	busy    bool
+	waiting []chan struct{}
+}
+
+// SetPushQueueDepth limits the number of pushes that can be waiting for a
+// single channel to [depth]. Pushes beyond that fail with ErrPushQueueFull.
+// A depth of 0 (the default) means the queue is unbounded.
+func (c *LitRpcClient) SetPushQueueDepth(depth int) {
+	c.pushQueue.mtx.Lock()
+	c.pushQueue.depth = depth
+	c.pushQueue.mtx.Unlock()
+}
+
+// SetPushCoalescing enables or disables merging of pushes. When enabled, pushes
+// to a channel that are waiting for an earlier push to the same channel are merged
+// into a single push of the summed amount, if they carry the same data. All merged
+// pushes return the state index of the combined push. This trades per-payment
+// states for throughput, which suits streams of small payments.
+func (c *LitRpcClient) SetPushCoalescing(enabled bool) {
+	c.pushQueue.mtx.Lock()
+	c.pushQueue.coalesce = enabled
+	c.pushQueue.mtx.Unlock()
+}
+
+// coalescing returns true if pushes should be merged
+func (q *pushQueue) coalescing() bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.coalesce
+}
+
+// pushCoalesced adds the push to a waiting batch for the same channel and data,
+// or starts a new batch that others can join until it is executed. A push
+// whose [ctx] is done while its batch is waiting is taken out of the batch;
+// once the batch is executed, it waits for the result, as its amount is being
+// paid.
+func (c *LitRpcClient) pushCoalesced(ctx context.Context, chanIdx uint32, amount int64, data [32]byte) (uint64, error) {
+	q := &c.pushQueue
+	key := batchKey{chanIdx, data}
+
+	q.mtx.Lock()
+	if q.batches == nil {
+		q.batches = make(map[batchKey]*pushBatch)
+	}
+	batch, ok := q.batches[key]
+	if ok {
+		batch.amount += amount
+		q.mtx.Unlock()
+		select {
+		case <-batch.done:
+		case <-ctx.Done():
+			q.mtx.Lock()
+			if q.batches[key] == batch {
+				batch.amount -= amount
+				q.mtx.Unlock()
+				return 0, ctx.Err()
+			}
+			q.mtx.Unlock()
+			<-batch.done
+		}
+		if batch.retry {
+			return c.pushCoalesced(ctx, chanIdx, amount, data)
+		}
+		return batch.stateIdx, batch.err
+	}
+	batch = &pushBatch{amount: amount, done: make(chan struct{})}
+	q.batches[key] = batch
+	q.mtx.Unlock()
+
+	release, err := q.acquire(ctx, chanIdx)
+	q.mtx.Lock()
+	// From here on, nobody can join the batch anymore
+	delete(q.batches, key)
+	amount = batch.amount
+	batch.retry = err != nil && ctx.Err() != nil
+	q.mtx.Unlock()
+
+	if err == nil {
+		batch.stateIdx, batch.err = c.doPush(ctx, chanIdx, amount, data)
+		release()
+	} else {
+		batch.err = err
+	}
+	close(batch.done)
+	return batch.stateIdx, batch.err
+}
+
+// acquire waits until it's our turn to push to channel [chanIdx], or until
+// [ctx] is done. The returned function must be called when the push is done.
+func (q *pushQueue) acquire(ctx context.Context, chanIdx uint32) (func(), error) {
+	q.mtx.Lock()
+	if q.channels == nil {
+		q.channels = make(map[uint32]*channelQueue)
+	}
+	cq, ok := q.channels[chanIdx]
+	if !ok {
+		cq = new(channelQueue)
+		q.channels[chanIdx] = cq
+	}
+	release := func() {
+		q.mtx.Lock()
+		defer q.mtx.Unlock()
+		if len(cq.waiting) > 0 {
+			close(cq.waiting[0])
+			cq.waiting = cq.waiting[1:]
+			return
+		}
+		cq.busy = false
+		delete(q.channels, chanIdx)
+	}
+	if !cq.busy {
+		cq.busy = true
+		q.mtx.Unlock()
+		return release, nil
+	}
+	if q.depth > 0 && len(cq.waiting) >= q.depth {
+		q.mtx.Unlock()
+		return nil, ErrPushQueueFull
+	}
+	turn := make(chan struct{})
+	cq.waiting = append(cq.waiting, turn)
+	q.mtx.Unlock()
+
+	select {
+	case <-turn:
+		return release, nil
+	case <-ctx.Done():
+	}
+	q.mtx.Lock()
+	for i, ch := range cq.waiting {
+		if ch == turn {
+			cq.waiting = append(cq.waiting[:i], cq.waiting[i+1:]...)
+			q.mtx.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+	q.mtx.Unlock()
+	// It became our turn while the context was done: pass it on
+	release()
+	return nil, ctx.Err()
+}
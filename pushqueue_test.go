@@ -0,0 +1,153 @@
+package litrpcclient
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+// blockedPushNode returns a node that holds the first push until [release]
+// is closed, and a function returning the amounts pushed so far
+func blockedPushNode(release chan struct{}) (*fakeNode, func() []int64) {
+	node := newFakeNode()
+	var mtx sync.Mutex
+	var amounts []int64
+	node.handle("LitRPC.Push", func(args interface{}) (interface{}, error) {
+		mtx.Lock()
+		amounts = append(amounts, args.(*litrpc.PushArgs).Amt)
+		n := len(amounts)
+		mtx.Unlock()
+		if n == 1 {
+			<-release
+		}
+		return litrpc.PushReply{StateIndex: uint64(n)}, nil
+	})
+	return node, func() []int64 {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return append([]int64(nil), amounts...)
+	}
+}
+
+// waitFor fails the test if [cond] doesn't become true within 5 seconds
+func waitFor(t *testing.T, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// push pushes [amount] to channel 1 in the background
+func push(c *LitRpcClient, ctx context.Context, amount int64) chan error {
+	result := make(chan error, 1)
+	go func() {
+		_, err := c.PushCtx(ctx, 1, amount, nil)
+		result <- err
+	}()
+	return result
+}
+
+// waiting returns the number of pushes waiting for channel 1
+func (q *pushQueue) waiting() int {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if cq := q.channels[1]; cq != nil {
+		return len(cq.waiting)
+	}
+	return 0
+}
+
+func TestPushQueueCancelWhileWaiting(t *testing.T) {
+	release := make(chan struct{})
+	node, pushed := blockedPushNode(release)
+	c := newTestClient(node)
+
+	first := push(c, context.Background(), 1)
+	waitFor(t, func() bool { return len(pushed()) == 1 })
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := push(c, ctx, 2)
+	waitFor(t, func() bool { return c.pushQueue.waiting() == 1 })
+	last := push(c, context.Background(), 4)
+	waitFor(t, func() bool { return c.pushQueue.waiting() == 2 })
+
+	cancel()
+	if err := <-canceled; err != context.Canceled {
+		t.Fatalf("canceled push returned %v", err)
+	}
+	if n := c.pushQueue.waiting(); n != 1 {
+		t.Fatalf("%d pushes waiting after cancel, want 1", n)
+	}
+	close(release)
+	if err := <-first; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-last; err != nil {
+		t.Fatal(err)
+	}
+	if got := pushed(); !reflect.DeepEqual(got, []int64{1, 4}) {
+		t.Fatalf("pushed %v, want [1 4]", got)
+	}
+	if len(c.pushQueue.channels) != 0 {
+		t.Fatal("channel queue left after the last push")
+	}
+}
+
+func TestPushCoalescingCancel(t *testing.T) {
+	release := make(chan struct{})
+	node, pushed := blockedPushNode(release)
+	c := newTestClient(node)
+	c.SetPushCoalescing(true)
+	batchAmount := func() int64 {
+		c.pushQueue.mtx.Lock()
+		defer c.pushQueue.mtx.Unlock()
+		for _, batch := range c.pushQueue.batches {
+			return batch.amount
+		}
+		return 0
+	}
+
+	first := push(c, context.Background(), 1)
+	waitFor(t, func() bool { return len(pushed()) == 1 })
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leader := push(c, leaderCtx, 2)
+	waitFor(t, func() bool { return c.pushQueue.waiting() == 1 })
+	followerCtx, cancelFollower := context.WithCancel(context.Background())
+	follower := push(c, followerCtx, 4)
+	waitFor(t, func() bool { return batchAmount() == 6 })
+	last := push(c, context.Background(), 8)
+	waitFor(t, func() bool { return batchAmount() == 14 })
+
+	// A canceled follower leaves the batch
+	cancelFollower()
+	if err := <-follower; err != context.Canceled {
+		t.Fatalf("canceled follower returned %v", err)
+	}
+	if amount := batchAmount(); amount != 10 {
+		t.Fatalf("batch amount %d after cancel, want 10", amount)
+	}
+
+	// When the push that started the batch is canceled, the others go on
+	cancelLeader()
+	if err := <-leader; err != context.Canceled {
+		t.Fatalf("canceled leader returned %v", err)
+	}
+	waitFor(t, func() bool { return c.pushQueue.waiting() == 1 && batchAmount() == 8 })
+
+	close(release)
+	if err := <-first; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-last; err != nil {
+		t.Fatal(err)
+	}
+	if got := pushed(); !reflect.DeepEqual(got, []int64{1, 8}) {
+		t.Fatalf("pushed %v, want [1 8]", got)
+	}
+}
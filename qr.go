@@ -0,0 +1,492 @@
+package litrpcclient
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// ErrQRTooLong is returned by QRCodePNG when the text doesn't fit in the
+// largest QR code it makes
+var ErrQRTooLong = errors.New("text too long for QR code")
+
+// qrVersion describes the error correction blocks of a QR code version at
+// error correction level M
+type qrVersion struct {
+	ecLen    int // error correction codewords per block
+	g1Blocks int
+	g1Len    int // data codewords per block of group 1
+	g2Blocks int
+	g2Len    int
+	align    []int // alignment pattern center positions
+}
+
+// qrVersions are versions 1 to 10 at level M, which hold up to 213 bytes:
+// enough for payment URIs, small enough to keep the tables short
+var qrVersions = []qrVersion{
+	{10, 1, 16, 0, 0, nil},
+	{16, 1, 28, 0, 0, []int{6, 18}},
+	{26, 1, 44, 0, 0, []int{6, 22}},
+	{18, 2, 32, 0, 0, []int{6, 26}},
+	{24, 2, 43, 0, 0, []int{6, 30}},
+	{16, 4, 27, 0, 0, []int{6, 34}},
+	{18, 4, 31, 0, 0, []int{6, 22, 38}},
+	{22, 2, 38, 2, 39, []int{6, 24, 42}},
+	{22, 3, 36, 2, 37, []int{6, 26, 46}},
+	{26, 4, 43, 1, 44, []int{6, 28, 50}},
+}
+
+// QRCodePNG encodes [text] (like a payment URI) as a QR code with error
+// correction level M, and returns it as a PNG image with [scale] pixels per
+// module and the standard quiet zone. Texts of up to 213 bytes fit; longer
+// ones return ErrQRTooLong.
+func QRCodePNG(text string, scale int) ([]byte, error) {
+	modules, err := qrEncode([]byte(text))
+	if err != nil {
+		return nil, err
+	}
+	if scale < 1 {
+		scale = 1
+	}
+	const quiet = 4
+	size := len(modules)
+	img := image.NewGray(image.Rect(0, 0, (size+2*quiet)*scale, (size+2*quiet)*scale))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	for y, row := range modules {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetGray((x+quiet)*scale+dx, (y+quiet)*scale+dy, color.Gray{})
+				}
+			}
+		}
+	}
+	var buf bytes.Buffer
+	err = png.Encode(&buf, img)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// qrEncode returns the modules (true is dark) of the smallest QR code that
+// holds [data] in byte mode
+func qrEncode(data []byte) ([][]bool, error) {
+	for i, v := range qrVersions {
+		version := i + 1
+		capacity := v.g1Blocks*v.g1Len + v.g2Blocks*v.g2Len
+		countBits := 8
+		if version >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) > 8*capacity {
+			continue
+		}
+		q := newQRCode(version, v)
+		q.drawCodewords(v.interleave(qrDataCodewords(data, countBits, capacity)))
+		q.applyBestMask()
+		return q.modules, nil
+	}
+	return nil, ErrQRTooLong
+}
+
+// qrDataCodewords returns the data codewords for [data] in byte mode,
+// padded to [capacity] codewords
+func qrDataCodewords(data []byte, countBits, capacity int) []byte {
+	var bits []bool
+	appendBits := func(v, n int) {
+		for i := n - 1; i >= 0; i-- {
+			bits = append(bits, v>>uint(i)&1 == 1)
+		}
+	}
+	appendBits(0x4, 4)
+	appendBits(len(data), countBits)
+	for _, b := range data {
+		appendBits(int(b), 8)
+	}
+	for i := 0; i < 4 && len(bits) < 8*capacity; i++ {
+		bits = append(bits, false)
+	}
+	for len(bits)%8 != 0 {
+		bits = append(bits, false)
+	}
+
+	codewords := make([]byte, 0, capacity)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for _, bit := range bits[i : i+8] {
+			b <<= 1
+			if bit {
+				b |= 1
+			}
+		}
+		codewords = append(codewords, b)
+	}
+	for pad := byte(0xEC); len(codewords) < capacity; pad ^= 0xEC ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+	return codewords
+}
+
+// interleave splits [data] into the version's blocks, adds the error
+// correction codewords, and interleaves them in the order they are placed
+func (v qrVersion) interleave(data []byte) []byte {
+	var blocks, ecBlocks [][]byte
+	divisor := rsDivisor(v.ecLen)
+	for i := 0; i < v.g1Blocks+v.g2Blocks; i++ {
+		n := v.g1Len
+		if i >= v.g1Blocks {
+			n = v.g2Len
+		}
+		blocks = append(blocks, data[:n])
+		ecBlocks = append(ecBlocks, rsRemainder(data[:n], divisor))
+		data = data[n:]
+	}
+
+	var result []byte
+	for i := 0; i < v.g1Len || i < v.g2Len; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < v.ecLen; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x, y byte) byte {
+	var z byte
+	for i := 7; i >= 0; i-- {
+		carry := z >> 7
+		z <<= 1
+		if carry == 1 {
+			z ^= 0x1D
+		}
+		if y>>uint(i)&1 == 1 {
+			z ^= x
+		}
+	}
+	return z
+}
+
+// rsDivisor returns the Reed-Solomon generator polynomial of [degree],
+// without its leading coefficient
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < degree {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 2)
+	}
+	return result
+}
+
+// rsRemainder returns the error correction codewords of [data]
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= gfMultiply(d, factor)
+		}
+	}
+	return result
+}
+
+// qrCode is a QR code being built
+type qrCode struct {
+	version    int
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// newQRCode creates a QR code of [version] with its function patterns drawn
+func newQRCode(version int, v qrVersion) *qrCode {
+	size := 17 + 4*version
+	q := &qrCode{version: version, size: size}
+	q.modules = make([][]bool, size)
+	q.isFunction = make([][]bool, size)
+	for i := range q.modules {
+		q.modules[i] = make([]bool, size)
+		q.isFunction[i] = make([]bool, size)
+	}
+
+	for i := 0; i < size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+	q.drawFinder(3, 3)
+	q.drawFinder(size-4, 3)
+	q.drawFinder(3, size-4)
+	last := len(v.align) - 1
+	for i, x := range v.align {
+		for j, y := range v.align {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			q.drawAlignment(x, y)
+		}
+	}
+	// Reserve the format areas, they are drawn with the mask
+	q.drawFormat(0)
+	q.drawVersion()
+	return q
+}
+
+// setFunction sets the module at column [x], row [y] as a function module
+func (q *qrCode) setFunction(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.isFunction[y][x] = true
+}
+
+func (q *qrCode) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= q.size || yy < 0 || yy >= q.size {
+				continue
+			}
+			dist := maxInt(absInt(dx), absInt(dy))
+			q.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (q *qrCode) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.setFunction(x+dx, y+dy, maxInt(absInt(dx), absInt(dy)) != 1)
+		}
+	}
+}
+
+// drawFormat draws the format bits for level M and [mask]
+func (q *qrCode) drawFormat(mask int) {
+	data := mask // level M is 00
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>uint(i)&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(i))
+	}
+	q.setFunction(8, q.size-8, true)
+}
+
+// drawVersion draws the version information of versions 7 and up
+func (q *qrCode) drawVersion() {
+	if q.version < 7 {
+		return
+	}
+	rem := q.version
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	bits := q.version<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := bits>>uint(i)&1 == 1
+		a, b := q.size-11+i%3, i/3
+		q.setFunction(a, b, dark)
+		q.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places [codewords] in the zigzag pattern over the modules
+// that are not function modules
+func (q *qrCode) drawCodewords(codewords []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < q.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert
+				}
+				if !q.isFunction[y][x] && i < len(codewords)*8 {
+					q.modules[y][x] = codewords[i>>3]>>uint(7-i&7)&1 == 1
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask flips the data modules selected by [mask]. Applying a mask
+// twice undoes it.
+func (q *qrCode) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !q.isFunction[y][x] {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// applyBestMask applies the mask with the lowest penalty
+func (q *qrCode) applyBestMask() {
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormat(mask)
+		if p := q.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		q.applyMask(mask)
+	}
+	q.applyMask(best)
+	q.drawFormat(best)
+}
+
+// penalty scores how hard the code is to scan, following the four rules of
+// the QR specification
+func (q *qrCode) penalty() int {
+	get := func(x, y int, transposed bool) bool {
+		if transposed {
+			return q.modules[x][y]
+		}
+		return q.modules[y][x]
+	}
+	penalty := 0
+	finderLike := []bool{true, false, true, true, true, false, true}
+	for _, transposed := range []bool{false, true} {
+		for y := 0; y < q.size; y++ {
+			run := 1
+			for x := 1; x <= q.size; x++ {
+				if x < q.size && get(x, y, transposed) == get(x-1, y, transposed) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += run - 2
+				}
+				run = 1
+			}
+			for x := 0; x+7 <= q.size; x++ {
+				match := true
+				for i, dark := range finderLike {
+					if get(x+i, y, transposed) != dark {
+						match = false
+						break
+					}
+				}
+				if match && (q.lightRun(x-4, x, y, transposed) || q.lightRun(x+7, x+11, y, transposed)) {
+					penalty += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x+1 < q.size && y+1 < q.size {
+				c := q.modules[y][x]
+				if q.modules[y][x+1] == c && q.modules[y+1][x] == c && q.modules[y+1][x+1] == c {
+					penalty += 3
+				}
+			}
+		}
+	}
+	total := q.size * q.size
+	k := (absInt(dark*20-total*10)+total-1)/total - 1
+	if k > 0 {
+		penalty += k * 10
+	}
+	return penalty
+}
+
+// lightRun returns whether the modules [from, to) of line [y] are light,
+// counting modules outside the code as light
+func (q *qrCode) lightRun(from, to, y int, transposed bool) bool {
+	for x := from; x < to; x++ {
+		if x < 0 || x >= q.size {
+			continue
+		}
+		dark := q.modules[y][x]
+		if transposed {
+			dark = q.modules[x][y]
+		}
+		if dark {
+			return false
+		}
+	}
+	return true
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
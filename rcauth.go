@@ -0,0 +1,85 @@
+package litrpcclient
+
+import (
+	"context"
+
+	"github.com/mit-dci/lit/crypto/koblitz"
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/qln"
+)
+
+// AuthorizeRemoteControl allows the holder of the private key of [pubKey] to
+// control the node over the remote control interface (see WithRemoteControl).
+// LIT has no RPC that lists the keys that are authorized, so operators that
+// rotate keys should keep track of the keys they authorized, and revoke the
+// old key with RevokeRemoteControl.
+func (c *LitRpcClient) AuthorizeRemoteControl(pubKey *koblitz.PublicKey) error {
+	return c.AuthorizeRemoteControlCtx(context.Background(), pubKey)
+}
+
+// AuthorizeRemoteControlCtx is like AuthorizeRemoteControl, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) AuthorizeRemoteControlCtx(ctx context.Context, pubKey *koblitz.PublicKey) error {
+	return c.setRemoteControlAuth(ctx, pubKey, true)
+}
+
+// RevokeRemoteControl removes the authorization of [pubKey] to control the
+// node. It also declines a pending request for access by that key.
+func (c *LitRpcClient) RevokeRemoteControl(pubKey *koblitz.PublicKey) error {
+	return c.RevokeRemoteControlCtx(context.Background(), pubKey)
+}
+
+// RevokeRemoteControlCtx is like RevokeRemoteControl, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) RevokeRemoteControlCtx(ctx context.Context, pubKey *koblitz.PublicKey) error {
+	return c.setRemoteControlAuth(ctx, pubKey, false)
+}
+
+// RequestRemoteAccess asks the node to authorize [pubKey] for remote control.
+// The request shows up in the node's list of pending requests until an
+// operator answers it with AuthorizeRemoteControl or RevokeRemoteControl.
+func (c *LitRpcClient) RequestRemoteAccess(pubKey *koblitz.PublicKey) error {
+	return c.RequestRemoteAccessCtx(context.Background(), pubKey)
+}
+
+// RequestRemoteAccessCtx is like RequestRemoteAccess, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) RequestRemoteAccessCtx(ctx context.Context, pubKey *koblitz.PublicKey) error {
+	args := new(litrpc.RCRequestAuthArgs)
+	copy(args.PubKey[:], pubKey.SerializeCompressed())
+	reply := new(litrpc.StatusReply)
+	return c.callCtx(ctx, "LitRPC.RequestRemoteControlAuthorization", args, reply)
+}
+
+// ListRemoteAccessRequests returns the keys that requested remote control
+// access and were not authorized or revoked yet
+func (c *LitRpcClient) ListRemoteAccessRequests() ([]*koblitz.PublicKey, error) {
+	return c.ListRemoteAccessRequestsCtx(context.Background())
+}
+
+// ListRemoteAccessRequestsCtx is like ListRemoteAccessRequests, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListRemoteAccessRequestsCtx(ctx context.Context) ([]*koblitz.PublicKey, error) {
+	args := new(litrpc.NoArgs)
+	reply := new(litrpc.RCPendingAuthRequestsReply)
+	err := c.callCtx(ctx, "LitRPC.ListPendingRemoteControlAuthRequests", args, reply)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*koblitz.PublicKey, 0, len(reply.PubKeys))
+	for _, b := range reply.PubKeys {
+		key, err := koblitz.ParsePubKey(b[:], koblitz.S256())
+		if err != nil {
+			return nil, remoteError("LitRPC.ListPendingRemoteControlAuthRequests", "Invalid public key %x: %v", b, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// setRemoteControlAuth sets whether [pubKey] is allowed to control the node
+func (c *LitRpcClient) setRemoteControlAuth(ctx context.Context, pubKey *koblitz.PublicKey, allowed bool) error {
+	args := new(litrpc.RCAuthArgs)
+	args.PubKey = pubKey.SerializeCompressed()
+	args.Authorization = &qln.RemoteControlAuthorization{Allowed: allowed}
+	copy(args.Authorization.PubKey[:], args.PubKey)
+	reply := new(litrpc.StatusReply)
+	return c.callCtx(ctx, "LitRPC.RemoteControlAuth", args, reply)
+}
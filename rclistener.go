@@ -0,0 +1,74 @@
+package litrpcclient
+
+import (
+	"net"
+
+	"github.com/mit-dci/lit/crypto/koblitz"
+	"github.com/mit-dci/lit/lndc"
+)
+
+// RemoteControlListener accepts lndc connections from LIT nodes, and controls
+// the nodes over the connections they opened. This reaches nodes behind NAT
+// without forwarding a port to them: the node connects out to the listener
+// like it connects to any other peer (for instance with ConnectCtx, or
+// "con" in lit-af, using the listener's IdentityAddress), after which the
+// remote control calls travel back over the same connection. The node only
+// answers them if the listener's key is authorized for remote control.
+type RemoteControlListener struct {
+	listener *lndc.Listener
+	key      *koblitz.PrivateKey
+	opts     []Option
+}
+
+// InboundNode is a node that connected to a RemoteControlListener
+type InboundNode struct {
+	// Client makes calls to the node over the connection it opened. It can't
+	// reconnect; when the connection is lost the node has to connect again.
+	Client *LitRpcClient
+	// PubKey is the node's identity key
+	PubKey *koblitz.PublicKey
+	// Addr is the address the node connected from
+	Addr net.Addr
+}
+
+// ListenRemoteControl listens for lndc connections on TCP port [port],
+// identifying as [key]. The clients of the accepted nodes are created with
+// [opts]; WithReconnect, WithSubscriptionConn and the options that select a
+// remote control key don't apply to them.
+func ListenRemoteControl(key *koblitz.PrivateKey, port int, opts ...Option) (*RemoteControlListener, error) {
+	listener, err := lndc.NewListener(key, port)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteControlListener{listener: listener, key: key, opts: opts}, nil
+}
+
+// Accept waits for the next node to connect, and returns a client that
+// controls it
+func (l *RemoteControlListener) Accept() (*InboundNode, error) {
+	conn, err := l.listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	client := new(LitRpcClient)
+	for _, opt := range l.opts {
+		opt(&client.opts)
+	}
+	client.conn = client.wrapTransport(newRCTransport(conn, l.key, &client.stats, client.asyncError))
+
+	node := &InboundNode{Client: client, Addr: conn.RemoteAddr()}
+	if lndcConn, ok := conn.(*lndc.Conn); ok {
+		node.PubKey = lndcConn.RemotePub()
+	}
+	return node, nil
+}
+
+// Addr returns the address the listener listens on
+func (l *RemoteControlListener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+// Close stops listening. Clients of nodes that already connected stay open.
+func (l *RemoteControlListener) Close() error {
+	return l.listener.Close()
+}
@@ -0,0 +1,144 @@
+package litrpcclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/rpc"
+	"sync"
+	"time"
+)
+
+// WithReconnect makes the client redial the node when the connection drops,
+// waiting between [minBackoff] and [maxBackoff] (doubling after each failed
+// attempt, with jitter) between attempts. Calls that are in flight when the
+// connection drops, or that are made while it is down, fail with
+// ErrDisconnected. After reconnecting, the client makes LIT listen again on
+// the ports it was told to listen on with Listen or ListenAny.
+func WithReconnect(minBackoff, maxBackoff time.Duration) Option {
+	return func(o *clientOptions) {
+		o.reconnectBackoff = ExponentialBackoff{Min: minBackoff, Max: maxBackoff, Jitter: true}
+	}
+}
+
+// listenState remembers the ports LIT was told to listen on
+type listenState struct {
+	mtx   sync.Mutex
+	ports []string
+}
+
+// add records that LIT listens on [port]
+func (l *listenState) add(port string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	for _, p := range l.ports {
+		if p == port {
+			return
+		}
+	}
+	l.ports = append(l.ports, port)
+}
+
+// list returns the ports LIT was told to listen on
+func (l *listenState) list() []string {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return append([]string(nil), l.ports...)
+}
+
+// reconnectTransport wraps the connection to the node and replaces it with
+// a new one when it drops
+type reconnectTransport struct {
+	dial        func() (transport, error)
+	onReconnect func()
+	onError     func(error)
+	backoff     Backoff
+
+	mtx    sync.Mutex
+	conn   transport // nil while disconnected
+	closed bool
+}
+
+func (t *reconnectTransport) Go(ctx context.Context, method string, args interface{}, reply interface{}, done func(error)) func(error) {
+	t.mtx.Lock()
+	conn, closed := t.conn, t.closed
+	t.mtx.Unlock()
+	if closed {
+		done(rpc.ErrShutdown)
+		return noCancel
+	}
+	if conn == nil {
+		done(ErrDisconnected)
+		return noCancel
+	}
+
+	return conn.Go(ctx, method, args, reply, func(err error) {
+		if err == rpc.ErrShutdown || err == io.EOF || err == io.ErrUnexpectedEOF {
+			t.disconnected(conn)
+			err = ErrDisconnected
+		}
+		done(err)
+	})
+}
+
+// disconnected drops [conn] and starts redialing, unless the connection was
+// already replaced or the transport was closed
+func (t *reconnectTransport) disconnected(conn transport) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.closed || t.conn != conn {
+		return
+	}
+	t.conn = nil
+	conn.Close()
+	go t.redial()
+}
+
+// redial dials the node until it succeeds or the transport is closed
+func (t *reconnectTransport) redial() {
+	for attempt := 1; ; attempt++ {
+		time.Sleep(t.backoff.Delay(attempt))
+
+		t.mtx.Lock()
+		closed := t.closed
+		t.mtx.Unlock()
+		if closed {
+			return
+		}
+
+		conn, err := t.dial()
+		if err == nil {
+			t.mtx.Lock()
+			if t.closed {
+				t.mtx.Unlock()
+				conn.Close()
+				return
+			}
+			t.conn = conn
+			t.mtx.Unlock()
+			t.onReconnect()
+			return
+		}
+
+		t.onError(fmt.Errorf("reconnecting: %w", err))
+	}
+}
+
+func (t *reconnectTransport) Close() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.closed = true
+	if t.conn == nil {
+		return nil
+	}
+	return t.conn.Close()
+}
+
+// relisten makes LIT listen again on the ports it listened on before the
+// connection dropped. LIT keeps listening while the node keeps running, in
+// which case the port is reported in use and ignored.
+func (c *LitRpcClient) relisten() {
+	for _, port := range c.listened.list() {
+		c.listen(context.Background(), port)
+	}
+}
@@ -0,0 +1,258 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"sync"
+
+	"github.com/mit-dci/lit/crypto/koblitz"
+	"github.com/mit-dci/lit/lndc"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// maxMessageSize is the largest message lndc will deliver in a single read
+const maxMessageSize = 65535
+
+// maxResponseSize is the largest remote control response the client accepts
+const maxResponseSize = 64 << 20
+
+// WithRemoteControl makes the client connect to LIT's remote control interface
+// (on the LN port, 2448 by default) instead of the websocket RPC port. The
+// connection is authenticated with [key], which has to be authorized for remote
+// control on the node. The RPC methods, arguments and replies are the same as
+// on the websocket RPC port.
+//
+// The lndc session keys are internal to the lndc package and can't be
+// exported for decrypting captured traffic. To inspect the calls on the
+// connection, use WithRecording, which stores them in plain text.
+func WithRemoteControl(key *koblitz.PrivateKey) Option {
+	return func(o *clientOptions) {
+		o.rcKey = key
+	}
+}
+
+// rcTransport sends JSON-RPC calls wrapped in remote control messages over an
+// lndc connection. Responses are matched to calls by the message index.
+type rcTransport struct {
+	conn    io.ReadWriteCloser
+	onError func(error)
+	// pubKey is the compressed public key of the client's key, which the
+	// node checks the authorization of
+	pubKey [33]byte
+
+	writeMtx sync.Mutex
+
+	mtx     sync.Mutex
+	nonce   uint64
+	pending map[uint64]*rpcCall
+	closed  bool
+	closing bool // Close was called
+}
+
+// dialRemoteControl connects to the node's LN port using key [key], opening
+// the TCP connection with [netDial]. Errors in the receive loop are passed to
+// [onError].
+func dialRemoteControl(key *koblitz.PrivateKey, host string, port int32, netDial func(string, string) (net.Conn, error), stats *connStats, onError func(error)) (transport, error) {
+	conn, err := lndc.Dial(key, net.JoinHostPort(host, fmt.Sprint(port)), "", netDial)
+	if err != nil {
+		return nil, err
+	}
+	return newRCTransport(conn, key, stats, onError), nil
+}
+
+// newRCTransport sends remote control calls over the established lndc
+// connection [conn], made with key [key]
+func newRCTransport(conn net.Conn, key *koblitz.PrivateKey, stats *connStats, onError func(error)) *rcTransport {
+	t := &rcTransport{
+		conn:    &countingConn{conn, stats},
+		onError: onError,
+		pending: make(map[uint64]*rpcCall),
+	}
+	copy(t.pubKey[:], key.PubKey().SerializeCompressed())
+	go t.receiveLoop()
+	return t
+}
+
+// Go sends a remote control request for [method]. The response is decoded
+// into [reply] by the receive loop.
+func (t *rcTransport) Go(ctx context.Context, method string, args interface{}, reply interface{}, done func(error)) func(error) {
+	msg := lnutil.RemoteControlRpcRequestMsg{Method: method, PubKey: t.pubKey}
+	var err error
+	msg.Args, err = json.Marshal(args)
+	if err != nil {
+		done(err)
+		return noCancel
+	}
+
+	t.mtx.Lock()
+	if t.closed {
+		t.mtx.Unlock()
+		done(rpc.ErrShutdown)
+		return noCancel
+	}
+	msg.Idx = t.nonce
+	t.nonce++
+	t.pending[msg.Idx] = &rpcCall{reply, done}
+	t.mtx.Unlock()
+	if span := spanFromContext(ctx); span != nil {
+		span.SetAttribute("lit.nonce", msg.Idx)
+	}
+
+	t.writeMtx.Lock()
+	_, err = t.conn.Write(msg.Bytes())
+	t.writeMtx.Unlock()
+	if err != nil {
+		t.fail(msg.Idx, err)
+		return noCancel
+	}
+	return func(err error) { t.fail(msg.Idx, err) }
+}
+
+// fail completes call [idx] with [err], if it is still waiting for its
+// response
+func (t *rcTransport) fail(idx uint64, err error) {
+	t.mtx.Lock()
+	call := t.pending[idx]
+	delete(t.pending, idx)
+	t.mtx.Unlock()
+	if call != nil {
+		call.done(err)
+	}
+}
+
+// Close closes the lndc connection. Pending calls return rpc.ErrShutdown.
+func (t *rcTransport) Close() error {
+	t.mtx.Lock()
+	t.closing = true
+	t.mtx.Unlock()
+	return t.conn.Close()
+}
+
+// receiveLoop reads messages from the connection and delivers responses to
+// the pending calls, until the connection fails or is closed.
+//
+// lndc splits writes larger than its maximum message size into several
+// messages, and a Read can return part of a message, so the reads don't
+// line up with responses. Responses are framed by the length of the result
+// they carry instead: the bytes read are collected until they hold a whole
+// response. Other messages carry no length the client knows, so they are
+// dropped along with the rest of the read they arrived in.
+func (t *rcTransport) receiveLoop() {
+	buf := make([]byte, maxMessageSize)
+	var received []byte
+	for {
+		n, err := t.conn.Read(buf)
+		if err != nil {
+			if !t.shutdown() {
+				t.onError(fmt.Errorf("remote control connection lost: %w", err))
+			}
+			return
+		}
+		received = append(received, buf[:n]...)
+		for len(received) > 0 {
+			if received[0] != lnutil.MSGID_REMOTE_RPCRESPONSE {
+				received = nil
+				break
+			}
+			size, complete := rcResponseSize(received)
+			if size > maxResponseSize {
+				t.onError(fmt.Errorf("invalid remote control response: %d bytes", size))
+				received = nil
+				break
+			}
+			if !complete {
+				break
+			}
+			response, err := lnutil.NewRemoteControlRpcResponseMsgFromBytes(received[:size], 0)
+			received = received[size:]
+			if err != nil {
+				t.onError(fmt.Errorf("invalid remote control response: %w", err))
+				continue
+			}
+			t.deliver(response)
+		}
+		if len(received) == 0 {
+			received = nil
+		}
+	}
+}
+
+// deliver decodes [response] into the reply of the call it belongs to, and
+// completes the call
+func (t *rcTransport) deliver(response lnutil.RemoteControlRpcResponseMsg) {
+	t.mtx.Lock()
+	call := t.pending[response.Idx]
+	delete(t.pending, response.Idx)
+	t.mtx.Unlock()
+	if call == nil {
+		return
+	}
+	if response.Error {
+		call.done(&numberedServerError{rpc.ServerError(response.Result), response.Idx})
+		return
+	}
+	call.done(json.Unmarshal(response.Result, call.reply))
+}
+
+// rcResponseSize returns the size of the response message at the start of
+// [b]: the message type, the index, the error flag, the length of the
+// result as a varint and the result. complete is false if [b] doesn't hold
+// the whole message yet; size is 0 if it doesn't even hold the length.
+func rcResponseSize(b []byte) (size int, complete bool) {
+	const header = 1 + 8 + 1
+	if len(b) <= header {
+		return 0, false
+	}
+	length, n := readVarInt(b[header:])
+	if n == 0 {
+		return 0, false
+	}
+	if length > maxResponseSize {
+		return maxResponseSize + 1, false
+	}
+	size = header + n + int(length)
+	return size, len(b) >= size
+}
+
+// readVarInt decodes the Bitcoin style varint at the start of [b], and
+// returns it with its size. The size is 0 if [b] is too short.
+func readVarInt(b []byte) (uint64, int) {
+	switch b[0] {
+	case 0xfd:
+		if len(b) < 3 {
+			return 0, 0
+		}
+		return uint64(binary.LittleEndian.Uint16(b[1:])), 3
+	case 0xfe:
+		if len(b) < 5 {
+			return 0, 0
+		}
+		return uint64(binary.LittleEndian.Uint32(b[1:])), 5
+	case 0xff:
+		if len(b) < 9 {
+			return 0, 0
+		}
+		return binary.LittleEndian.Uint64(b[1:]), 9
+	}
+	return uint64(b[0]), 1
+}
+
+// shutdown marks the transport closed and fails all pending calls with
+// rpc.ErrShutdown. It returns whether Close was called.
+func (t *rcTransport) shutdown() bool {
+	t.mtx.Lock()
+	t.closed = true
+	pending := t.pending
+	t.pending = make(map[uint64]*rpcCall)
+	closing := t.closing
+	t.mtx.Unlock()
+	for _, call := range pending {
+		call.done(rpc.ErrShutdown)
+	}
+	return closing
+}
@@ -0,0 +1,218 @@
+package litrpcclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/rpc"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mit-dci/lit/crypto/koblitz"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// newPipeTransport returns a remote control transport with a new key, and
+// the node's end of its connection
+func newPipeTransport(t *testing.T) (*rcTransport, *koblitz.PrivateKey, net.Conn) {
+	key, err := koblitz.NewPrivateKey(koblitz.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientConn, nodeConn := net.Pipe()
+	transport := newRCTransport(clientConn, key, new(connStats), func(err error) { t.Error(err) })
+	t.Cleanup(func() { transport.Close() })
+	return transport, key, nodeConn
+}
+
+// readRequest reads a request from the node's end of the connection
+func readRequest(t *testing.T, conn net.Conn) lnutil.RemoteControlRpcRequestMsg {
+	buf := make([]byte, maxMessageSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := lnutil.NewRemoteControlRpcRequestMsgFromBytes(buf[:n], 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+// response returns the serialized response to [msg] with result [result]
+func response(t *testing.T, msg lnutil.RemoteControlRpcRequestMsg, result interface{}) []byte {
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lnutil.RemoteControlRpcResponseMsg{Idx: msg.Idx, Result: b}.Bytes()
+}
+
+func TestRemoteControlRequestCarriesPubKey(t *testing.T) {
+	transport, key, nodeConn := newPipeTransport(t)
+	go func() {
+		msg := readRequest(t, nodeConn)
+		var want [33]byte
+		copy(want[:], key.PubKey().SerializeCompressed())
+		if msg.PubKey != want {
+			t.Errorf("request has pubkey %x, want %x", msg.PubKey, want)
+		}
+		if msg.Method != "LitRPC.Balance" {
+			t.Errorf("request has method %s", msg.Method)
+		}
+		nodeConn.Write(response(t, msg, "ok"))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var reply string
+	err := callTransport(ctx, transport, "LitRPC.Balance", struct{}{}, &reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply != "ok" {
+		t.Fatalf("reply %q, want ok", reply)
+	}
+}
+
+func TestRemoteControlFramesResponses(t *testing.T) {
+	transport, _, nodeConn := newPipeTransport(t)
+	large := strings.Repeat("x", 3*maxMessageSize/2)
+
+	results := make(chan string, 3)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		go func() {
+			var reply string
+			err := callTransport(ctx, transport, "LitRPC.Balance", struct{}{}, &reply)
+			if err != nil {
+				t.Error(err)
+			}
+			results <- reply
+		}()
+	}
+
+	var msgs []lnutil.RemoteControlRpcRequestMsg
+	for i := 0; i < 3; i++ {
+		msgs = append(msgs, readRequest(t, nodeConn))
+	}
+	// Two responses in a single read, then a response larger than an lndc
+	// message, split like lndc splits it
+	nodeConn.Write(append(response(t, msgs[0], "first"), response(t, msgs[1], "second")...))
+	third := response(t, msgs[2], large)
+	for len(third) > 0 {
+		n := maxMessageSize
+		if n > len(third) {
+			n = len(third)
+		}
+		nodeConn.Write(third[:n])
+		third = third[n:]
+	}
+
+	got := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		got[<-results] = true
+	}
+	if !got["first"] || !got["second"] || !got[large] {
+		t.Fatalf("calls didn't all get their response")
+	}
+}
+
+func TestRCResponseSize(t *testing.T) {
+	msg := lnutil.RemoteControlRpcResponseMsg{Idx: 7, Result: bytes.Repeat([]byte{1}, 300)}.Bytes()
+	for i := 0; i < len(msg); i++ {
+		if _, complete := rcResponseSize(msg[:i]); complete {
+			t.Fatalf("%d of %d bytes reported complete", i, len(msg))
+		}
+	}
+	size, complete := rcResponseSize(append(msg, msg...))
+	if !complete || size != len(msg) {
+		t.Fatalf("size %d (complete %v), want %d", size, complete, len(msg))
+	}
+}
+
+// pendingCalls returns the number of calls waiting for a response
+func (t *rcTransport) pendingCalls() int {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return len(t.pending)
+}
+
+func TestRemoteControlCallLifecycle(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	transport, _, nodeConn := newPipeTransport(t)
+	requests := make(chan lnutil.RemoteControlRpcRequestMsg)
+	go func() {
+		defer close(requests)
+		buf := make([]byte, maxMessageSize)
+		for {
+			n, err := nodeConn.Read(buf)
+			if err != nil {
+				return
+			}
+			msg, _ := lnutil.NewRemoteControlRpcRequestMsgFromBytes(buf[:n], 0)
+			requests <- msg
+		}
+	}()
+	call := func(ctx context.Context) chan error {
+		result := make(chan error, 1)
+		go func() {
+			var reply string
+			result <- callTransport(ctx, transport, "LitRPC.Balance", struct{}{}, &reply)
+		}()
+		return result
+	}
+
+	// Success
+	done := call(context.Background())
+	nodeConn.Write(response(t, <-requests, "ok"))
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	// Timeout, with the response arriving afterwards
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done = call(ctx)
+	late := <-requests
+	if err := <-done; err != context.DeadlineExceeded {
+		t.Fatalf("got %v, want deadline exceeded", err)
+	}
+	nodeConn.Write(response(t, late, "late"))
+
+	// Cancel
+	ctx, cancel = context.WithCancel(context.Background())
+	done = call(ctx)
+	<-requests
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("got %v, want canceled", err)
+	}
+	if n := transport.pendingCalls(); n != 0 {
+		t.Fatalf("%d calls still pending after timeout and cancel", n)
+	}
+
+	// Close, with a call in flight
+	done = call(context.Background())
+	<-requests
+	transport.Close()
+	if err := <-done; err != rpc.ErrShutdown {
+		t.Fatalf("got %v, want rpc.ErrShutdown", err)
+	}
+	if n := transport.pendingCalls(); n != 0 {
+		t.Fatalf("%d calls still pending after close", n)
+	}
+
+	// The receive loop and the node's reader end with the connection
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines left, %d before", runtime.NumGoroutine(), baseline)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,328 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// OperationResult describes the outcome of an operation that changes state on
+// the node. Fields that don't apply to an operation are left empty; new
+// fields may be added in minor versions.
+type OperationResult struct {
+	// Txids are the ids of the transactions the operation published
+	Txids []string
+	// ChannelIndex is the channel the operation applies to
+	ChannelIndex uint32
+	// StateIndex is the channel state the operation created
+	StateIndex uint64
+	// HTLCIndex is the index of the HTLC within the channel, for AddHTLCResult
+	HTLCIndex uint32
+	// Status is the status text the node replied with, if any
+	Status string
+	// Raw is the node's reply to the operation's RPC, as JSON, when available
+	Raw json.RawMessage
+}
+
+type resultKey struct{}
+
+// resultCapture is where callCtx stores the reply to [method]
+type resultCapture struct {
+	method string
+	result *OperationResult
+}
+
+// withResult returns a context that makes callCtx record the reply to
+// [method] in the returned result
+func withResult(ctx context.Context, method string) (context.Context, *OperationResult) {
+	res := new(OperationResult)
+	return context.WithValue(ctx, resultKey{}, &resultCapture{method, res}), res
+}
+
+// captureResult records [reply] to [method] in the result of [ctx], if the
+// context was created by withResult for [method]
+func captureResult(ctx context.Context, method string, reply interface{}) {
+	capture, ok := ctx.Value(resultKey{}).(*resultCapture)
+	if !ok || capture.method != method {
+		return
+	}
+	raw, err := json.Marshal(reply)
+	if err != nil {
+		return
+	}
+	capture.result.Raw = raw
+	var status struct {
+		Status string
+	}
+	if json.Unmarshal(raw, &status) == nil {
+		capture.result.Status = status.Status
+	}
+}
+
+// ConnectResult describes the connection made by ConnectPeer
+type ConnectResult struct {
+	// PeerIdx is the index the node assigned to the peer
+	PeerIdx uint32
+	// LNAddr is the LN address of the peer
+	LNAddr string
+	// RemoteHost is the address of the peer's end of the connection
+	RemoteHost string
+}
+
+// ConnectPeer is like Connect, but returns the peer index the node
+// assigned to the peer. LIT doesn't report the index when connecting, so it
+// is looked up in the connection list by the peer's LN address.
+func (c *LitRpcClient) ConnectPeer(address, host string, port uint32) (*ConnectResult, error) {
+	return c.ConnectPeerCtx(context.Background(), address, host, port)
+}
+
+// ConnectPeerCtx is like ConnectPeer, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ConnectPeerCtx(ctx context.Context, address, host string, port uint32) (*ConnectResult, error) {
+	err := c.peers.checkAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	err = c.connect(ctx, address, host, port)
+	if err != nil {
+		return nil, err
+	}
+	conn, ok, err := c.connectionTo(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, remoteError("LitRPC.Connect", "Peer %s not found in connection list", address)
+	}
+	return &ConnectResult{PeerIdx: conn.PeerNumber, LNAddr: address, RemoteHost: conn.RemoteHost}, nil
+}
+
+// SendResult is like Send, but returns an OperationResult
+func (c *LitRpcClient) SendResult(address string, amount int64) (*OperationResult, error) {
+	return c.SendResultCtx(context.Background(), address, amount)
+}
+
+// SendResultCtx is like SendResult, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SendResultCtx(ctx context.Context, address string, amount int64) (*OperationResult, error) {
+	ctx, res := withResult(ctx, "LitRPC.Send")
+	txid, err := c.SendCtx(ctx, address, amount)
+	if err != nil {
+		return nil, err
+	}
+	res.Txids = []string{txid}
+	return res, nil
+}
+
+// SweepResult is like Sweep without dry run, but returns an OperationResult
+func (c *LitRpcClient) SweepResult(coinType uint32, destAddress string) (*OperationResult, error) {
+	return c.SweepResultCtx(context.Background(), coinType, destAddress)
+}
+
+// SweepResultCtx is like SweepResult, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SweepResultCtx(ctx context.Context, coinType uint32, destAddress string) (*OperationResult, error) {
+	ctx, res := withResult(ctx, "LitRPC.Sweep")
+	txids, err := c.SweepCtx(ctx, coinType, destAddress, false)
+	if err != nil {
+		return nil, err
+	}
+	res.Txids = txids
+	return res, nil
+}
+
+// FanoutResult is like Fanout, but returns an OperationResult
+func (c *LitRpcClient) FanoutResult(destAddress string, numOutputs uint32, amountPerOutput int64) (*OperationResult, error) {
+	return c.FanoutResultCtx(context.Background(), destAddress, numOutputs, amountPerOutput)
+}
+
+// FanoutResultCtx is like FanoutResult, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) FanoutResultCtx(ctx context.Context, destAddress string, numOutputs uint32, amountPerOutput int64) (*OperationResult, error) {
+	ctx, res := withResult(ctx, "LitRPC.Fanout")
+	txid, _, err := c.FanoutCtx(ctx, destAddress, numOutputs, amountPerOutput)
+	if err != nil && txid == "" {
+		return nil, err
+	}
+	res.Txids = []string{txid}
+	return res, nil
+}
+
+// FundChannelResult is like FundChannel, but returns an OperationResult
+func (c *LitRpcClient) FundChannelResult(peerIndex, coinType uint32, amount, initialSend int64, data []byte) (*OperationResult, error) {
+	return c.FundChannelResultCtx(context.Background(), peerIndex, coinType, amount, initialSend, data)
+}
+
+// FundChannelResultCtx is like FundChannelResult, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) FundChannelResultCtx(ctx context.Context, peerIndex, coinType uint32, amount, initialSend int64, data []byte) (*OperationResult, error) {
+	ctx, res := withResult(ctx, "LitRPC.FundChannel")
+	err := c.FundChannelCtx(ctx, peerIndex, coinType, amount, initialSend, data)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// PushResult is like Push, but returns an OperationResult. Like Push,
+// it returns a *StateRegressionError together with the result if the node
+// returned a state index that is not higher than before.
+func (c *LitRpcClient) PushResult(channelIndex uint32, amount int64, data []byte) (*OperationResult, error) {
+	return c.PushResultCtx(context.Background(), channelIndex, amount, data)
+}
+
+// PushResultCtx is like PushResult, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) PushResultCtx(ctx context.Context, channelIndex uint32, amount int64, data []byte) (*OperationResult, error) {
+	ctx, res := withResult(ctx, "LitRPC.Push")
+	stateIndex, err := c.PushCtx(ctx, channelIndex, amount, data)
+	if err != nil && stateIndex == 0 {
+		return nil, err
+	}
+	res.ChannelIndex = channelIndex
+	res.StateIndex = stateIndex
+	return res, err
+}
+
+// PushReceipt is a record of a payment made with PushWithReceipt
+type PushReceipt struct {
+	ChannelIndex uint32
+	// StateIndex is the channel state the push created
+	StateIndex uint64
+	Amount     int64
+	// LocalBalance and RemoteBalance are our and the peer's balance in the
+	// channel after the push
+	LocalBalance  int64
+	RemoteBalance int64
+	// Data is the data the channel state carries
+	Data [32]byte
+	// Time is the time the push completed
+	Time time.Time
+}
+
+// PushWithReceipt is like Push, but returns a PushReceipt for accounting.
+// The balances are read from the node after the push; when other pushes to
+// the channel run concurrently, they can include the effect of those. If
+// the push succeeded but the channel can't be read, the receipt is returned
+// without balances, together with the error.
+func (c *LitRpcClient) PushWithReceipt(channelIndex uint32, amount int64, data []byte) (*PushReceipt, error) {
+	return c.PushWithReceiptCtx(context.Background(), channelIndex, amount, data)
+}
+
+// PushWithReceiptCtx is like PushWithReceipt, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) PushWithReceiptCtx(ctx context.Context, channelIndex uint32, amount int64, data []byte) (*PushReceipt, error) {
+	stateIndex, err := c.PushCtx(ctx, channelIndex, amount, data)
+	if err != nil {
+		return nil, err
+	}
+	receipt := &PushReceipt{
+		ChannelIndex: channelIndex,
+		StateIndex:   stateIndex,
+		Amount:       amount,
+		Time:         time.Now(),
+	}
+	copy(receipt.Data[:], data)
+	channel, err := c.GetChannelCtx(ctx, channelIndex)
+	if err != nil {
+		return receipt, err
+	}
+	receipt.LocalBalance = channel.MyBalance
+	receipt.RemoteBalance = channel.Capacity - channel.MyBalance
+	receipt.Data = channel.Data
+	return receipt, nil
+}
+
+// CloseChannelResult is like CloseChannel, but returns an OperationResult
+func (c *LitRpcClient) CloseChannelResult(channelIndex uint32) (*OperationResult, error) {
+	return c.CloseChannelResultCtx(context.Background(), channelIndex)
+}
+
+// CloseChannelResultCtx is like CloseChannelResult, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) CloseChannelResultCtx(ctx context.Context, channelIndex uint32) (*OperationResult, error) {
+	ctx, res := withResult(ctx, "LitRPC.CloseChannel")
+	err := c.CloseChannelCtx(ctx, channelIndex)
+	if err != nil {
+		return nil, err
+	}
+	res.ChannelIndex = channelIndex
+	return res, nil
+}
+
+// BreakChannelResult is like BreakChannel, but returns an OperationResult
+func (c *LitRpcClient) BreakChannelResult(channelIndex uint32) (*OperationResult, error) {
+	return c.BreakChannelResultCtx(context.Background(), channelIndex)
+}
+
+// BreakChannelResultCtx is like BreakChannelResult, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) BreakChannelResultCtx(ctx context.Context, channelIndex uint32) (*OperationResult, error) {
+	ctx, res := withResult(ctx, "LitRPC.BreakChannel")
+	err := c.BreakChannelCtx(ctx, channelIndex)
+	if err != nil {
+		return nil, err
+	}
+	res.ChannelIndex = channelIndex
+	return res, nil
+}
+
+// SettleContractResult is like SettleContract, but returns an OperationResult
+func (c *LitRpcClient) SettleContractResult(contractIndex uint64, oracleValue int64, oracleSignature []byte) (*OperationResult, error) {
+	return c.SettleContractResultCtx(context.Background(), contractIndex, oracleValue, oracleSignature)
+}
+
+// SettleContractResultCtx is like SettleContractResult, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SettleContractResultCtx(ctx context.Context, contractIndex uint64, oracleValue int64, oracleSignature []byte) (*OperationResult, error) {
+	ctx, res := withResult(ctx, "LitRPC.SettleContract")
+	err := c.SettleContractCtx(ctx, contractIndex, oracleValue, oracleSignature)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AddHTLCResult is like AddHTLC, but returns an OperationResult
+func (c *LitRpcClient) AddHTLCResult(channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (*OperationResult, error) {
+	return c.AddHTLCResultCtx(context.Background(), channelIndex, amount, lockTime, hash, data)
+}
+
+// AddHTLCResultCtx is like AddHTLCResult, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) AddHTLCResultCtx(ctx context.Context, channelIndex uint32, amount int64, lockTime uint32, hash [32]byte, data []byte) (*OperationResult, error) {
+	ctx, res := withResult(ctx, "LitRPC.AddHTLC")
+	htlcIndex, stateIndex, err := c.AddHTLCCtx(ctx, channelIndex, amount, lockTime, hash, data)
+	if err != nil {
+		return nil, err
+	}
+	res.ChannelIndex = channelIndex
+	res.HTLCIndex = htlcIndex
+	res.StateIndex = stateIndex
+	return res, nil
+}
+
+// SettleHTLCResult is like SettleHTLC, but returns an OperationResult
+func (c *LitRpcClient) SettleHTLCResult(channelIndex, htlcIndex uint32, preimage [16]byte) (*OperationResult, error) {
+	return c.SettleHTLCResultCtx(context.Background(), channelIndex, htlcIndex, preimage)
+}
+
+// SettleHTLCResultCtx is like SettleHTLCResult, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SettleHTLCResultCtx(ctx context.Context, channelIndex, htlcIndex uint32, preimage [16]byte) (*OperationResult, error) {
+	ctx, res := withResult(ctx, "LitRPC.ClearHTLC")
+	stateIndex, err := c.SettleHTLCCtx(ctx, channelIndex, htlcIndex, preimage)
+	if err != nil {
+		return nil, err
+	}
+	res.ChannelIndex = channelIndex
+	res.HTLCIndex = htlcIndex
+	res.StateIndex = stateIndex
+	return res, nil
+}
+
+// FailHTLCResult is like FailHTLC, but returns an OperationResult
+func (c *LitRpcClient) FailHTLCResult(channelIndex, htlcIndex uint32) (*OperationResult, error) {
+	return c.FailHTLCResultCtx(context.Background(), channelIndex, htlcIndex)
+}
+
+// FailHTLCResultCtx is like FailHTLCResult, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) FailHTLCResultCtx(ctx context.Context, channelIndex, htlcIndex uint32) (*OperationResult, error) {
+	ctx, res := withResult(ctx, "LitRPC.ClearHTLC")
+	stateIndex, err := c.FailHTLCCtx(ctx, channelIndex, htlcIndex)
+	if err != nil {
+		return nil, err
+	}
+	res.ChannelIndex = channelIndex
+	res.HTLCIndex = htlcIndex
+	res.StateIndex = stateIndex
+	return res, nil
+}
@@ -0,0 +1,43 @@
+package litrpcclient
+
+import "context"
+
+// Version is the version of this client library
+const Version = "1.0.0"
+
+// SelfInfo describes this client and the node it is connected to
+type SelfInfo struct {
+	// ClientVersion is the version of this library
+	ClientVersion string
+	// AppName is the application name set with WithAppName
+	AppName string
+	// NodeLNAddress is the LN address of the node the client is connected to
+	NodeLNAddress string
+}
+
+// WithAppName sets the name of the application using the client. LIT has no
+// RPC for clients to identify themselves, so the name is not sent to the node;
+// it is returned by SelfInfo so it can be included in logs and reports.
+func WithAppName(name string) Option {
+	return func(o *clientOptions) {
+		o.appName = name
+	}
+}
+
+// SelfInfo returns the identity of this client and of the node it is connected to
+func (c *LitRpcClient) SelfInfo() (*SelfInfo, error) {
+	return c.SelfInfoCtx(context.Background())
+}
+
+// SelfInfoCtx is like SelfInfo, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SelfInfoCtx(ctx context.Context) (*SelfInfo, error) {
+	adr, err := c.GetLNAddressCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &SelfInfo{
+		ClientVersion: Version,
+		AppName:       c.opts.appName,
+		NodeLNAddress: adr,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package litrpcclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mit-dci/lit-rpc-client-go/oracle"
+)
+
+// SelfTestCheck is the result of one of the checks of SelfTest
+type SelfTestCheck struct {
+	Name string `json:"name"`
+	// Err is why the check failed, nil if it passed
+	Err error `json:"-"`
+	// Warning is true for checks that found something worth looking at, that
+	// doesn't stop the client from working (like a node that doesn't listen)
+	Warning bool `json:"warning,omitempty"`
+	// Detail describes what the check found
+	Detail   string        `json:"detail"`
+	Duration time.Duration `json:"duration"`
+}
+
+// SelfTestReport is the result of SelfTest
+type SelfTestReport struct {
+	Checks []SelfTestCheck `json:"checks"`
+}
+
+// OK returns true if all checks passed, warnings included
+func (r *SelfTestReport) OK() bool {
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// SelfTest runs checks that don't change anything on the node: that it can
+// be reached and accepts the client's calls (which on remote control means
+// the key is authorized), whether it listens for peers, that balances and
+// fee rates can be read, and that the REST APIs of the oracles it knows are
+// reachable and serve the oracles' keys. It's meant for smoke tests after a
+// deploy. Checks that depend on a failed check are skipped.
+func (c *LitRpcClient) SelfTest(ctx context.Context) *SelfTestReport {
+	report := &SelfTestReport{}
+	run := func(name string, check func() (string, bool, error)) bool {
+		start := time.Now()
+		detail, warning, err := check()
+		if err != nil {
+			detail = err.Error()
+		}
+		report.Checks = append(report.Checks, SelfTestCheck{
+			Name:     name,
+			Err:      err,
+			Warning:  warning,
+			Detail:   detail,
+			Duration: time.Since(start),
+		})
+		return err == nil
+	}
+
+	ok := run("connection", func() (string, bool, error) {
+		address, err := c.GetLNAddressCtx(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		if key := c.opts.rcKey; key != nil {
+			return fmt.Sprintf("node %s, authorized as %s", address, IdentityAddress(key)), false, nil
+		}
+		return "node " + address, false, nil
+	})
+	if !ok {
+		return report
+	}
+
+	run("listening", func() (string, bool, error) {
+		listening, err := c.IsListeningCtx(ctx)
+		if err != nil || listening {
+			return "listening for peers", false, err
+		}
+		return "not listening, peers can't connect to the node", true, nil
+	})
+
+	var coinTypes []uint32
+	ok = run("balances", func() (string, bool, error) {
+		balances, err := c.ListBalancesCtx(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		for _, b := range balances {
+			coinTypes = append(coinTypes, b.CoinType)
+		}
+		if len(coinTypes) == 0 {
+			return "the node has no wallets", true, nil
+		}
+		return fmt.Sprintf("wallets for coin types %v", coinTypes), false, nil
+	})
+	if ok {
+		for _, coinType := range coinTypes {
+			run(fmt.Sprintf("fee %d", coinType), func() (string, bool, error) {
+				fee, err := c.GetFeeCtx(ctx, coinType)
+				if err != nil {
+					return "", false, err
+				}
+				return fmt.Sprintf("%d sat/byte", fee), fee <= 0, nil
+			})
+		}
+	}
+
+	oracles, err := c.ListOraclesCtx(ctx)
+	if !run("oracles", func() (string, bool, error) {
+		return fmt.Sprintf("%d oracles", len(oracles)), false, err
+	}) {
+		return report
+	}
+	for _, o := range oracles {
+		if o.Url == "" {
+			continue
+		}
+		run("oracle "+o.Name, func() (string, bool, error) {
+			pubKey, err := oracle.NewClient(o.Url).PubKey(ctx)
+			if err != nil {
+				return "", false, err
+			}
+			if pubKey != o.A {
+				return "", false, fmt.Errorf("%s serves a different key than the node has for the oracle", o.Url)
+			}
+			return o.Url + " reachable", false, nil
+		})
+	}
+	return report
+}
@@ -0,0 +1,95 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// sessionFile is the name of the call log WithRecording writes
+const sessionFile = "session.jsonl"
+
+// SessionCall is a call in the session log written by WithRecording
+type SessionCall struct {
+	Method string          `json:"method"`
+	Args   json.RawMessage `json:"args"`
+	Reply  json.RawMessage `json:"reply,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	// Remote is true if Error was returned by the node
+	Remote bool `json:"remote,omitempty"`
+}
+
+// ReplayedCall is the outcome of replaying a SessionCall
+type ReplayedCall struct {
+	SessionCall
+	// ReplayReply and ReplayErr are the reply and error of the replayed call
+	ReplayReply json.RawMessage
+	ReplayErr   error
+	// Diverged is true if the call failed in the session but succeeded on
+	// replay, or the other way around. Replies are not compared, since they
+	// contain txids, indexes and keys that differ between nodes.
+	Diverged bool
+}
+
+// LoadSession reads the session log written by WithRecording to directory [dir]
+func LoadSession(dir string) ([]SessionCall, error) {
+	f, err := os.Open(filepath.Join(dir, sessionFile))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// A decoder rather than a line scanner, since replies like channel
+	// lists have no size limit
+	var calls []SessionCall
+	dec := json.NewDecoder(f)
+	for {
+		var call SessionCall
+		err = dec.Decode(&call)
+		if err == io.EOF {
+			return calls, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+}
+
+// ReplaySession sends [calls] to the node of [client] in order, with the
+// recorded arguments, to reproduce a recorded session against a fresh
+// (regtest) node. It stops at the first call that diverges if [stopOnDiverge]
+// is true. Since indexes of channels, peers and contracts are replayed
+// verbatim, the node should start from the same state as the recorded one.
+func ReplaySession(ctx context.Context, client *LitRpcClient, calls []SessionCall, stopOnDiverge bool) ([]ReplayedCall, error) {
+	replayed := make([]ReplayedCall, 0, len(calls))
+	for _, call := range calls {
+		r := ReplayedCall{SessionCall: call}
+		r.ReplayErr = client.CallContext(ctx, call.Method, call.Args, &r.ReplayReply)
+		if ctx.Err() != nil {
+			return replayed, ctx.Err()
+		}
+		r.Diverged = (call.Error == "") != (r.ReplayErr == nil)
+		replayed = append(replayed, r)
+		if r.Diverged && stopOnDiverge {
+			break
+		}
+	}
+	return replayed, nil
+}
+
+// logCall appends [call] to the session log. Callers must hold t.mtx.
+func (t *recordTransport) logCall(call SessionCall) {
+	b, err := json.Marshal(call)
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(filepath.Join(t.dir, sessionFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.Write(append(b, '\n'))
+}
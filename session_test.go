@@ -0,0 +1,40 @@
+package litrpcclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadSessionLargeReply(t *testing.T) {
+	dir, err := ioutil.TempDir("", "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	large, _ := json.Marshal(strings.Repeat("x", 32*maxMessageSize))
+	var log []byte
+	for _, call := range []SessionCall{
+		{Method: "LitRPC.ChannelList", Args: json.RawMessage(`{}`), Reply: large},
+		{Method: "LitRPC.Balance", Args: json.RawMessage(`{}`), Error: "down"},
+	} {
+		b, _ := json.Marshal(call)
+		log = append(append(log, b...), '\n')
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, sessionFile), log, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls, err := LoadSession(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 || len(calls[0].Reply) != len(large) || calls[1].Error != "down" {
+		t.Fatalf("loaded %d calls", len(calls))
+	}
+}
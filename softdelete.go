@@ -0,0 +1,159 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/mit-dci/lit/dlc"
+	"github.com/mit-dci/lit/qln"
+)
+
+// Archive is a persisted set of oracles and peers that are hidden from
+// ListOraclesFiltered and ListConnectionsFiltered. LIT can't delete oracles or
+// forget peers, and contracts and channels keep referring to them, so
+// archiving only tidies up the lists on the client side; the node is not
+// changed.
+type Archive struct {
+	path string
+
+	mtx  sync.Mutex
+	data archiveData
+}
+
+// archiveData is the persisted content of an Archive
+type archiveData struct {
+	// Oracles are the public keys (A) of the archived oracles, hex encoded
+	Oracles map[string]bool `json:"oracles"`
+	// Peers are the indexes of the archived peers
+	Peers map[uint32]bool `json:"peers"`
+}
+
+// NewArchive creates an Archive that persists to the file at [path].
+// Previously archived oracles and peers are loaded from it.
+func NewArchive(path string) (*Archive, error) {
+	a := &Archive{
+		path: path,
+		data: archiveData{Oracles: make(map[string]bool), Peers: make(map[uint32]bool)},
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, &a.data)
+	if err != nil {
+		return nil, err
+	}
+	if a.data.Oracles == nil {
+		a.data.Oracles = make(map[string]bool)
+	}
+	if a.data.Peers == nil {
+		a.data.Peers = make(map[uint32]bool)
+	}
+	return a, nil
+}
+
+// WithArchive makes ListOraclesFiltered and ListConnectionsFiltered hide the
+// oracles and peers in [archive]
+func WithArchive(archive *Archive) Option {
+	return func(o *clientOptions) {
+		o.archive = archive
+	}
+}
+
+// ArchiveOracle hides the oracle with public key [pubKey]
+func (a *Archive) ArchiveOracle(pubKey [33]byte) error {
+	return a.update(func() { a.data.Oracles[hex.EncodeToString(pubKey[:])] = true })
+}
+
+// UnarchiveOracle shows the oracle with public key [pubKey] again
+func (a *Archive) UnarchiveOracle(pubKey [33]byte) error {
+	return a.update(func() { delete(a.data.Oracles, hex.EncodeToString(pubKey[:])) })
+}
+
+// ArchivePeer hides peer [peerIndex]
+func (a *Archive) ArchivePeer(peerIndex uint32) error {
+	return a.update(func() { a.data.Peers[peerIndex] = true })
+}
+
+// UnarchivePeer shows peer [peerIndex] again
+func (a *Archive) UnarchivePeer(peerIndex uint32) error {
+	return a.update(func() { delete(a.data.Peers, peerIndex) })
+}
+
+// OracleArchived returns true if the oracle with public key [pubKey] is archived
+func (a *Archive) OracleArchived(pubKey [33]byte) bool {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.data.Oracles[hex.EncodeToString(pubKey[:])]
+}
+
+// PeerArchived returns true if peer [peerIndex] is archived
+func (a *Archive) PeerArchived(peerIndex uint32) bool {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.data.Peers[peerIndex]
+}
+
+// update applies [change] and persists the archive
+func (a *Archive) update(change func()) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	change()
+	b, err := json.MarshalIndent(a.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(a.path, b, 0600)
+}
+
+// ListOraclesFiltered is like ListOracles, but leaves out the oracles
+// archived in the Archive set with WithArchive, unless [includeArchived] is
+// true
+func (c *LitRpcClient) ListOraclesFiltered(includeArchived bool) ([]*dlc.DlcOracle, error) {
+	return c.ListOraclesFilteredCtx(context.Background(), includeArchived)
+}
+
+// ListOraclesFilteredCtx is like ListOraclesFiltered, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListOraclesFilteredCtx(ctx context.Context, includeArchived bool) ([]*dlc.DlcOracle, error) {
+	oracles, err := c.ListOraclesCtx(ctx)
+	if err != nil || includeArchived || c.opts.archive == nil {
+		return oracles, err
+	}
+	shown := oracles[:0]
+	for _, o := range oracles {
+		if !c.opts.archive.OracleArchived(o.A) {
+			shown = append(shown, o)
+		}
+	}
+	return shown, nil
+}
+
+// ListConnectionsFiltered is like ListConnections, but leaves out the
+// peers archived in the Archive set with WithArchive, unless
+// [includeArchived] is true
+func (c *LitRpcClient) ListConnectionsFiltered(includeArchived bool) ([]qln.PeerInfo, error) {
+	return c.ListConnectionsFilteredCtx(context.Background(), includeArchived)
+}
+
+// ListConnectionsFilteredCtx is like ListConnectionsFiltered, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListConnectionsFilteredCtx(ctx context.Context, includeArchived bool) ([]qln.PeerInfo, error) {
+	peers, err := c.ListConnectionsCtx(ctx)
+	if err != nil || includeArchived || c.opts.archive == nil {
+		return peers, err
+	}
+	shown := peers[:0]
+	for _, p := range peers {
+		if !c.opts.archive.PeerArchived(p.PeerNumber) {
+			shown = append(shown, p)
+		}
+	}
+	return shown, nil
+}
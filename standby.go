@@ -0,0 +1,166 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// lease is the content of a leadership lock file
+type lease struct {
+	Owner   string    `json:"owner"`
+	Expires time.Time `json:"expires"`
+}
+
+// LeaderLock elects one of several processes that share a directory (like
+// a primary and a warm standby sharing the files of an Outbox or
+// PeerKeeper) as leader, with a lease in a file. The leader renews its lease
+// every Interval; when it stops, another process takes over once the lease
+// expires. Only the leader should run components that act on the node, so
+// payments are not executed twice; Lead does that.
+type LeaderLock struct {
+	// Interval is the time between attempts to acquire or renew the lease
+	Interval time.Duration
+	// TTL is the time a lease stays valid without being renewed. It should
+	// be several times Interval.
+	TTL time.Duration
+
+	path  string
+	owner string
+
+	mtx    sync.Mutex
+	leader bool
+	// expires is when our lease expires, if we are leader
+	expires time.Time
+}
+
+// NewLeaderLock creates a LeaderLock using the lease file at [path], for the
+// process identified by [owner], which must be unique among the processes
+// sharing the file (like the host name)
+func NewLeaderLock(path, owner string) *LeaderLock {
+	return &LeaderLock{
+		Interval: 5 * time.Second,
+		TTL:      30 * time.Second,
+		path:     path,
+		owner:    owner,
+	}
+}
+
+// IsLeader returns true if this process holds an unexpired lease
+func (l *LeaderLock) IsLeader() bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.leader && time.Now().Before(l.expires)
+}
+
+// Lead acquires and renews the lease until [ctx] is cancelled, and runs
+// [run] while this process is leader. The context passed to [run] is
+// cancelled when the lease is lost, and [run] is started again when it is
+// acquired again. Create components like Outbox and PeerKeeper inside [run],
+// so they load the state the previous leader persisted. Lead returns when
+// [ctx] is cancelled and [run] returned.
+func (l *LeaderLock) Lead(ctx context.Context, run func(ctx context.Context)) {
+	var cancelRun context.CancelFunc
+	var done chan struct{}
+	start := func() {
+		var runCtx context.Context
+		runCtx, cancelRun = context.WithCancel(ctx)
+		done = make(chan struct{})
+		go func(done chan struct{}) {
+			defer close(done)
+			run(runCtx)
+		}(done)
+	}
+	stop := func() {
+		if cancelRun != nil {
+			cancelRun()
+			<-done
+			cancelRun = nil
+		}
+	}
+	defer l.release()
+	defer stop()
+
+	ticker := time.NewTicker(l.Interval)
+	defer ticker.Stop()
+	for {
+		leader := l.tryAcquire()
+		if leader && cancelRun == nil {
+			start()
+		} else if !leader {
+			stop()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tryAcquire takes or renews the lease, and returns whether we hold it.
+// Changing the lease file is guarded by a second file created exclusively,
+// so two processes can't take over an expired lease at the same time.
+func (l *LeaderLock) tryAcquire() bool {
+	guard := l.path + ".lock"
+	f, err := os.OpenFile(guard, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		// Remove guards left behind by a process that died holding one
+		if info, statErr := os.Stat(guard); statErr == nil && time.Since(info.ModTime()) > l.TTL {
+			os.Remove(guard)
+		}
+		return l.IsLeader()
+	}
+	f.Close()
+	defer os.Remove(guard)
+
+	var current lease
+	b, err := ioutil.ReadFile(l.path)
+	if err == nil {
+		json.Unmarshal(b, &current)
+	}
+	now := time.Now()
+	if current.Owner != l.owner && now.Before(current.Expires) {
+		l.setLeader(false, time.Time{})
+		return false
+	}
+
+	next := lease{Owner: l.owner, Expires: now.Add(l.TTL)}
+	b, err = json.Marshal(next)
+	if err == nil {
+		err = writeFileAtomic(l.path, b, 0600)
+	}
+	if err != nil {
+		return l.IsLeader()
+	}
+	l.setLeader(true, next.Expires)
+	return true
+}
+
+// release gives up the lease, so a standby can take over right away
+func (l *LeaderLock) release() {
+	if !l.IsLeader() {
+		return
+	}
+	l.setLeader(false, time.Time{})
+	b, err := ioutil.ReadFile(l.path)
+	if err != nil {
+		return
+	}
+	var current lease
+	if json.Unmarshal(b, &current) == nil && current.Owner == l.owner {
+		os.Remove(l.path)
+	}
+}
+
+// setLeader records whether we are leader, and until when
+func (l *LeaderLock) setLeader(leader bool, expires time.Time) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.leader = leader
+	l.expires = expires
+}
@@ -0,0 +1,80 @@
+package litrpcclient
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mit-dci/lit/qln"
+)
+
+// StateRegressionError is returned when the node reports a state index for a
+// channel that is lower than one it reported before. This should never happen,
+// and indicates the node's database was corrupted or restored from an old backup.
+type StateRegressionError struct {
+	// ChanIdx is the index of the channel, or 0 when the regression was found
+	// in a StateDump (which identifies channels by PKH)
+	ChanIdx uint32
+	// Pkh is the channel's PKH when the regression was found in a StateDump
+	Pkh [20]byte
+	// Last is the highest state index seen before
+	Last uint64
+	// Got is the state index the node returned now
+	Got uint64
+}
+
+func (e *StateRegressionError) Error() string {
+	if e.ChanIdx == 0 {
+		return fmt.Sprintf("State index for channel with PKH %x regressed from %d to %d", e.Pkh, e.Last, e.Got)
+	}
+	return fmt.Sprintf("State index for channel %d regressed from %d to %d", e.ChanIdx, e.Last, e.Got)
+}
+
+// stateTracker keeps the last seen state index per channel
+type stateTracker struct {
+	mtx     sync.Mutex
+	byIndex map[uint32]uint64
+	byPkh   map[[20]byte]uint64
+}
+
+// checkPush records the state index returned by a push to channel [chanIdx].
+// A push always results in a new state, so the index has to increase.
+func (t *stateTracker) checkPush(chanIdx uint32, stateIdx uint64) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.byIndex == nil {
+		t.byIndex = make(map[uint32]uint64)
+	}
+	last, ok := t.byIndex[chanIdx]
+	if ok && stateIdx <= last {
+		return &StateRegressionError{ChanIdx: chanIdx, Last: last, Got: stateIdx}
+	}
+	t.byIndex[chanIdx] = stateIdx
+	return nil
+}
+
+// checkDump records the highest state index per channel found in a state dump
+// and makes sure none of them went down since the previous dump.
+func (t *stateTracker) checkDump(txs []qln.JusticeTx) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.byPkh == nil {
+		t.byPkh = make(map[[20]byte]uint64)
+	}
+	highest := make(map[[20]byte]uint64)
+	for _, tx := range txs {
+		if tx.Idx >= highest[tx.Pkh] {
+			highest[tx.Pkh] = tx.Idx
+		}
+	}
+
+	var err error
+	for pkh, idx := range highest {
+		last, ok := t.byPkh[pkh]
+		if ok && idx < last {
+			err = &StateRegressionError{Pkh: pkh, Last: last, Got: idx}
+			continue
+		}
+		t.byPkh[pkh] = idx
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+package litrpcclient
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// ConnStats are statistics about the client's connection to the node
+type ConnStats struct {
+	BytesRead    uint64
+	BytesWritten uint64
+	// MessagesSent and MessagesReceived count requests and responses
+	MessagesSent     uint64
+	MessagesReceived uint64
+	LastRead         time.Time
+	LastWrite        time.Time
+	ConnectedAt      time.Time
+	Uptime           time.Duration
+}
+
+// connStats collects the statistics of a connection
+type connStats struct {
+	mtx   sync.Mutex
+	stats ConnStats
+}
+
+// ConnStats returns statistics about the connection to the node
+func (c *LitRpcClient) ConnStats() ConnStats {
+	c.stats.mtx.Lock()
+	defer c.stats.mtx.Unlock()
+	stats := c.stats.stats
+	if !stats.ConnectedAt.IsZero() {
+		stats.Uptime = time.Since(stats.ConnectedAt)
+	}
+	return stats
+}
+
+// connected marks the start of a new connection
+func (s *connStats) connected() {
+	s.mtx.Lock()
+	s.stats.ConnectedAt = time.Now()
+	s.mtx.Unlock()
+}
+
+// sent counts a request
+func (s *connStats) sent() {
+	s.mtx.Lock()
+	s.stats.MessagesSent++
+	s.mtx.Unlock()
+}
+
+// received counts a response
+func (s *connStats) received() {
+	s.mtx.Lock()
+	s.stats.MessagesReceived++
+	s.mtx.Unlock()
+}
+
+// countingConn counts the bytes read from and written to a connection
+type countingConn struct {
+	io.ReadWriteCloser
+	stats *connStats
+}
+
+func (c *countingConn) Read(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Read(p)
+	c.stats.mtx.Lock()
+	c.stats.stats.BytesRead += uint64(n)
+	c.stats.stats.LastRead = time.Now()
+	c.stats.mtx.Unlock()
+	return n, err
+}
+
+func (c *countingConn) Write(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Write(p)
+	c.stats.mtx.Lock()
+	c.stats.stats.BytesWritten += uint64(n)
+	c.stats.stats.LastWrite = time.Now()
+	c.stats.mtx.Unlock()
+	return n, err
+}
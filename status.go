@@ -0,0 +1,91 @@
+package litrpcclient
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// Status is the interpretation of the status text a node replies with to
+// calls that don't return structured data. Fields the text doesn't mention
+// are -1 (or empty for Txid).
+type Status struct {
+	Method string
+	// Raw is the status text as returned by the node
+	Raw          string
+	ChannelIndex int64
+	PeerIndex    int64
+	Height       int64
+	Txid         string
+}
+
+// UnexpectedStatusError is returned when the status text a node replies
+// with is not recognized as success for the method. It unwraps to a
+// *RemoteError, so it matches ErrRemote and ErrUnexpectedReply.
+type UnexpectedStatusError struct {
+	Method string
+	// Status is the status text as returned by the node
+	Status string
+}
+
+func (e *UnexpectedStatusError) Error() string {
+	return "Unexpected response from server: " + e.Status
+}
+
+func (e *UnexpectedStatusError) Unwrap() error {
+	return &RemoteError{Method: e.Method, Message: e.Error(), UnexpectedReply: true}
+}
+
+// statusSuccess holds, per method, the pattern a status text has to match to
+// indicate success. The patterns only look for the words that carry the
+// meaning, so rewording the rest of the message doesn't break them.
+var statusSuccess = map[string]*regexp.Regexp{
+	"LitRPC.Connect":        regexp.MustCompile(`(?i)\bconnected\b`),
+	"LitRPC.AssignNickname": regexp.MustCompile(`(?i)\bnickname\b`),
+	"LitRPC.Stop":           regexp.MustCompile(`(?i)\bstopping\b`),
+	"LitRPC.FundChannel":    regexp.MustCompile(`(?i)\bfunded\b`),
+	"LitRPC.CloseChannel":   regexp.MustCompile(`(?i)\b(ok|closed|closing)\b`),
+	"LitRPC.BreakChannel":   regexp.MustCompile(`\S`),
+}
+
+// statusFailure matches status texts that report a failure, even if they
+// also contain the words a success pattern looks for
+var statusFailure = regexp.MustCompile(`(?i)\b(error|fail(ed|ure)?|not|can't|cannot|unable)\b`)
+
+var (
+	statusChannel = regexp.MustCompile(`(?i)\bchan(?:nel)?\s*(?:idx|index)?\s*#?(\d+)`)
+	statusPeer    = regexp.MustCompile(`(?i)\bpeer\s*(?:idx|index)?\s*#?(\d+)\b`)
+	statusHeight  = regexp.MustCompile(`(?i)\bheight\s*:?\s*(\d+)`)
+	statusTxid    = regexp.MustCompile(`\b[0-9a-fA-F]{64}\b`)
+)
+
+// ParseStatus interprets status text [status] that the node replied with to
+// RPC [method]. It returns an *UnexpectedStatusError if the text doesn't
+// indicate success. Methods without a known pattern accept any text that
+// doesn't report a failure.
+func ParseStatus(method, status string) (*Status, error) {
+	success, ok := statusSuccess[method]
+	if (ok && !success.MatchString(status)) || statusFailure.MatchString(status) {
+		return nil, &UnexpectedStatusError{Method: method, Status: status}
+	}
+	return &Status{
+		Method:       method,
+		Raw:          status,
+		ChannelIndex: statusNumber(statusChannel, status),
+		PeerIndex:    statusNumber(statusPeer, status),
+		Height:       statusNumber(statusHeight, status),
+		Txid:         statusTxid.FindString(status),
+	}, nil
+}
+
+// statusNumber returns the number captured by [re] in [status], or -1
+func statusNumber(re *regexp.Regexp, status string) int64 {
+	m := re.FindStringSubmatch(status)
+	if m == nil {
+		return -1
+	}
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
@@ -0,0 +1,154 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// streamInterval is the time between pushes of a payment stream
+const streamInterval = time.Second
+
+// StreamSummary is the accounting of a payment stream
+type StreamSummary struct {
+	// Paid is the total amount pushed so far
+	Paid int64
+	// Pushes is the number of pushes made
+	Pushes int
+	// LastStateIndex is the channel's state index after the last push
+	LastStateIndex uint64
+	// Err is the error that stopped the stream, if any
+	Err error
+}
+
+// PaymentStream is a stream of payments started with Stream
+type PaymentStream struct {
+	channelIndex  uint32
+	ratePerSecond int64
+	totalCap      int64
+
+	mtx     sync.Mutex
+	paused  bool
+	summary StreamSummary
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// Stream pays [ratePerSecond] satoshi per second through channel [channelIndex],
+// by pushing the amount due every second, until [totalCap] satoshi has been paid,
+// the stream is stopped or [ctx] is cancelled. Time spent paused is not paid for.
+// If a push fails the stream stops, see Summary().Err.
+func (c *LitRpcClient) Stream(ctx context.Context, channelIndex uint32, ratePerSecond, totalCap int64) *PaymentStream {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &PaymentStream{
+		channelIndex:  channelIndex,
+		ratePerSecond: ratePerSecond,
+		totalCap:      totalCap,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+	}
+	go c.runStream(ctx, s)
+	return s
+}
+
+// Pause stops paying until Resume is called
+func (s *PaymentStream) Pause() {
+	s.mtx.Lock()
+	s.paused = true
+	s.mtx.Unlock()
+}
+
+// Resume continues paying after Pause
+func (s *PaymentStream) Resume() {
+	s.mtx.Lock()
+	s.paused = false
+	s.mtx.Unlock()
+}
+
+// Stop ends the stream. A push that is in flight is not interrupted, so it
+// is counted in Summary once it completes; use Done to wait for that.
+func (s *PaymentStream) Stop() {
+	s.cancel()
+}
+
+// Done returns a channel that's closed when the stream has ended
+func (s *PaymentStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Summary returns the accounting of the stream so far
+func (s *PaymentStream) Summary() StreamSummary {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.summary
+}
+
+// runStream pushes the amount due every streamInterval. Pushes are made with
+// a context that isn't cancelled with the stream, so stopping can't abandon
+// a push the node executes without it being counted.
+func (c *LitRpcClient) runStream(ctx context.Context, s *PaymentStream) {
+	defer close(s.done)
+	defer s.cancel()
+	ticker := time.NewTicker(streamInterval)
+	defer ticker.Stop()
+
+	// owed accumulates time that has to be paid for, so pushes that take
+	// longer than the interval are caught up by the next one
+	var owed time.Duration
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.mtx.Lock()
+			paused := s.paused
+			paid := s.summary.Paid
+			s.mtx.Unlock()
+			if !paused {
+				owed += now.Sub(last)
+			}
+			last = now
+
+			amount := int64(owed) * s.ratePerSecond / int64(time.Second)
+			if amount > s.totalCap-paid {
+				amount = s.totalCap - paid
+			}
+			if amount <= 0 {
+				continue
+			}
+			owed -= time.Duration(amount * int64(time.Second) / s.ratePerSecond)
+
+			stateIdx, err := c.PushCtx(detachedContext{ctx}, s.channelIndex, amount, nil)
+			s.mtx.Lock()
+			// A state regression is reported after the push went through
+			var regression *StateRegressionError
+			if err == nil || errors.As(err, &regression) {
+				s.summary.Paid += amount
+				s.summary.Pushes++
+				s.summary.LastStateIndex = stateIdx
+			}
+			s.summary.Err = err
+			done := err != nil || s.summary.Paid >= s.totalCap
+			s.mtx.Unlock()
+			if done {
+				return
+			}
+		}
+	}
+}
+
+// detachedContext carries the values of a context, but not its cancellation
+// or deadline
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
@@ -0,0 +1,39 @@
+package litrpcclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+func TestStreamStopCountsInFlightPush(t *testing.T) {
+	node := newFakeNode()
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	node.handle("LitRPC.Push", func(interface{}) (interface{}, error) {
+		close(arrived)
+		<-release
+		return litrpc.PushReply{StateIndex: 5}, nil
+	})
+	client := newTestClient(node)
+
+	s := client.Stream(context.Background(), 1, 1000, 100000)
+	select {
+	case <-arrived:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no push")
+	}
+	s.Stop()
+	close(release)
+	<-s.Done()
+
+	summary := s.Summary()
+	if summary.Err != nil {
+		t.Fatalf("stream failed: %v", summary.Err)
+	}
+	if summary.Pushes != 1 || summary.Paid == 0 || summary.LastStateIndex != 5 {
+		t.Fatalf("push that completed after Stop not counted: %+v", summary)
+	}
+}
@@ -0,0 +1,70 @@
+package litrpcclient
+
+import (
+	"context"
+
+	"github.com/mit-dci/lit/litrpc"
+)
+
+// coinBech32Prefixes are the bech32 prefixes of the coin types LIT supports.
+// LIT reports the coin of a UTXO by its prefix.
+var coinBech32Prefixes = map[uint32]string{
+	0:     "bc",
+	1:     "tb",
+	257:   "bcrt",
+	2:     "ltc",
+	65537: "tltc",
+	258:   "rltc",
+	28:    "vtc",
+	65536: "tvtc",
+	261:   "rvtc",
+}
+
+// Sweep moves all funds in the wallet for coin type [coinType] to
+// [destAddress], using one transaction per UTXO, and returns the ids of those
+// transactions. When [dryRun] is true nothing is sent, and the outpoints of
+// the UTXOs that would be swept are returned instead. LIT picks the wallet
+// from the address; [coinType] selects the UTXOs to count, for coin types the
+// client doesn't know all UTXOs are counted.
+func (c *LitRpcClient) Sweep(coinType uint32, destAddress string, dryRun bool) ([]string, error) {
+	return c.SweepCtx(context.Background(), coinType, destAddress, dryRun)
+}
+
+// SweepCtx is like Sweep, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SweepCtx(ctx context.Context, coinType uint32, destAddress string, dryRun bool) ([]string, error) {
+	utxos, err := c.ListUtxosCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prefix, known := coinBech32Prefixes[coinType]
+	outpoints := []string{}
+	var total int64
+	for _, utxo := range utxos {
+		if !known || utxo.CoinType == prefix {
+			outpoints = append(outpoints, utxo.OutPoint)
+			total += utxo.Amt
+		}
+	}
+	if dryRun || len(outpoints) == 0 {
+		return outpoints, nil
+	}
+	settle, err := c.chargeBudget(ctx, "LitRPC.Sweep", coinType, known, sendTxSize*int64(len(outpoints)), total)
+	if err != nil {
+		return nil, err
+	}
+
+	c.warnAddressReuse(c.addresses.send(destAddress))
+	args := new(litrpc.SweepArgs)
+	args.DestAdr = destAddress
+	args.NumTx = uint32(len(outpoints))
+	reply := new(litrpc.TxidsReply)
+	err = c.callCtx(ctx, "LitRPC.Sweep", args, reply)
+	settle(err)
+	if err != nil {
+		return nil, err
+	}
+	if reply.Txids == nil {
+		return nil, remoteError("LitRPC.Sweep", "Unexpected response from server")
+	}
+	return reply.Txids, nil
+}
@@ -0,0 +1,59 @@
+package litrpcclient
+
+import "context"
+
+// CoinSyncStatus is the state of the blockchain sync of the node for a coin type
+type CoinSyncStatus struct {
+	CoinType uint32
+	// SyncHeight is the height up to which the node's wallet processed blocks
+	SyncHeight int32
+	// HeaderHeight is the height of the chain tip, from the source set with
+	// WithChainTip. It is 0 if no source is set for the coin type, since LIT
+	// only reports the height its wallet is synced to.
+	HeaderHeight int32
+	// Behind is the number of blocks the wallet is behind the chain tip, or 0
+	// if HeaderHeight is unknown
+	Behind int32
+}
+
+// WithChainTip sets [tip] as the source of the chain tip height of coin type
+// [coinType] for SyncStatus, like a block explorer or a full node of the coin
+func WithChainTip(coinType uint32, tip func(ctx context.Context) (int32, error)) Option {
+	return func(o *clientOptions) {
+		if o.chainTips == nil {
+			o.chainTips = make(map[uint32]func(context.Context) (int32, error))
+		}
+		o.chainTips[coinType] = tip
+	}
+}
+
+// SyncStatus returns how far the node's wallet is synced for each coin type
+// it has a wallet for, so applications can hold off payments while the node
+// is behind
+func (c *LitRpcClient) SyncStatus() ([]CoinSyncStatus, error) {
+	return c.SyncStatusCtx(context.Background())
+}
+
+// SyncStatusCtx is like SyncStatus, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) SyncStatusCtx(ctx context.Context) ([]CoinSyncStatus, error) {
+	balances, err := c.ListBalancesCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]CoinSyncStatus, 0, len(balances))
+	for _, b := range balances {
+		status := CoinSyncStatus{CoinType: b.CoinType, SyncHeight: b.SyncHeight}
+		if tip, ok := c.opts.chainTips[b.CoinType]; ok {
+			status.HeaderHeight, err = tip(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if status.HeaderHeight > status.SyncHeight {
+				status.Behind = status.HeaderHeight - status.SyncHeight
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,162 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"time"
+)
+
+// redacted replaces the values of redacted fields in error reports
+const redacted = "[redacted]"
+
+// Fields in call arguments that hold keys, amounts or addresses
+var (
+	keyFields     = []string{"Key", "PubKey", "OracleSig", "RPoint", "Sig", "Data"}
+	amountFields  = []string{"Amt", "Amts", "Amount", "Capacity", "InitialSend", "OurAmount", "TheirAmount", "Fee"}
+	addressFields = []string{"DestAddrs", "DestAdr", "DestLNAdr", "LNAddr", "Url"}
+)
+
+// RedactionPolicy selects which call arguments are removed from error reports
+type RedactionPolicy struct {
+	Keys      bool
+	Amounts   bool
+	Addresses bool
+}
+
+// Patterns of keys, addresses and amounts in error texts
+var (
+	keyPattern     = regexp.MustCompile(`\b[0-9a-fA-F]{64,}\b`)
+	addressPattern = regexp.MustCompile(`(?i)\b(ln|bc|tb|bcrt|ltc|tltc|rltc|vtc|tvtc|rvtc)1[02-9ac-hj-np-z]{6,}\b` +
+		`|\b[123mn][1-9A-HJ-NP-Za-km-z]{25,34}\b|https?://\S+|@\S+|\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`)
+	amountPattern = regexp.MustCompile(`\b\d+\b`)
+)
+
+// ErrorReport describes a failed call
+type ErrorReport struct {
+	Time   time.Time
+	Method string
+	// Args are the call's arguments, with fields removed according to the
+	// RedactionPolicy, at any depth
+	Args map[string]interface{}
+	// Error is the error the call failed with. Its text has the keys,
+	// amounts and addresses the RedactionPolicy removes replaced; errors.Is
+	// still matches the package's error values.
+	Error error
+	// CorrelationID is the id set on the call's context with WithCorrelationID
+	CorrelationID string
+}
+
+// ErrorSink receives reports about failed calls, for instance to forward them to
+// an error aggregation service
+type ErrorSink interface {
+	CaptureError(report ErrorReport)
+}
+
+// WithErrorSink makes the client report every failed call to [sink]. Call
+// arguments and error texts are redacted according to [policy] before they
+// are reported.
+func WithErrorSink(sink ErrorSink, policy RedactionPolicy) Option {
+	return func(o *clientOptions) {
+		o.errorSink = sink
+		o.redaction = policy
+	}
+}
+
+// reportError sends a report for a failed call of [method] to the error sink
+func (c *LitRpcClient) reportError(ctx context.Context, method string, args interface{}, err error) {
+	if c.opts.errorSink == nil {
+		return
+	}
+	c.opts.errorSink.CaptureError(ErrorReport{
+		Time:          time.Now(),
+		Method:        method,
+		Args:          c.opts.redaction.redact(args),
+		Error:         c.opts.redaction.redactError(err),
+		CorrelationID: CorrelationID(ctx),
+	})
+}
+
+// redact converts call arguments into a map without the fields the policy
+// removes, at any depth
+func (p RedactionPolicy) redact(args interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	b, err := json.Marshal(args)
+	if err != nil {
+		return fields
+	}
+	json.Unmarshal(b, &fields)
+
+	remove := make(map[string]bool)
+	if p.Keys {
+		for _, name := range keyFields {
+			remove[name] = true
+		}
+	}
+	if p.Amounts {
+		for _, name := range amountFields {
+			remove[name] = true
+		}
+	}
+	if p.Addresses {
+		for _, name := range addressFields {
+			remove[name] = true
+		}
+	}
+	redactFields(fields, remove)
+	return fields
+}
+
+// redactFields replaces the values of the fields in [remove] within the
+// decoded JSON [value]
+func redactFields(value interface{}, remove map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			if remove[name] {
+				v[name] = redacted
+			} else {
+				redactFields(field, remove)
+			}
+		}
+	case []interface{}:
+		for _, elem := range v {
+			redactFields(elem, remove)
+		}
+	}
+}
+
+// redactError returns [err] with the keys, addresses and amounts the policy
+// removes replaced in its text
+func (p RedactionPolicy) redactError(err error) error {
+	if !p.Keys && !p.Amounts && !p.Addresses {
+		return err
+	}
+	text := err.Error()
+	if p.Keys {
+		text = keyPattern.ReplaceAllString(text, redacted)
+	}
+	if p.Addresses {
+		text = addressPattern.ReplaceAllString(text, redacted)
+	}
+	if p.Amounts {
+		text = amountPattern.ReplaceAllString(text, redacted)
+	}
+	return &redactedError{text: text, err: err}
+}
+
+// redactedError is an error with a redacted text. It doesn't unwrap, so the
+// original text can't be retrieved, but matches what the original matches.
+type redactedError struct {
+	text string
+	err  error
+}
+
+func (e *redactedError) Error() string {
+	return e.text
+}
+
+func (e *redactedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
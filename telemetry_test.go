@@ -0,0 +1,49 @@
+package litrpcclient
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRedactNestedArgs(t *testing.T) {
+	args := map[string]interface{}{
+		"Method": "LitRPC.Send",
+		"Args": map[string]interface{}{
+			"DestAddrs": []string{"tb1qxyz"},
+			"Amts":      []int64{1000},
+			"Outputs":   []interface{}{map[string]interface{}{"Amount": 5, "PubKey": "02ab"}},
+		},
+	}
+	fields := RedactionPolicy{Keys: true, Amounts: true, Addresses: true}.redact(args)
+	nested := fields["Args"].(map[string]interface{})
+	if nested["DestAddrs"] != redacted || nested["Amts"] != redacted {
+		t.Errorf("nested fields not redacted: %v", nested)
+	}
+	output := nested["Outputs"].([]interface{})[0].(map[string]interface{})
+	if output["Amount"] != redacted || output["PubKey"] != redacted {
+		t.Errorf("fields in nested list not redacted: %v", output)
+	}
+	if fields["Method"] != "LitRPC.Send" {
+		t.Errorf("unrelated field changed: %v", fields["Method"])
+	}
+}
+
+func TestRedactErrorText(t *testing.T) {
+	err := &RemoteError{Method: "LitRPC.Send", Message: "can't send 150000 to " +
+		"tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx from ln1qkx3d9e2ev2kn0e46ayg7pz9g6k2fs5wv4ahu8@203.0.113.5:2448, key " +
+		strings.Repeat("ab", 33)}
+	redactedErr := RedactionPolicy{Keys: true, Amounts: true, Addresses: true}.redactError(err)
+	text := redactedErr.Error()
+	for _, leak := range []string{"150000", "tb1q", "ln1q", "203.0.113.5", "abab"} {
+		if strings.Contains(text, leak) {
+			t.Errorf("%q leaks %q", text, leak)
+		}
+	}
+	if !errors.Is(redactedErr, ErrRemote) {
+		t.Error("redacted error doesn't match ErrRemote")
+	}
+	if (RedactionPolicy{}).redactError(err) != error(err) {
+		t.Error("error changed without redaction")
+	}
+}
@@ -0,0 +1,62 @@
+package litrpcclient
+
+import (
+	"context"
+	"time"
+)
+
+// WithTimeout makes calls fail with ErrTimeout when the node doesn't reply
+// within [timeout]. By default calls have no timeout. Calls whose context
+// has a deadline use that deadline instead, and calls made with a context from
+// WithoutTimeout have no timeout at all.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithMethodTimeout overrides the timeout set with WithTimeout for RPC
+// [method] (like "LitRPC.FundChannel"), for calls that routinely take longer.
+// A [timeout] of 0 means calls to [method] have no timeout.
+func WithMethodTimeout(method string, timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		if o.methodTimeouts == nil {
+			o.methodTimeouts = make(map[string]time.Duration)
+		}
+		o.methodTimeouts[method] = timeout
+	}
+}
+
+type noTimeoutKey struct{}
+
+// WithoutTimeout returns a context that disables the timeout set with
+// WithTimeout or WithMethodTimeout for calls made with it, for long-running
+// operations
+func WithoutTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noTimeoutKey{}, true)
+}
+
+// callContext applies the timeout for [method] to [ctx]
+func (c *LitRpcClient) callContext(ctx context.Context, method string) (context.Context, context.CancelFunc) {
+	timeout := c.callTimeout(ctx, method)
+	if timeout == 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// callTimeout returns the timeout that applies to a call of [method] with
+// [ctx], or 0 if there is none, or the deadline of [ctx] applies
+func (c *LitRpcClient) callTimeout(ctx context.Context, method string) time.Duration {
+	timeout, ok := c.opts.methodTimeouts[method]
+	if !ok {
+		timeout = c.opts.timeout
+	}
+	if ctx.Value(noTimeoutKey{}) != nil {
+		return 0
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return 0
+	}
+	return timeout
+}
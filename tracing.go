@@ -0,0 +1,73 @@
+package litrpcclient
+
+import "context"
+
+// Tracer starts spans for the RPC calls of a client, so their latency shows
+// up in distributed traces. It's a small interface so the package doesn't
+// depend on a tracing library; an OpenTelemetry adapter looks like:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) StartSpan(ctx context.Context, method string) (context.Context, litrpcclient.Span) {
+//		ctx, span := t.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+//		return ctx, otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) SetAttribute(key string, value interface{}) {
+//		s.Span.SetAttributes(attribute.String(key, fmt.Sprint(value)))
+//	}
+//
+//	func (s otelSpan) End(err error) {
+//		if err != nil {
+//			s.RecordError(err)
+//			s.SetStatus(codes.Error, err.Error())
+//		}
+//		s.Span.End()
+//	}
+type Tracer interface {
+	// StartSpan starts a span for a call of RPC [method], as a child of the
+	// span in [ctx], and returns a context that carries the new span
+	StartSpan(ctx context.Context, method string) (context.Context, Span)
+}
+
+// Span is a single traced call, started by a Tracer
+type Span interface {
+	// SetAttribute adds information about the call to the span
+	SetAttribute(key string, value interface{})
+	// End ends the span with the result of the call
+	End(err error)
+}
+
+// WithTracer makes the client start a span with [tracer] for every call,
+// from the context passed to the call. The span covers the whole call,
+// including calls the client refuses without sending, and gets the
+// attributes "rpc.system", "rpc.method" and, on remote control connections,
+// "lit.nonce" (the index of the call on the connection).
+func WithTracer(tracer Tracer) Option {
+	return func(o *clientOptions) {
+		o.tracer = tracer
+	}
+}
+
+// spanKey is the context key of the span of the current call
+type spanKey struct{}
+
+// startSpan starts the span of a call of [method], or returns a nil span
+// when the client has no tracer
+func (c *LitRpcClient) startSpan(ctx context.Context, method string) (context.Context, Span) {
+	if c.opts.tracer == nil {
+		return ctx, nil
+	}
+	ctx, span := c.opts.tracer.StartSpan(ctx, method)
+	span.SetAttribute("rpc.system", "jsonrpc")
+	span.SetAttribute("rpc.method", method)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// spanFromContext returns the span of the current call, or nil
+func spanFromContext(ctx context.Context) Span {
+	span, _ := ctx.Value(spanKey{}).(Span)
+	return span
+}
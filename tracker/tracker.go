@@ -0,0 +1,107 @@
+// Package tracker is a client for LIT trackers, the services LIT nodes use to
+// find the network address of a node from its LN address.
+package tracker
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// ErrNotFound is returned by Lookup when the tracker doesn't know the node
+var ErrNotFound = errors.New("node not found on tracker")
+
+// ErrPubKeyMismatch is returned by Lookup when the public key the tracker
+// returned does not belong to the requested LN address
+var ErrPubKeyMismatch = errors.New("tracker returned public key of another node")
+
+// Node is a node as registered on the tracker
+type Node struct {
+	// LNAddress is the node's LN address
+	LNAddress string
+	// IPv4 and IPv6 are the host:port addresses the node listens on, either
+	// can be empty
+	IPv4 string
+	IPv6 string
+	// PubKey is the node's public key
+	PubKey [33]byte
+}
+
+// Client looks up nodes on a tracker
+type Client struct {
+	// URL is the base URL of the tracker, like "http://hubris.media.mit.edu:46580"
+	URL string
+	// HTTPClient is used for requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Client for the tracker at [url]
+func NewClient(url string) *Client {
+	return &Client{URL: strings.TrimSuffix(url, "/")}
+}
+
+// Lookup returns the node with LN address [lnAddr]. The public key returned
+// by the tracker is checked against [lnAddr], so a tracker can't send us to
+// another node.
+func (c *Client) Lookup(ctx context.Context, lnAddr string) (*Node, error) {
+	req, err := http.NewRequest("GET", c.URL+"/"+lnAddr, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tracker lookup of %s: %s", lnAddr, resp.Status)
+	}
+
+	var reply struct {
+		Success bool
+		Node    struct {
+			IPv4   string
+			IPv6   string
+			PubKey string
+		}
+	}
+	err = json.NewDecoder(resp.Body).Decode(&reply)
+	if err != nil {
+		return nil, err
+	}
+	if !reply.Success {
+		return nil, ErrNotFound
+	}
+
+	node := &Node{LNAddress: lnAddr, IPv4: reply.Node.IPv4, IPv6: reply.Node.IPv6}
+	pub, err := hex.DecodeString(reply.Node.PubKey)
+	if err != nil || len(pub) != len(node.PubKey) {
+		return nil, fmt.Errorf("tracker returned invalid public key %q", reply.Node.PubKey)
+	}
+	copy(node.PubKey[:], pub)
+	if lnutil.LitAdrFromPubkey(node.PubKey) != lnAddr {
+		return nil, ErrPubKeyMismatch
+	}
+	return node, nil
+}
+
+// HostPort returns the address to connect to the node on, preferring IPv4
+func (n *Node) HostPort() string {
+	if n.IPv4 != "" {
+		return n.IPv4
+	}
+	return n.IPv6
+}
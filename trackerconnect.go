@@ -0,0 +1,35 @@
+package litrpcclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/mit-dci/lit-rpc-client-go/tracker"
+)
+
+// ConnectViaTracker looks up the node with LN address [address] on tracker
+// [t] and connects to it on the address found there. Unlike Connect with an
+// empty host, which uses the node's own tracker, this uses a tracker chosen
+// by the application, and verifies the tracker's answer.
+func (c *LitRpcClient) ConnectViaTracker(t *tracker.Client, address string) error {
+	return c.ConnectViaTrackerCtx(context.Background(), t, address)
+}
+
+// ConnectViaTrackerCtx is like ConnectViaTracker, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ConnectViaTrackerCtx(ctx context.Context, t *tracker.Client, address string) error {
+	node, err := t.Lookup(ctx, address)
+	if err != nil {
+		return err
+	}
+	host, portStr, err := net.SplitHostPort(node.HostPort())
+	if err != nil {
+		return fmt.Errorf("tracker returned invalid address for %s: %w", address, err)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 32)
+	if err != nil {
+		return fmt.Errorf("tracker returned invalid port for %s: %w", address, err)
+	}
+	return c.ConnectCtx(ctx, address, host, uint32(port))
+}
@@ -0,0 +1,178 @@
+package litrpcclient
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrTxNotFound is returned by GetTransaction when the transaction is not
+// known
+var ErrTxNotFound = errors.New("transaction not found")
+
+// TxInput is an input of a Transaction
+type TxInput struct {
+	// OutPoint is the output it spends, as txid:index
+	OutPoint string
+	// Amount is the value of the spent output, or 0 if unknown
+	Amount int64
+}
+
+// TxOutput is an output of a Transaction
+type TxOutput struct {
+	Index  uint32
+	Amount int64
+	// Address is the address the output pays to, if known
+	Address string
+	// Mine is true if the output is an unspent output of the node's wallet
+	Mine bool
+}
+
+// Transaction holds the details of an on-chain transaction
+type Transaction struct {
+	Txid     string
+	CoinType uint32
+	Inputs   []TxInput
+	Outputs  []TxOutput
+	// Fee is the fee the transaction paid, or -1 if unknown
+	Fee int64
+	// Height is the height of the block that contains the transaction, 0 if
+	// unconfirmed
+	Height int32
+	// Confirmations is the number of blocks on top of (and including) the
+	// block that contains the transaction
+	Confirmations int32
+}
+
+// ChainBackend looks up transactions on a blockchain, like a block explorer
+// or a full node of the coin
+type ChainBackend interface {
+	// Transaction returns the transaction with id [txid], or an error
+	// matching ErrTxNotFound
+	Transaction(ctx context.Context, txid string) (*Transaction, error)
+}
+
+// WithChainBackend sets [backend] as the source of transaction details of
+// coin type [coinType] for GetTransaction
+func WithChainBackend(coinType uint32, backend ChainBackend) Option {
+	return func(o *clientOptions) {
+		if o.chainBackends == nil {
+			o.chainBackends = make(map[uint32]ChainBackend)
+		}
+		o.chainBackends[coinType] = backend
+	}
+}
+
+// GetTransaction returns the details of transaction [txid] of coin type
+// [coinType]. LIT has no RPC to look up transactions, so the details come
+// from the backend set with WithChainBackend. Without a backend, only the
+// outputs of the transaction that are unspent outputs of the node's wallet
+// are known, so the inputs are missing and the fee is -1; transactions
+// without such outputs return ErrTxNotFound.
+func (c *LitRpcClient) GetTransaction(txid string, coinType uint32) (*Transaction, error) {
+	return c.GetTransactionCtx(context.Background(), txid, coinType)
+}
+
+// GetTransactionCtx is like GetTransaction, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) GetTransactionCtx(ctx context.Context, txid string, coinType uint32) (*Transaction, error) {
+	utxos, err := c.ListUtxosCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mine := make(map[uint32]int64)
+	var height int32
+	prefix, known := coinBech32Prefixes[coinType]
+	for _, utxo := range utxos {
+		if known && utxo.CoinType != prefix {
+			continue
+		}
+		sep := strings.LastIndex(utxo.OutPoint, ":")
+		if sep < 0 || utxo.OutPoint[:sep] != txid {
+			continue
+		}
+		index, err := strconv.ParseUint(utxo.OutPoint[sep+1:], 10, 32)
+		if err != nil {
+			continue
+		}
+		mine[uint32(index)] = utxo.Amt
+		height = utxo.Height
+	}
+
+	var tx *Transaction
+	if backend, ok := c.opts.chainBackends[coinType]; ok {
+		tx, err = backend.Transaction(ctx, txid)
+		if err != nil {
+			return nil, err
+		}
+		for i := range tx.Outputs {
+			_, tx.Outputs[i].Mine = mine[tx.Outputs[i].Index]
+		}
+	} else {
+		if len(mine) == 0 {
+			return nil, ErrTxNotFound
+		}
+		tx = &Transaction{Txid: txid, CoinType: coinType, Fee: -1, Height: height}
+		for index, amount := range mine {
+			tx.Outputs = append(tx.Outputs, TxOutput{Index: index, Amount: amount, Mine: true})
+		}
+		sort.Slice(tx.Outputs, func(i, j int) bool { return tx.Outputs[i].Index < tx.Outputs[j].Index })
+	}
+
+	if tx.Confirmations == 0 && tx.Height > 0 {
+		tx.Confirmations = c.confirmations(ctx, coinType, tx.Height)
+	}
+	return tx, nil
+}
+
+// confirmations returns the confirmations of a block at [height], according
+// to the sync height of the node's wallet of coin type [coinType]
+func (c *LitRpcClient) confirmations(ctx context.Context, coinType uint32, height int32) int32 {
+	balances, err := c.ListBalancesCtx(ctx)
+	if err != nil {
+		return 0
+	}
+	for _, b := range balances {
+		if b.CoinType == coinType && b.SyncHeight >= height {
+			return b.SyncHeight - height + 1
+		}
+	}
+	return 0
+}
+
+// TxBroadcaster is implemented by a ChainBackend that can broadcast
+// transactions
+type TxBroadcaster interface {
+	// Broadcast broadcasts the serialized transaction [rawTx] and returns
+	// its txid
+	Broadcast(ctx context.Context, rawTx []byte) (string, error)
+}
+
+// PushTx broadcasts the externally built transaction [rawTxHex] (hex
+// encoded) of coin type [coinType], like an exported justice transaction,
+// and returns its txid. LIT doesn't expose the broadcast of raw
+// transactions over RPC, so the transaction is broadcast by the backend set
+// with WithChainBackend, which has to implement TxBroadcaster. Without one,
+// PushTx returns ErrNotSupported.
+func (c *LitRpcClient) PushTx(rawTxHex string, coinType uint32) (string, error) {
+	return c.PushTxCtx(context.Background(), rawTxHex, coinType)
+}
+
+// PushTxCtx is like PushTx, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) PushTxCtx(ctx context.Context, rawTxHex string, coinType uint32) (string, error) {
+	rawTx, err := hex.DecodeString(rawTxHex)
+	if err != nil {
+		return "", err
+	}
+	if c.opts.readOnly {
+		return "", ErrReadOnly
+	}
+	broadcaster, ok := c.opts.chainBackends[coinType].(TxBroadcaster)
+	if !ok {
+		return "", fmt.Errorf("broadcast of coin type %d: %w", coinType, ErrNotSupported)
+	}
+	return broadcaster.Broadcast(ctx, rawTx)
+}
@@ -0,0 +1,216 @@
+package litrpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+// transport carries JSON-RPC calls to the node. Errors returned by the node
+// itself are returned as rpc.ServerError (or *numberedServerError for
+// transports that number their calls), and calls on a closed transport
+// return rpc.ErrShutdown, regardless of the underlying connection.
+type transport interface {
+	// Go sends a call of [method] with [args] and returns without waiting
+	// for the reply. [done] is called once: with nil after the reply was
+	// decoded into [reply], or with the error of the call. Transports call it
+	// from the loop that receives the replies, so calls in flight don't each
+	// need a goroutine waiting for them; it can also be called before Go
+	// returns. The returned cancel abandons the call: unless the call
+	// completed or its reply is being decoded, [done] is called with [err].
+	// [ctx] only provides values, like the span of the call.
+	Go(ctx context.Context, method string, args interface{}, reply interface{}, done func(error)) (cancel func(err error))
+	Close() error
+}
+
+// noCancel is the cancel function of calls that completed in Go
+func noCancel(error) {}
+
+// callTransport makes a call with [t] and waits for it to complete. When
+// [ctx] is done first, the call is abandoned and ctx.Err() returned.
+func callTransport(ctx context.Context, t transport, method string, args interface{}, reply interface{}) error {
+	result := make(chan error, 1)
+	cancel := t.Go(ctx, method, args, reply, func(err error) { result <- err })
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		cancel(ctx.Err())
+		// A reply that is being decoded completes the call instead, so
+		// [reply] isn't written to after returning
+		return <-result
+	}
+}
+
+// rpcTransport carries calls over a JSON-RPC connection. Like net/rpc's
+// client it numbers the calls and matches the replies to them in a receive
+// loop, but it completes calls with a function instead of a channel, so
+// CallAsync needs no goroutine per call.
+type rpcTransport struct {
+	codec rpc.ClientCodec
+
+	sendMtx sync.Mutex
+
+	mtx      sync.Mutex
+	seq      uint64
+	pending  map[uint64]*rpcCall
+	closing  bool  // Close was called
+	shutdown error // set when the receive loop ended
+}
+
+// rpcCall is a call waiting for its reply
+type rpcCall struct {
+	reply interface{}
+	done  func(error)
+}
+
+// newRPCTransport makes calls over [codec], and starts receiving the replies
+func newRPCTransport(codec rpc.ClientCodec) *rpcTransport {
+	t := &rpcTransport{codec: codec, pending: make(map[uint64]*rpcCall)}
+	go t.receiveLoop()
+	return t
+}
+
+func (t *rpcTransport) Go(ctx context.Context, method string, args interface{}, reply interface{}, done func(error)) func(error) {
+	t.mtx.Lock()
+	if t.closing || t.shutdown != nil {
+		err := t.shutdown
+		if t.closing || err == nil {
+			err = rpc.ErrShutdown
+		}
+		t.mtx.Unlock()
+		done(err)
+		return noCancel
+	}
+	seq := t.seq
+	t.seq++
+	t.pending[seq] = &rpcCall{reply, done}
+	t.mtx.Unlock()
+
+	t.sendMtx.Lock()
+	err := t.codec.WriteRequest(&rpc.Request{ServiceMethod: method, Seq: seq}, args)
+	t.sendMtx.Unlock()
+	if err != nil {
+		t.fail(seq, err)
+		return noCancel
+	}
+	return func(err error) { t.fail(seq, err) }
+}
+
+// fail completes call [seq] with [err], if it is still waiting for its reply
+func (t *rpcTransport) fail(seq uint64, err error) {
+	t.mtx.Lock()
+	call := t.pending[seq]
+	delete(t.pending, seq)
+	t.mtx.Unlock()
+	if call != nil {
+		call.done(err)
+	}
+}
+
+// receiveLoop reads the replies and completes their calls, until the
+// connection fails or is closed. Then it fails the pending calls, with
+// rpc.ErrShutdown if the transport was closed.
+func (t *rpcTransport) receiveLoop() {
+	var err error
+	for err == nil {
+		var response rpc.Response
+		err = t.codec.ReadResponseHeader(&response)
+		if err != nil {
+			break
+		}
+		t.mtx.Lock()
+		call := t.pending[response.Seq]
+		delete(t.pending, response.Seq)
+		t.mtx.Unlock()
+
+		switch {
+		case call == nil:
+			// Abandoned call
+			err = t.codec.ReadResponseBody(nil)
+		case response.Error != "":
+			err = t.codec.ReadResponseBody(nil)
+			call.done(rpc.ServerError(response.Error))
+		default:
+			err = t.codec.ReadResponseBody(call.reply)
+			if err != nil {
+				call.done(errors.New("reading body " + err.Error()))
+			} else {
+				call.done(nil)
+			}
+		}
+	}
+
+	t.mtx.Lock()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if t.closing {
+		err = rpc.ErrShutdown
+	}
+	t.shutdown = err
+	pending := t.pending
+	t.pending = make(map[uint64]*rpcCall)
+	t.mtx.Unlock()
+	for _, call := range pending {
+		call.done(err)
+	}
+}
+
+// Close closes the connection. Pending calls fail with rpc.ErrShutdown.
+func (t *rpcTransport) Close() error {
+	t.mtx.Lock()
+	if t.closing {
+		t.mtx.Unlock()
+		return rpc.ErrShutdown
+	}
+	t.closing = true
+	t.mtx.Unlock()
+	return t.codec.Close()
+}
+
+// dialWebsocket connects to LIT's built-in websocket RPC endpoint, opening
+// the TCP connection with [netDial]
+func dialWebsocket(host string, port int32, netDial func(string, string) (net.Conn, error), stats *connStats) (transport, error) {
+	hostPort := net.JoinHostPort(host, fmt.Sprint(port))
+	config, err := websocket.NewConfig(fmt.Sprintf("ws://%s/ws", hostPort), "http://127.0.0.1/")
+	if err != nil {
+		return nil, err
+	}
+	conn, err := netDial("tcp", hostPort)
+	if err != nil {
+		return nil, err
+	}
+	wsConn, err := websocket.NewClient(config, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return newRPCTransport(jsonrpc.NewClientCodec(&countingConn{wsConn, stats})), nil
+}
+
+// WithSubscriptionConn makes the client open a second connection to the node,
+// dedicated to the polling done by watchers and subscriptions (like
+// WatchContracts and PeerKeeper). This keeps large replies to regular calls
+// (like StateDump) from delaying event delivery, and vice versa. The second
+// connection uses the same transport and key as the first.
+func WithSubscriptionConn() Option {
+	return func(o *clientOptions) {
+		o.subscriptionConn = true
+	}
+}
+
+// subscriptions returns the client to use for polling done by watchers
+func (c *LitRpcClient) subscriptions() *LitRpcClient {
+	if c.sub != nil {
+		return c.sub
+	}
+	return c
+}
@@ -0,0 +1,160 @@
+package litrpcclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// fixture holds the recorded responses to calls of a method with the same arguments
+type fixture struct {
+	Method    string            `json:"method"`
+	Args      json.RawMessage   `json:"args"`
+	Responses []fixtureResponse `json:"responses"`
+}
+
+// fixtureResponse is a single recorded response
+type fixtureResponse struct {
+	Reply json.RawMessage `json:"reply,omitempty"`
+	Error string          `json:"error,omitempty"`
+	// Remote is true if Error was returned by the node
+	Remote bool `json:"remote,omitempty"`
+}
+
+// WithRecording makes the client record every response from the node to
+// fixture files in directory [dir], one file per method and arguments. The
+// fixtures can be replayed with NewReplayClient. All calls are also logged in
+// order to the file "session.jsonl" in [dir], which ReplaySession can send to
+// another node.
+func WithRecording(dir string) Option {
+	return func(o *clientOptions) {
+		o.recordDir = dir
+	}
+}
+
+// NewReplayClient creates a client that doesn't connect to a node, but replays
+// the responses recorded with WithRecording in directory [dir]. Calls with the
+// same method and arguments get the recorded responses in order, after which the
+// last one is repeated. Calls that were not recorded fail with ErrNotSupported.
+func NewReplayClient(dir string) *LitRpcClient {
+	client := new(LitRpcClient)
+	client.conn = &replayTransport{dir: dir, played: make(map[string]int)}
+	return client
+}
+
+// fixturePath returns the file the responses to [method] with [args] are stored in
+func fixturePath(dir, method string, args []byte) string {
+	hash := sha256.Sum256(append([]byte(method), args...))
+	name := strings.Replace(method, ".", "_", -1)
+	return filepath.Join(dir, fmt.Sprintf("%s-%x.json", name, hash[:8]))
+}
+
+// recordTransport records the responses of the wrapped transport
+type recordTransport struct {
+	transport
+	dir string
+	mtx sync.Mutex
+}
+
+func (t *recordTransport) Go(ctx context.Context, method string, args interface{}, reply interface{}, done func(error)) func(error) {
+	return t.transport.Go(ctx, method, args, reply, func(err error) {
+		t.record(method, args, reply, err)
+		done(err)
+	})
+}
+
+// record adds the response to a call of [method] with [args] to its fixture
+// and to the session log
+func (t *recordTransport) record(method string, args interface{}, reply interface{}, callErr error) {
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return
+	}
+	response := fixtureResponse{}
+	if callErr != nil {
+		response.Error = callErr.Error()
+		response.Remote = errors.As(callErr, new(rpc.ServerError))
+	} else {
+		response.Reply, _ = json.Marshal(reply)
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	path := fixturePath(t.dir, method, argsJSON)
+	f := fixture{Method: method, Args: argsJSON}
+	b, err := ioutil.ReadFile(path)
+	if err == nil {
+		json.Unmarshal(b, &f)
+	}
+	f.Responses = append(f.Responses, response)
+	b, err = json.MarshalIndent(f, "", "  ")
+	if err == nil {
+		writeFileAtomic(path, b, 0644)
+	}
+	t.logCall(SessionCall{Method: method, Args: argsJSON, Reply: response.Reply, Error: response.Error, Remote: response.Remote})
+}
+
+// replayTransport answers calls from recorded fixtures
+type replayTransport struct {
+	dir    string
+	mtx    sync.Mutex
+	played map[string]int
+}
+
+func (t *replayTransport) Go(ctx context.Context, method string, args interface{}, reply interface{}, done func(error)) func(error) {
+	done(t.replay(method, args, reply))
+	return noCancel
+}
+
+// replay decodes the next recorded response to [method] with [args] into
+// [reply]
+func (t *replayTransport) replay(method string, args interface{}, reply interface{}) error {
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	path := fixturePath(t.dir, method, argsJSON)
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%s: no recorded response: %w", method, ErrNotSupported)
+	}
+	if err != nil {
+		return err
+	}
+	var f fixture
+	err = json.Unmarshal(b, &f)
+	if err != nil {
+		return err
+	}
+	if len(f.Responses) == 0 {
+		return fmt.Errorf("%s: no recorded response: %w", method, ErrNotSupported)
+	}
+
+	t.mtx.Lock()
+	i := t.played[path]
+	if i < len(f.Responses)-1 {
+		t.played[path]++
+	}
+	t.mtx.Unlock()
+
+	response := f.Responses[i]
+	if response.Remote {
+		return rpc.ServerError(response.Error)
+	}
+	if response.Error != "" {
+		return errors.New(response.Error)
+	}
+	return json.Unmarshal(response.Reply, reply)
+}
+
+func (t *replayTransport) Close() error {
+	return nil
+}
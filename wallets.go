@@ -0,0 +1,43 @@
+package litrpcclient
+
+import "context"
+
+// Wallet is one of the node's wallets. LIT has a single wallet per coin
+// type, with no accounts or sub-wallets within it, so the coin type is the
+// wallet's index: it is what selects the wallet in GetAddresses, NewAddress,
+// FundChannel and the other calls that take a [coinType]. Send and Sweep
+// pick the wallet from the coin type of the destination address.
+type Wallet struct {
+	CoinType   uint32
+	SyncHeight int32
+	// Balance is the total of the wallet's unspent outputs in satoshi
+	Balance int64
+	// ChannelBalance is our total balance in the wallet's channels
+	ChannelBalance int64
+	// FeeRate is the wallet's fee rate in satoshi/byte
+	FeeRate int64
+}
+
+// ListWallets returns the wallets the node runs, one per coin type
+func (c *LitRpcClient) ListWallets() ([]Wallet, error) {
+	return c.ListWalletsCtx(context.Background())
+}
+
+// ListWalletsCtx is like ListWallets, but uses [ctx] for cancellation and deadlines
+func (c *LitRpcClient) ListWalletsCtx(ctx context.Context) ([]Wallet, error) {
+	balances, err := c.ListBalancesCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wallets := make([]Wallet, len(balances))
+	for i, b := range balances {
+		wallets[i] = Wallet{
+			CoinType:       b.CoinType,
+			SyncHeight:     b.SyncHeight,
+			Balance:        b.TxoTotal,
+			ChannelBalance: b.ChanTotal,
+			FeeRate:        b.FeeRate,
+		}
+	}
+	return wallets, nil
+}
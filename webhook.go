@@ -0,0 +1,224 @@
+package litrpcclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mit-dci/lit/litrpc"
+	"github.com/mit-dci/lit/lnutil"
+)
+
+// WebhookEventType is the type of event a webhook is notified of
+type WebhookEventType string
+
+const (
+	// EventPaymentReceived is sent when our balance in a channel increased
+	EventPaymentReceived WebhookEventType = "payment.received"
+	// EventChannelClosed is sent when a channel is closed or broken
+	EventChannelClosed WebhookEventType = "channel.closed"
+	// EventContractSettled is sent when a contract is being settled or is closed
+	EventContractSettled WebhookEventType = "contract.settled"
+)
+
+// WebhookSignatureHeader is the HTTP header that carries the hex encoded
+// HMAC-SHA256 of the request body, keyed with the webhook's Secret
+const WebhookSignatureHeader = "X-Lit-Signature"
+
+// WebhookEvent is the JSON body posted to a webhook
+type WebhookEvent struct {
+	Type WebhookEventType `json:"type"`
+	Time time.Time        `json:"time"`
+	// Receipt describes the payment, for EventPaymentReceived
+	Receipt *PushReceipt `json:"receipt,omitempty"`
+	// Channel is the channel, for EventChannelClosed
+	Channel *litrpc.ChannelInfo `json:"channel,omitempty"`
+	// Contract is the contract, for EventContractSettled
+	Contract *lnutil.DlcContract `json:"contract,omitempty"`
+}
+
+// Webhook is a URL that is notified of events
+type Webhook struct {
+	URL string
+	// Secret is the key the bodies are signed with, see WebhookSignatureHeader.
+	// Bodies are not signed if it is empty.
+	Secret []byte
+	// Events are the event types to send, all types if empty
+	Events []WebhookEventType
+}
+
+// wants returns whether the webhook is notified of events of type [t]
+func (w *Webhook) wants(t WebhookEventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDispatcher posts the events it derives from the refreshes of a
+// Mirror to webhooks. A delivery that fails (an error or a status other
+// than 2xx) is retried Retries times, waiting Backoff between attempts;
+// deliveries that still fail are reported to the client's async error
+// handler.
+type WebhookDispatcher struct {
+	// Retries is the number of times a failed delivery is retried
+	Retries int
+	// Backoff is the time to wait before retrying
+	Backoff Backoff
+	// HTTPClient is the client used for the requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+
+	mirror *Mirror
+	hooks  []Webhook
+}
+
+// NewWebhookDispatcher creates a WebhookDispatcher for the events of the
+// node [mirror] mirrors, that posts them to [hooks]. Call Run to start it.
+func NewWebhookDispatcher(mirror *Mirror, hooks ...Webhook) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		Retries: 3,
+		Backoff: ExponentialBackoff{Min: time.Second, Max: time.Minute},
+		mirror:  mirror,
+		hooks:   hooks,
+	}
+}
+
+// Run compares every snapshot of the mirror to the previous one, and posts
+// an event for every payment received, channel closed and contract settled
+// in between, until [ctx] is cancelled. The mirror has to be running.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	var previous *MirrorSnapshot
+	for snapshot := range d.mirror.Subscribe(ctx) {
+		if previous != nil {
+			for _, event := range snapshotEvents(previous, snapshot) {
+				d.Dispatch(ctx, event)
+			}
+		}
+		previous = snapshot
+	}
+}
+
+// Dispatch posts [event] to every webhook that wants its type
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, event WebhookEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		d.mirror.client.asyncError(err)
+		return
+	}
+	for i := range d.hooks {
+		hook := &d.hooks[i]
+		if !hook.wants(event.Type) {
+			continue
+		}
+		err := d.deliver(ctx, hook, body)
+		if err != nil {
+			d.mirror.client.asyncError(fmt.Errorf("webhook %s: %w", hook.URL, err))
+		}
+	}
+}
+
+// deliver posts [body] to [hook], retrying failures
+func (d *WebhookDispatcher) deliver(ctx context.Context, hook *Webhook, body []byte) error {
+	var err error
+	for attempt := 0; attempt <= d.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(d.Backoff.Delay(attempt - 1)):
+			}
+		}
+		err = d.post(ctx, hook, body)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// post makes a single delivery of [body] to [hook]
+func (d *WebhookDispatcher) post(ctx context.Context, hook *Webhook, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(hook.Secret) > 0 {
+		mac := hmac.New(sha256.New, hook.Secret)
+		mac.Write(body)
+		req.Header.Set(WebhookSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+	httpClient := d.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return nil
+}
+
+// snapshotEvents returns the events that happened between snapshots
+// [before] and [after]
+func snapshotEvents(before, after *MirrorSnapshot) []WebhookEvent {
+	var events []WebhookEvent
+	channels := make(map[uint32]litrpc.ChannelInfo, len(before.Channels))
+	for _, ch := range before.Channels {
+		channels[ch.CIdx] = ch
+	}
+	for i := range after.Channels {
+		ch := &after.Channels[i]
+		prev, ok := channels[ch.CIdx]
+		if !ok {
+			continue
+		}
+		if ch.MyBalance > prev.MyBalance {
+			events = append(events, WebhookEvent{Type: EventPaymentReceived, Time: after.Updated, Receipt: &PushReceipt{
+				ChannelIndex:  ch.CIdx,
+				StateIndex:    ch.StateNum,
+				Amount:        ch.MyBalance - prev.MyBalance,
+				LocalBalance:  ch.MyBalance,
+				RemoteBalance: ch.Capacity - ch.MyBalance,
+				Data:          ch.Data,
+				Time:          after.Updated,
+			}})
+		}
+		if ch.Closed && !prev.Closed {
+			events = append(events, WebhookEvent{Type: EventChannelClosed, Time: after.Updated, Channel: ch})
+		}
+	}
+
+	statuses := make(map[uint64]lnutil.DlcContractStatus, len(before.Contracts))
+	for _, contract := range before.Contracts {
+		statuses[contract.Idx] = contract.Status
+	}
+	for _, contract := range after.Contracts {
+		prev, ok := statuses[contract.Idx]
+		if !ok || prev == contract.Status {
+			continue
+		}
+		// Settling is followed by closed, only the first one is an event
+		kind, _ := contractEventKind(true, contract.Status)
+		prevKind, _ := contractEventKind(true, prev)
+		if kind == ContractSettled && prevKind != ContractSettled {
+			events = append(events, WebhookEvent{Type: EventContractSettled, Time: after.Updated, Contract: contract})
+		}
+	}
+	return events
+}